@@ -9,10 +9,16 @@ import (
 	"vdm/api/routes/articles"
 	"vdm/api/routes/auth"
 	"vdm/api/routes/get_csrf"
+	"vdm/api/routes/governments"
+	"vdm/api/routes/mfa"
 	"vdm/api/routes/moderator"
+	"vdm/api/routes/open_data"
+	"vdm/api/routes/passkeys"
 	"vdm/api/routes/password_update"
 	"vdm/api/routes/politicians"
 	"vdm/api/routes/redactor"
+	"vdm/api/routes/sessions"
+	"vdm/api/routes/stats"
 	"vdm/core/dependencies"
 	"vdm/core/fiberx"
 	"vdm/core/locals/local_keys"
@@ -85,11 +91,17 @@ func Group(deps *dependencies.Dependencies) *fiberx.Group {
 		auth.Group(deps),
 		password_update.Group(deps),
 		politicians.Group(deps),
+		governments.Group(deps),
 		articles.Group(deps),
+		stats.Group(deps),
 
 		locals_authed_user.Middleware(deps.Config.Security),
 		authorize_authed_user.Middleware(deps.GormDB()),
 
+		sessions.Group(deps),
+		mfa.Group(deps),
+		passkeys.Group(deps),
+		open_data.Group(deps),
 		redactor.Group(deps),
 		moderator.Group(deps),
 		admin.Group(deps),
@@ -17,6 +17,7 @@ import (
 	"vdm/test_utils"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/testcontainers/testcontainers-go"
 )
@@ -98,3 +99,50 @@ func TestIntegration_Success(t *testing.T) {
 
 	assert.Equal(t, fiber.StatusNoContent, res.StatusCode)
 }
+
+func TestIntegration_Session(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	session := uuid.New()
+	if err := connector.GormDB().Create(&models.UserToken{UserID: data.user.ID, Hash: "session", Category: models.UserTokenCategoryRefresh,
+		Expiry: time.Now().Add(time.Minute), FamilyID: &session}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiberx.NewApp()
+	locals_authed_user.Middleware(data.cfg).Register(app)
+	Middleware(connector.GormDB()).Register(app)
+	app.Use(func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	jwt, err := jwt_utils.GenerateJWT(locals.AuthedUser{ID: data.user.ID, SessionID: session},
+		data.cfg.AccessTokenSecret, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	get := func() int {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: data.cfg.AccessCookieName, Value: jwt})
+
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+
+		return res.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusNoContent, get())
+
+	// once the session is revoked, its access token is refused before it expires
+	if err := connector.GormDB().Where("family_id = ?", session).Delete(&models.UserToken{}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusUnauthorized, get())
+}
@@ -2,6 +2,7 @@ package authorize_authed_user
 
 import (
 	"vdm/core/models"
+	"vdm/core/session"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -9,6 +10,8 @@ import (
 
 type Repository interface {
 	getUserRoles(userID uuid.UUID) ([]*models.Role, error)
+	sessionIsActive(userID, sessionID uuid.UUID) (bool, error)
+	totpConfirmed(userID uuid.UUID) (bool, error)
 }
 
 type repository struct {
@@ -27,3 +30,20 @@ func (r *repository) getUserRoles(userID uuid.UUID) ([]*models.Role, error) {
 
 	return user.Roles, nil
 }
+
+// sessionIsActive tells whether the session still holds an unconsumed refresh token,
+// which is no longer the case once it has been signed out, revoked, or has expired
+func (r *repository) sessionIsActive(userID, sessionID uuid.UUID) (bool, error) {
+	var active bool
+	err := r.db.Raw(`SELECT EXISTS (
+		SELECT 1 FROM user_tokens
+		WHERE family_id = @sessionID AND user_id = @userID AND category = @category
+		AND consumed_at IS NULL AND expiry > now()
+	)`, map[string]any{"sessionID": sessionID, "userID": userID, "category": models.UserTokenCategoryRefresh}).
+		Scan(&active).Error
+	return active, err
+}
+
+func (r *repository) totpConfirmed(userID uuid.UUID) (bool, error) {
+	return session.TOTPConfirmed(r.db, userID)
+}
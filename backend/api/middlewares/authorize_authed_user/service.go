@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"vdm/core/locals"
 	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 type Service interface {
@@ -15,6 +18,18 @@ type service struct {
 }
 
 func (s *service) authorizeAuthedUser(authedUser *locals.AuthedUser) error {
+	// revoking a session cuts its access right away, instead of when its access token expires.
+	// Access tokens issued before sessions were tracked have no session and expire shortly.
+	if authedUser.SessionID != uuid.Nil {
+		active, err := s.repo.sessionIsActive(authedUser.ID, authedUser.SessionID)
+		if err != nil {
+			return fmt.Errorf("failed to check session: %v", err)
+		}
+		if !active {
+			return &fiber.Error{Code: fiber.StatusUnauthorized, Message: "session revoked"}
+		}
+	}
+
 	roles, err := s.repo.getUserRoles(authedUser.ID)
 	if err != nil {
 		return fmt.Errorf("failed to get user roles: %v", err)
@@ -25,5 +40,9 @@ func (s *service) authorizeAuthedUser(authedUser *locals.AuthedUser) error {
 		authedUser.Roles[i] = roles[i].Name
 	}
 
+	if authedUser.MFAEnabled, err = s.repo.totpConfirmed(authedUser.ID); err != nil {
+		return fmt.Errorf("failed to check totp: %v", err)
+	}
+
 	return nil
 }
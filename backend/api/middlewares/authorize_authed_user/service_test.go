@@ -0,0 +1,55 @@
+package authorize_authed_user
+
+import (
+	"errors"
+	"testing"
+	"vdm/core/locals"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubRepository struct {
+	active     bool
+	mfaEnabled bool
+}
+
+func (stubRepository) getUserRoles(userID uuid.UUID) ([]*models.Role, error) {
+	return []*models.Role{{Name: models.RoleRedactor}}, nil
+}
+
+func (r stubRepository) sessionIsActive(userID, sessionID uuid.UUID) (bool, error) {
+	return r.active, nil
+}
+
+func (r stubRepository) totpConfirmed(userID uuid.UUID) (bool, error) {
+	return r.mfaEnabled, nil
+}
+
+func TestService_AuthorizeAuthedUser(t *testing.T) {
+	svc := &service{repo: stubRepository{active: true, mfaEnabled: true}}
+
+	authedUser := locals.AuthedUser{ID: uuid.New(), SessionID: uuid.New()}
+	if err := svc.authorizeAuthedUser(&authedUser); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []models.RoleName{models.RoleRedactor}, authedUser.Roles)
+	assert.True(t, authedUser.MFAEnabled)
+
+	// access tokens issued before sessions were tracked have no session to check
+	svc = &service{repo: stubRepository{active: false}}
+	assert.NoError(t, svc.authorizeAuthedUser(&locals.AuthedUser{ID: uuid.New()}))
+}
+
+func TestService_AuthorizeAuthedUser_ErrSessionRevoked(t *testing.T) {
+	svc := &service{repo: stubRepository{active: false}}
+
+	err := svc.authorizeAuthedUser(&locals.AuthedUser{ID: uuid.New(), SessionID: uuid.New()})
+
+	var fiberErr *fiber.Error
+	if assert.True(t, errors.As(err, &fiberErr)) {
+		assert.Equal(t, fiber.StatusUnauthorized, fiberErr.Code)
+	}
+}
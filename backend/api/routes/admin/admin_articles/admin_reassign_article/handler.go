@@ -0,0 +1,34 @@
+package admin_reassign_article
+
+import (
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	reassignArticleForAdmin(c *fiber.Ctx) error
+}
+
+type handler struct {
+	svc Service
+}
+
+func (h *handler) reassignArticleForAdmin(c *fiber.Ctx) error {
+	articleID, err := uuid.Parse(c.Params(local_keys.ArticleID))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid article id"}
+	}
+
+	userTag := c.Params(local_keys.UserTag)
+	if len(userTag) < 6 {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid userTag"}
+	}
+
+	if err = h.svc.reassignArticle(articleID, userTag); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,145 @@
+package admin_reassign_article
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	redactor          *models.User
+	inactiveModerator *models.User
+	moderator         *models.User
+	userNoRole        *models.User
+	article           *models.Article
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	moderatorRole := &models.Role{Name: models.RoleModerator}
+	if err = connector.GormDB().Create(moderatorRole).Error; err != nil {
+		return
+	}
+
+	data.redactor = &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(data.redactor).Error; err != nil {
+		return
+	}
+
+	data.inactiveModerator = &models.User{Email: "inactive@test.com", Tag: "inactive0123", Password: "x", Roles: []*models.Role{moderatorRole}}
+	if err = connector.GormDB().Create(data.inactiveModerator).Error; err != nil {
+		return
+	}
+
+	data.moderator = &models.User{Email: "moderator@test.com", Tag: "moderator0123", Password: "x", Roles: []*models.Role{moderatorRole}}
+	if err = connector.GormDB().Create(data.moderator).Error; err != nil {
+		return
+	}
+
+	data.userNoRole = &models.User{Email: "no_role@test.com", Tag: "user_no_role", Password: "x"}
+	if err = connector.GormDB().Create(data.userNoRole).Error; err != nil {
+		return
+	}
+
+	claimedAt := time.Now().Add(-30 * 24 * time.Hour)
+	data.article = &models.Article{
+		RedactorID:  data.redactor.ID,
+		ModeratorID: &data.inactiveModerator.ID,
+		ClaimedAt:   &claimedAt,
+		Title:       "Stuck",
+		Status:      models.ArticleStatusUnderReview,
+		Reference:   uuid.New(),
+		Minor:       1,
+	}
+	err = connector.GormDB().Create(data.article).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/"+data.article.ID.String()+"/moderator/"+data.moderator.Tag, nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNoContent, res.StatusCode)
+
+	var reassigned models.Article
+	if err := connector.GormDB().First(&reassigned, "id = ?", data.article.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if assert.NotNil(t, reassigned.ModeratorID) {
+		assert.Equal(t, data.moderator.ID, *reassigned.ModeratorID)
+	}
+	if assert.NotNil(t, reassigned.ClaimedAt) {
+		assert.True(t, reassigned.ClaimedAt.After(*data.article.ClaimedAt))
+	}
+}
+
+func TestIntegration_ErrBadRequest_NotModerator(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/"+data.article.ID.String()+"/moderator/"+data.userNoRole.Tag, nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestIntegration_ErrNotFound(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	for _, path := range []string{
+		"/" + uuid.New().String() + "/moderator/" + data.moderator.Tag,
+		"/" + data.article.ID.String() + "/moderator/unknown_user_tag",
+	} {
+		req := httptest.NewRequest(Method, path, nil)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	}
+}
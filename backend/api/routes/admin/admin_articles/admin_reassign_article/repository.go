@@ -0,0 +1,48 @@
+package admin_reassign_article
+
+import (
+	"errors"
+	"time"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	findUserByTagWithRole(userTag string, roleName models.RoleName) (*models.User, error)
+	updateArticleModerator(articleID, moderatorID uuid.UUID) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) findUserByTagWithRole(userTag string, roleName models.RoleName) (*models.User, error) {
+	var user models.User
+
+	if err := r.db.Where("tag = ?", userTag).
+		Preload("Roles", "name = ?", roleName).
+		Select("id").
+		First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// updateArticleModerator claims an article under review on behalf of the moderator, whoever held it before.
+// A redactor can never moderate their own article.
+func (r *repository) updateArticleModerator(articleID, moderatorID uuid.UUID) (bool, error) {
+	res := r.db.Model(&models.Article{}).
+		Where("id = ? AND redactor_id <> ? AND status = ?", articleID, moderatorID, models.ArticleStatusUnderReview).
+		// a moderator reviews each version at most once
+		Where("NOT EXISTS (SELECT 1 FROM article_reviews WHERE article_id = articles.id AND moderator_id = ?)", moderatorID).
+		Updates(map[string]any{"moderator_id": moderatorID, "claimed_at": time.Now()})
+
+	return res.RowsAffected > 0, res.Error
+}
@@ -0,0 +1,40 @@
+package admin_reassign_article
+
+import (
+	"fmt"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	reassignArticle(articleID uuid.UUID, userTag string) error
+}
+
+type service struct {
+	repo Repository
+}
+
+func (s *service) reassignArticle(articleID uuid.UUID, userTag string) error {
+	user, err := s.repo.findUserByTagWithRole(userTag, models.RoleModerator)
+	if err != nil {
+		return fmt.Errorf("error finding user by tag: %s", err)
+	}
+	if user == nil {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("user with tag %s not found", userTag)}
+	}
+	if !user.HasRole(models.RoleModerator) {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: fmt.Sprintf("user with tag %s does not have role %s", userTag, models.RoleModerator)}
+	}
+
+	reassigned, err := s.repo.updateArticleModerator(articleID, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update article moderator: %v", err)
+	}
+	if !reassigned {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("no article under review with id %s can be moderated by user %s", articleID, userTag)}
+	}
+
+	return nil
+}
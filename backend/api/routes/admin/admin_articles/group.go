@@ -0,0 +1,19 @@
+package admin_articles
+
+import (
+	"vdm/api/routes/admin/admin_articles/admin_reassign_article"
+	"vdm/core/dependencies"
+	"vdm/core/fiberx"
+)
+
+const Prefix = "/articles"
+
+func Group(deps *dependencies.Dependencies) *fiberx.Group {
+	group := fiberx.NewGroup(Prefix)
+
+	group.Add(
+		admin_reassign_article.Route(deps.GormDB()),
+	)
+
+	return group
+}
@@ -0,0 +1,53 @@
+package admin_clear_lockout
+
+import (
+	"fmt"
+	"net/url"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/lockout"
+	"vdm/core/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	clearLockoutForAdmin(c *fiber.Ctx) error
+}
+
+type handler struct {
+	guard *lockout.Guard
+}
+
+func (h *handler) clearLockoutForAdmin(c *fiber.Ctx) error {
+	authedUser, ok := c.Locals(local_keys.AuthedUser).(locals.AuthedUser)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "can't locals authed user"}
+	}
+
+	// emails and IPv6 addresses may come escaped
+	value, err := url.PathUnescape(c.Params(local_keys.LockoutSubject))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid lockout subject"}
+	}
+
+	subject, ok := lockout.ParseSubject(c.Params(local_keys.LockoutKind), value)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid lockout subject"}
+	}
+
+	found, err := h.guard.Clear(c.UserContext(), subject)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("no failed attempts for %s %s", subject.Kind, subject.Value)}
+	}
+
+	logger.Info("lockout cleared by admin",
+		logger.Any("event", "admin_clear_lockout"),
+		logger.Any("kind", subject.Kind),
+		logger.Any("adminId", authedUser.ID))
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
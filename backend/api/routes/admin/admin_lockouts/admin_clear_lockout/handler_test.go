@@ -0,0 +1,70 @@
+package admin_clear_lockout
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/env"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/lockout"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newApp(guard *lockout.Guard) *fiber.App {
+	h := &handler{guard}
+
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(local_keys.AuthedUser, locals.AuthedUser{ID: uuid.New()})
+		return c.Next()
+	})
+	app.Add(Method, Path, h.clearLockoutForAdmin)
+
+	return app
+}
+
+func TestHandler(t *testing.T) {
+	guard := lockout.New(lockout.NewMemoryStore(), env.LockoutConfig{
+		BackoffAfter:       1,
+		BackoffBase:        time.Minute,
+		BackoffMax:         time.Minute,
+		AccountMaxFailures: 2,
+		IPMaxFailures:      2,
+		Duration:           time.Hour,
+		FailuresTTL:        time.Hour,
+	})
+
+	ctx := context.Background()
+	for range 2 {
+		guard.Fail(ctx, lockout.Account("user@email.com"), lockout.IP("2001:db8::1"))
+	}
+
+	app := newApp(guard)
+
+	for _, tc := range []struct {
+		target   string
+		expected int
+	}{
+		{"/account/User%40email.com", fiber.StatusNoContent},
+		{"/account/user@email.com", fiber.StatusNotFound},
+		{"/ip/2001%3Adb8%3A%3A1", fiber.StatusNoContent},
+		{"/user/user@email.com", fiber.StatusBadRequest},
+		{"/account/%20", fiber.StatusBadRequest},
+	} {
+		res, err := app.Test(httptest.NewRequest(Method, tc.target, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, tc.expected, res.StatusCode, tc.target)
+	}
+
+	assert.NoError(t, guard.Check(ctx, lockout.Account("user@email.com"), lockout.IP("2001:db8::1")))
+}
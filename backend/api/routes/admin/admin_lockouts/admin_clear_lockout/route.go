@@ -0,0 +1,19 @@
+package admin_clear_lockout
+
+import (
+	"vdm/core/fiberx"
+	"vdm/core/locals/local_keys"
+	"vdm/core/lockout"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	Path   = "/:" + local_keys.LockoutKind + "/:" + local_keys.LockoutSubject
+	Method = fiber.MethodDelete
+)
+
+func Route(guard *lockout.Guard) *fiberx.Route {
+	handler := &handler{guard}
+	return fiberx.NewRoute(Method, Path, handler.clearLockoutForAdmin)
+}
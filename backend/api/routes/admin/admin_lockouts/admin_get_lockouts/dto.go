@@ -0,0 +1,15 @@
+package admin_get_lockouts
+
+import (
+	"time"
+	"vdm/core/lockout"
+)
+
+type ResponseDTO struct {
+	Kind          lockout.Kind `json:"kind"`
+	Subject       string       `json:"subject"`
+	Failures      int64        `json:"failures"`
+	LastFailureAt time.Time    `json:"lastFailureAt"`
+	RetryAt       time.Time    `json:"retryAt"`
+	Locked        bool         `json:"locked"`
+}
@@ -0,0 +1,36 @@
+package admin_get_lockouts
+
+import (
+	"vdm/core/lockout"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	getLockoutsForAdmin(c *fiber.Ctx) error
+}
+
+type handler struct {
+	guard *lockout.Guard
+}
+
+func (h *handler) getLockoutsForAdmin(c *fiber.Ctx) error {
+	entries, err := h.guard.Lockouts(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	dto := make([]ResponseDTO, len(entries))
+	for i, entry := range entries {
+		dto[i] = ResponseDTO{
+			Kind:          entry.Kind,
+			Subject:       entry.Value,
+			Failures:      entry.Failures,
+			LastFailureAt: entry.LastFailureAt,
+			RetryAt:       entry.RetryAt,
+			Locked:        entry.Locked,
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto)
+}
@@ -0,0 +1,60 @@
+package admin_get_lockouts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/env"
+	"vdm/core/fiberx"
+	"vdm/core/lockout"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Success(t *testing.T) {
+	guard := lockout.New(lockout.NewMemoryStore(), env.LockoutConfig{
+		BackoffAfter:       1,
+		BackoffBase:        time.Minute,
+		BackoffMax:         time.Minute,
+		AccountMaxFailures: 2,
+		IPMaxFailures:      3,
+		Duration:           time.Hour,
+		FailuresTTL:        time.Hour,
+	})
+
+	ctx := context.Background()
+	guard.Fail(ctx, lockout.Account("user@email.com"), lockout.IP("192.0.2.1"))
+	guard.Fail(ctx, lockout.Account("user@email.com"))
+
+	h := &handler{guard}
+
+	app := fiberx.NewApp()
+	app.Add(Method, Path, h.getLockoutsForAdmin)
+
+	res, err := app.Test(httptest.NewRequest(Method, Path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	var dto []ResponseDTO
+	if err = json.NewDecoder(res.Body).Decode(&dto); err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, dto, 2) {
+		assert.Equal(t, lockout.KindAccount, dto[0].Kind)
+		assert.Equal(t, "user@email.com", dto[0].Subject)
+		assert.Equal(t, int64(2), dto[0].Failures)
+		assert.True(t, dto[0].Locked)
+
+		assert.Equal(t, lockout.KindIP, dto[1].Kind)
+		assert.Equal(t, "192.0.2.1", dto[1].Subject)
+		assert.False(t, dto[1].Locked)
+	}
+}
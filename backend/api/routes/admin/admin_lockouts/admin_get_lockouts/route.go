@@ -0,0 +1,18 @@
+package admin_get_lockouts
+
+import (
+	"vdm/core/fiberx"
+	"vdm/core/lockout"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	Path   = "/"
+	Method = fiber.MethodGet
+)
+
+func Route(guard *lockout.Guard) *fiberx.Route {
+	handler := &handler{guard}
+	return fiberx.NewRoute(Method, Path, handler.getLockoutsForAdmin)
+}
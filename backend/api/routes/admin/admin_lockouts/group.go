@@ -0,0 +1,21 @@
+package admin_lockouts
+
+import (
+	"vdm/api/routes/admin/admin_lockouts/admin_clear_lockout"
+	"vdm/api/routes/admin/admin_lockouts/admin_get_lockouts"
+	"vdm/core/dependencies"
+	"vdm/core/fiberx"
+)
+
+const Prefix = "/lockouts"
+
+func Group(deps *dependencies.Dependencies) *fiberx.Group {
+	group := fiberx.NewGroup(Prefix)
+
+	group.Add(
+		admin_get_lockouts.Route(deps.Lockout),
+		admin_clear_lockout.Route(deps.Lockout),
+	)
+
+	return group
+}
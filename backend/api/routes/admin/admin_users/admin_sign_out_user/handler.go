@@ -0,0 +1,46 @@
+package admin_sign_out_user
+
+import (
+	"fmt"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	signOutUserForAdmin(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) signOutUserForAdmin(c *fiber.Ctx) error {
+	authedUser, ok := c.Locals(local_keys.AuthedUser).(locals.AuthedUser)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "can't locals authed user"}
+	}
+
+	userTag := c.Params(local_keys.UserTag)
+	if len(userTag) < 6 {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid user tag"}
+	}
+
+	// the access tokens of the user are refused as soon as their sessions are gone
+	user, err := h.repo.deleteSessionsByUserTag(userTag)
+	if err != nil {
+		return fmt.Errorf("failed to delete sessions: %v", err)
+	}
+	if user == nil {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("user with tag %s not found", userTag)}
+	}
+
+	logger.Info("user signed out by admin",
+		logger.Any("event", "admin_sign_out_user"),
+		logger.Any("userId", user.ID),
+		logger.Any("adminId", authedUser.ID))
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
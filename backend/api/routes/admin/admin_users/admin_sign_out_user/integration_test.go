@@ -0,0 +1,101 @@
+package admin_sign_out_user
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	admin *models.User
+	user  *models.User
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.admin = &models.User{Email: "admin@test.com", Tag: "admin0123", Password: "x"}
+	data.user = &models.User{Email: "user@test.com", Tag: "user0123", Password: "x"}
+	if err = connector.GormDB().Create([]*models.User{data.admin, data.user}).Error; err != nil {
+		return
+	}
+
+	first, second, admin := uuid.New(), uuid.New(), uuid.New()
+	expiry := time.Now().Add(time.Hour)
+
+	err = connector.GormDB().Create([]*models.UserToken{
+		{UserID: data.user.ID, Hash: "first", Category: models.UserTokenCategoryRefresh, Expiry: expiry, FamilyID: &first},
+		{UserID: data.user.ID, Hash: "second", Category: models.UserTokenCategoryRefresh, Expiry: expiry, FamilyID: &second},
+		{UserID: data.user.ID, Hash: "password", Category: models.UserTokenCategoryPassword, Expiry: expiry},
+		{UserID: data.admin.ID, Hash: "admin", Category: models.UserTokenCategoryRefresh, Expiry: expiry, FamilyID: &admin},
+	}).Error
+	return
+}
+
+func newApp(connector database.Connector, adminID uuid.UUID) *fiber.App {
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(local_keys.AuthedUser, locals.AuthedUser{ID: adminID})
+		return c.Next()
+	})
+	Route(connector.GormDB()).Register(app)
+	return app
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := newApp(connector, data.admin.ID)
+
+	res, err := app.Test(httptest.NewRequest(Method, "/"+data.user.Tag+"/sessions", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNoContent, res.StatusCode)
+
+	// every session of the user is revoked, other tokens and users are left untouched
+	var hashes []string
+	if err = connector.GormDB().Model(&models.UserToken{}).Order("hash").Pluck("hash", &hashes).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"admin", "password"}, hashes)
+}
+
+func TestIntegration_ErrNotFound(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := newApp(connector, data.admin.ID)
+
+	res, err := app.Test(httptest.NewRequest(Method, "/unknown_user_tag/sessions", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
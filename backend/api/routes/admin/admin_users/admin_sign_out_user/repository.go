@@ -0,0 +1,36 @@
+package admin_sign_out_user
+
+import (
+	"vdm/core/models"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	deleteSessionsByUserTag(userTag string) (*models.User, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// deleteSessionsByUserTag deletes every refresh token of the user, it returns nil when there is no such user
+func (r *repository) deleteSessionsByUserTag(userTag string) (*models.User, error) {
+	var users []models.User
+	if err := r.db.Where("tag = ?", userTag).
+		Select("id").
+		Limit(1).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	if err := r.db.Where("user_id = ? AND category = ?", users[0].ID, models.UserTokenCategoryRefresh).
+		Delete(&models.UserToken{}).Error; err != nil {
+		return nil, err
+	}
+
+	return &users[0], nil
+}
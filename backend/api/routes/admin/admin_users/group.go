@@ -5,6 +5,7 @@ import (
 	"vdm/api/routes/admin/admin_users/admin_grant_user_role"
 	"vdm/api/routes/admin/admin_users/admin_revoke_user_role"
 	"vdm/api/routes/admin/admin_users/admin_search_users"
+	"vdm/api/routes/admin/admin_users/admin_sign_out_user"
 	"vdm/core/dependencies"
 	"vdm/core/fiberx"
 )
@@ -19,6 +20,7 @@ func Group(deps *dependencies.Dependencies) *fiberx.Group {
 		admin_find_user.Route(deps.GormDB()),
 		admin_grant_user_role.Route(deps.GormDB()),
 		admin_revoke_user_role.Route(deps.GormDB()),
+		admin_sign_out_user.Route(deps.GormDB()),
 	)
 
 	return group
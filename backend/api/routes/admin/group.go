@@ -2,6 +2,8 @@ package admin
 
 import (
 	"fmt"
+	"vdm/api/routes/admin/admin_articles"
+	"vdm/api/routes/admin/admin_lockouts"
 	"vdm/api/routes/admin/admin_users"
 	"vdm/core/dependencies"
 	"vdm/core/fiberx"
@@ -29,10 +31,17 @@ func Group(deps *dependencies.Dependencies) *fiberx.Group {
 					Message: fmt.Sprintf("user %s does not have role %s", authedUser.ID, models.RoleModerator)}
 			}
 
+			if !authedUser.MFAEnabled {
+				return &fiber.Error{Code: fiber.StatusForbidden,
+					Message: fmt.Sprintf("role %s requires two-factor authentication", models.RoleAdmin)}
+			}
+
 			return c.Next()
 		}),
 
 		admin_users.Group(deps),
+		admin_articles.Group(deps),
+		admin_lockouts.Group(deps),
 	)
 
 	return group
@@ -0,0 +1,145 @@
+package find_published_article
+
+import (
+	"time"
+	"vdm/core/dto/response_dto"
+	"vdm/core/models"
+)
+
+// mimeApplicationLDJSON is negotiated by search engines and aggregators to get the ClaimReview of an article
+const mimeApplicationLDJSON = "application/ld+json"
+
+const siteName = "Vigie du mensonge"
+
+// claimReview follows https://schema.org/ClaimReview
+type claimReview struct {
+	Context       string       `json:"@context"`
+	Type          string       `json:"@type"`
+	URL           string       `json:"url"`
+	Name          string       `json:"name"`
+	ClaimReviewed string       `json:"claimReviewed"`
+	DatePublished time.Time    `json:"datePublished"`
+	DateModified  time.Time    `json:"dateModified"`
+	InLanguage    string       `json:"inLanguage"`
+	Author        organization `json:"author"`
+	ReviewRating  rating       `json:"reviewRating"`
+	ItemReviewed  claim        `json:"itemReviewed"`
+}
+
+type organization struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type person struct {
+	Type     string `json:"@type"`
+	Name     string `json:"name"`
+	JobTitle string `json:"jobTitle,omitempty"`
+}
+
+type rating struct {
+	Type          string `json:"@type"`
+	RatingValue   int    `json:"ratingValue"`
+	BestRating    int    `json:"bestRating"`
+	WorstRating   int    `json:"worstRating"`
+	AlternateName string `json:"alternateName"`
+}
+
+type claim struct {
+	Type          string         `json:"@type"`
+	Author        []person       `json:"author,omitempty"`
+	DatePublished time.Time      `json:"datePublished"`
+	Appearance    []creativeWork `json:"appearance,omitempty"`
+}
+
+type creativeWork struct {
+	Type          string        `json:"@type"`
+	URL           string        `json:"url"`
+	Publisher     *organization `json:"publisher,omitempty"`
+	DatePublished *time.Time    `json:"datePublished,omitempty"`
+	Text          string        `json:"text,omitempty"`
+	HasPart       *clip         `json:"hasPart,omitempty"`
+}
+
+// clip locates the claim in a video
+type clip struct {
+	Type        string `json:"@type"`
+	StartOffset int    `json:"startOffset"`
+}
+
+// ratings are on a 1 to 5 scale, where 5 would be true: a falsehood is not necessarily deliberate and ranks above a lie.
+var ratings = map[models.ArticleCategory]int{
+	models.ArticleCategoryLie:       1,
+	models.ArticleCategoryFalsehood: 2,
+}
+
+var appearanceTypes = map[models.ArticleSourceType]string{
+	models.ArticleSourceTypeVideo:              "VideoObject",
+	models.ArticleSourceTypeOfficialTranscript: "DigitalDocument",
+	models.ArticleSourceTypePressArticle:       "NewsArticle",
+	models.ArticleSourceTypeSocialPost:         "SocialMediaPosting",
+}
+
+func newClaimReview(clientURL string, article response_dto.Article) claimReview {
+	review := claimReview{
+		Context:       "https://schema.org",
+		Type:          "ClaimReview",
+		URL:           clientURL + "/articles/" + article.ID.String(),
+		Name:          article.Title,
+		ClaimReviewed: article.Title,
+		DatePublished: article.UpdatedAt,
+		DateModified:  article.UpdatedAt,
+		InLanguage:    "fr",
+		Author:        organization{Type: "Organization", Name: siteName, URL: clientURL},
+		ReviewRating: rating{
+			Type:          "Rating",
+			RatingValue:   ratings[article.Category],
+			BestRating:    5,
+			WorstRating:   1,
+			AlternateName: article.Category.Label(),
+		},
+		ItemReviewed: claim{
+			Type:          "Claim",
+			DatePublished: article.EventDate,
+		},
+	}
+
+	if article.PublishedAt != nil {
+		review.DatePublished = *article.PublishedAt
+	}
+
+	for _, p := range article.Politicians {
+		review.ItemReviewed.Author = append(review.ItemReviewed.Author, person{Type: "Person", Name: p.FullName, JobTitle: p.Office})
+	}
+
+	for _, source := range article.Sources {
+		appearance := creativeWork{
+			Type:          "CreativeWork",
+			URL:           source.URL,
+			DatePublished: source.PublishedAt,
+			Text:          source.Quote,
+		}
+
+		if t, ok := appearanceTypes[source.Type]; ok {
+			appearance.Type = t
+		}
+
+		if source.Publisher != "" {
+			appearance.Publisher = &organization{Type: "Organization", Name: source.Publisher}
+		}
+
+		if source.TimestampSeconds != nil {
+			appearance.HasPart = &clip{Type: "Clip", StartOffset: *source.TimestampSeconds}
+		}
+
+		// the verbatim quote is a better summary of the claim than the title of the article
+		if source.Quote != "" && review.ClaimReviewed == article.Title {
+			review.ClaimReviewed = source.Quote
+		}
+
+		review.ItemReviewed.Appearance = append(review.ItemReviewed.Appearance, appearance)
+	}
+
+	return review
+}
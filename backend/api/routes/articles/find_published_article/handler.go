@@ -14,7 +14,8 @@ type Handler interface {
 }
 
 type handler struct {
-	repo Repository
+	repo      Repository
+	clientURL string
 }
 
 func (h *handler) findPublishedArticleForUser(c *fiber.Ctx) error {
@@ -32,5 +33,13 @@ func (h *handler) findPublishedArticleForUser(c *fiber.Ctx) error {
 		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("article with id %s not found", articleID)}
 	}
 
-	return c.Status(fiber.StatusOK).JSON(response_dto.NewArticle(*article))
+	c.Vary(fiber.HeaderAccept)
+
+	dto := response_dto.NewArticle(*article)
+
+	if c.Accepts(fiber.MIMEApplicationJSON, mimeApplicationLDJSON) == mimeApplicationLDJSON {
+		return c.Status(fiber.StatusOK).JSON(newClaimReview(h.clientURL, dto), mimeApplicationLDJSON)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto)
 }
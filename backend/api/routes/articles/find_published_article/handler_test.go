@@ -0,0 +1,158 @@
+package find_published_article
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubRepository struct {
+	article *models.Article
+}
+
+func (r *stubRepository) findPublishedArticle(articleID uuid.UUID) (*models.Article, error) {
+	return r.article, nil
+}
+
+func newStubArticle() *models.Article {
+	publishedAt := time.Date(2024, 6, 10, 8, 30, 0, 0, time.UTC)
+	sourcePublishedAt := time.Date(2024, 6, 9, 20, 0, 0, 0, time.UTC)
+	timestamp := 754
+	politician := &models.Politician{ID: uuid.New(), FirstName: "Emmanuel", LastName: "Macron"}
+
+	return &models.Article{
+		ID:          uuid.New(),
+		Reference:   uuid.New(),
+		Title:       "Article about Emmanuel Macron",
+		Category:    models.ArticleCategoryFalsehood,
+		Status:      models.ArticleStatusPublished,
+		EventDate:   sourcePublishedAt,
+		UpdatedAt:   publishedAt,
+		PublishedAt: &publishedAt,
+		Politicians: []*models.Politician{politician},
+		ArticlePoliticians: []*models.ArticlePolitician{
+			{PoliticianID: politician.ID, Office: "Président de la République"},
+		},
+		Sources: []*models.ArticleSource{
+			{URL: "https://example.com/press", Type: models.ArticleSourceTypePressArticle, Publisher: "Le Journal"},
+			{
+				URL:              "https://example.com/video",
+				Type:             models.ArticleSourceTypeVideo,
+				Publisher:        "France 2",
+				PublishedAt:      &sourcePublishedAt,
+				TimestampSeconds: &timestamp,
+				Quote:            "Le chômage n'a jamais été aussi bas",
+			},
+		},
+	}
+}
+
+func newAppWithStubRepo(article *models.Article) *fiber.App {
+	app := fiberx.NewApp()
+	h := &handler{repo: &stubRepository{article}, clientURL: "https://vigie.test"}
+	app.Add(Method, Path, h.findPublishedArticleForUser)
+	return app
+}
+
+func TestHandler_ClaimReview(t *testing.T) {
+	article := newStubArticle()
+	app := newAppWithStubRepo(article)
+
+	req := httptest.NewRequest(Method, "/"+article.ID.String(), nil)
+	req.Header.Set(fiber.HeaderAccept, mimeApplicationLDJSON)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	assert.Equal(t, mimeApplicationLDJSON, res.Header.Get(fiber.HeaderContentType))
+	assert.Equal(t, fiber.HeaderAccept, res.Header.Get(fiber.HeaderVary))
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var review claimReview
+	if err = json.Unmarshal(resBody, &review); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "ClaimReview", review.Type)
+	assert.Equal(t, "https://vigie.test/articles/"+article.ID.String(), review.URL)
+	assert.Equal(t, "Le chômage n'a jamais été aussi bas", review.ClaimReviewed)
+	assert.True(t, article.PublishedAt.Equal(review.DatePublished))
+	assert.Equal(t, rating{Type: "Rating", RatingValue: 2, BestRating: 5, WorstRating: 1, AlternateName: "Contre-vérité"}, review.ReviewRating)
+
+	assert.Equal(t, []person{{Type: "Person", Name: "Emmanuel Macron", JobTitle: "Président de la République"}}, review.ItemReviewed.Author)
+	assert.True(t, article.EventDate.Equal(review.ItemReviewed.DatePublished))
+
+	if assert.Len(t, review.ItemReviewed.Appearance, 2) {
+		press, video := review.ItemReviewed.Appearance[0], review.ItemReviewed.Appearance[1]
+
+		assert.Equal(t, "NewsArticle", press.Type)
+		assert.Equal(t, &organization{Type: "Organization", Name: "Le Journal"}, press.Publisher)
+		assert.Nil(t, press.HasPart)
+
+		assert.Equal(t, "VideoObject", video.Type)
+		assert.Equal(t, "https://example.com/video", video.URL)
+		assert.Equal(t, &clip{Type: "Clip", StartOffset: 754}, video.HasPart)
+	}
+}
+
+func TestHandler_DefaultsToJSON(t *testing.T) {
+	article := newStubArticle()
+	app := newAppWithStubRepo(article)
+
+	for _, accept := range []string{"", "*/*", fiber.MIMEApplicationJSON, "application/json, application/ld+json;q=0.5"} {
+		req := httptest.NewRequest(Method, "/"+article.ID.String(), nil)
+		req.Header.Set(fiber.HeaderAccept, accept)
+
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode, accept)
+		assert.Equal(t, fiber.MIMEApplicationJSON, res.Header.Get(fiber.HeaderContentType), accept)
+	}
+}
+
+func TestClaimReview_WithoutQuote(t *testing.T) {
+	article := newStubArticle()
+	article.Category = models.ArticleCategoryLie
+	article.Sources = nil
+
+	app := newAppWithStubRepo(article)
+
+	req := httptest.NewRequest(Method, "/"+article.ID.String(), nil)
+	req.Header.Set(fiber.HeaderAccept, mimeApplicationLDJSON)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var review claimReview
+	if err = json.NewDecoder(res.Body).Decode(&review); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, article.Title, review.ClaimReviewed)
+	assert.Equal(t, 1, review.ReviewRating.RatingValue)
+	assert.Equal(t, "Mensonge", review.ReviewRating.AlternateName)
+	assert.Empty(t, review.ItemReviewed.Appearance)
+}
@@ -64,7 +64,7 @@ func TestIntegration_Success(t *testing.T) {
 	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
 
 	app := fiberx.NewApp()
-	Route(connector.GormDB()).Register(app)
+	Route("https://vigie.test", connector.GormDB()).Register(app)
 
 	req := httptest.NewRequest(Method, "/"+data.article.ID.String(), nil)
 
@@ -93,13 +93,43 @@ func TestIntegration_Success(t *testing.T) {
 	assert.Equal(t, 1, len(resDTO.Tags))
 }
 
+func TestIntegration_Success_ClaimReview(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route("https://vigie.test", connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/"+data.article.ID.String(), nil)
+	req.Header.Set(fiber.HeaderAccept, mimeApplicationLDJSON)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code 200, got %d", res.StatusCode)
+	}
+
+	var review claimReview
+	if err = json.NewDecoder(res.Body).Decode(&review); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "https://vigie.test/articles/"+data.article.ID.String(), review.URL)
+	assert.Equal(t, []person{{Type: "Person", Name: "Emmanuel Macron"}}, review.ItemReviewed.Author)
+}
+
 func TestIntegration_ErrNotFound(t *testing.T) {
 	c := context.Background()
 	container, connector, _ := loadTestData(c, t)
 	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
 
 	app := fiberx.NewApp()
-	Route(connector.GormDB()).Register(app)
+	Route("https://vigie.test", connector.GormDB()).Register(app)
 
 	req := httptest.NewRequest(Method, "/"+uuid.New().String(), nil)
 
@@ -118,7 +148,7 @@ func TestIntegration_BadRequest(t *testing.T) {
 	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
 
 	app := fiberx.NewApp()
-	Route(connector.GormDB()).Register(app)
+	Route("https://vigie.test", connector.GormDB()).Register(app)
 
 	req := httptest.NewRequest(Method, "/not-a-uuid", nil)
 
@@ -25,6 +25,7 @@ func (r *repository) findPublishedArticle(articleID uuid.UUID) (*models.Article,
 		Preload("Politicians", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "first_name", "last_name")
 		}).
+		Preload("ArticlePoliticians").
 		First(&article).Error; err != nil {
 
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -13,8 +13,9 @@ const (
 	Method = fiber.MethodGet
 )
 
-func Route(db *gorm.DB) *fiberx.Route {
+// Route serves a published article, or its ClaimReview when application/ld+json is negotiated. Links point to the client at clientURL.
+func Route(clientURL string, db *gorm.DB) *fiberx.Route {
 	repo := &repository{db}
-	handler := &handler{repo}
+	handler := &handler{repo, clientURL}
 	return fiberx.NewRoute(Method, Path, handler.findPublishedArticleForUser)
 }
@@ -0,0 +1,24 @@
+package get_published_article_versions
+
+import (
+	"time"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+)
+
+type VersionDTO struct {
+	ID             uuid.UUID  `json:"id"`
+	Major          int16      `json:"major"`
+	PublishedAt    *time.Time `json:"publishedAt,omitempty"`
+	CorrectionNote string     `json:"correctionNote,omitempty"`
+}
+
+func newVersionDTO(article models.Article) VersionDTO {
+	return VersionDTO{
+		ID:             article.ID,
+		Major:          article.Major,
+		PublishedAt:    article.PublishedAt,
+		CorrectionNote: article.CorrectionNote,
+	}
+}
@@ -0,0 +1,39 @@
+package get_published_article_versions
+
+import (
+	"fmt"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	getPublishedArticleVersions(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) getPublishedArticleVersions(c *fiber.Ctx) error {
+	articleID, err := uuid.Parse(c.Params(local_keys.ArticleID))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid article id"}
+	}
+
+	versions, err := h.repo.getPublishedVersions(articleID)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("article with id %s not found", articleID)}
+	}
+
+	resDTO := make([]VersionDTO, len(versions))
+	for i, version := range versions {
+		resDTO[i] = newVersionDTO(version)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resDTO)
+}
@@ -0,0 +1,144 @@
+package get_published_article_versions
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	articles []*models.Article
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	reference := uuid.New()
+	firstPublication := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	secondPublication := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	data.articles = []*models.Article{
+		{
+			RedactorID:  redactor.ID,
+			Title:       "First published version",
+			Status:      models.ArticleStatusArchived,
+			Category:    models.ArticleCategoryLie,
+			Reference:   reference,
+			Major:       1,
+			PublishedAt: &firstPublication,
+		},
+		{
+			RedactorID:     redactor.ID,
+			Title:          "Second published version",
+			Status:         models.ArticleStatusPublished,
+			Category:       models.ArticleCategoryLie,
+			Reference:      reference,
+			Major:          2,
+			PublishedAt:    &secondPublication,
+			CorrectionNote: "The quoted figure was taken from an outdated report.",
+		},
+		{
+			RedactorID: redactor.ID,
+			Title:      "Draft of the next version",
+			Status:     models.ArticleStatusDraft,
+			Category:   models.ArticleCategoryLie,
+			Reference:  reference,
+			Major:      2,
+			Minor:      1,
+		},
+	}
+
+	err = connector.GormDB().Create(&data.articles).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	// the history can be fetched from the current version as well as from a superseded one
+	for _, article := range data.articles[:2] {
+		req := httptest.NewRequest(Method, "/"+article.ID.String()+"/versions", nil)
+
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected status code 200, got %d", res.StatusCode)
+		}
+
+		resBody, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var resDTO []VersionDTO
+		if err = json.Unmarshal(resBody, &resDTO); err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Equal(t, 2, len(resDTO)) {
+			assert.Equal(t, data.articles[1].ID, resDTO[0].ID)
+			assert.Equal(t, data.articles[1].CorrectionNote, resDTO[0].CorrectionNote)
+			assert.True(t, data.articles[1].PublishedAt.Equal(*resDTO[0].PublishedAt))
+
+			assert.Equal(t, data.articles[0].ID, resDTO[1].ID)
+			assert.Empty(t, resDTO[1].CorrectionNote)
+		}
+	}
+}
+
+func TestIntegration_ErrNotFound(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	// unpublished versions do not expose the history
+	for _, articleID := range []uuid.UUID{data.articles[2].ID, uuid.New()} {
+		req := httptest.NewRequest(Method, "/"+articleID.String()+"/versions", nil)
+
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	}
+}
@@ -0,0 +1,39 @@
+package get_published_article_versions
+
+import (
+	"fmt"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	getPublishedVersions(articleID uuid.UUID) ([]models.Article, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// published versions are the only ones with minor = 0, since publishing increments major and resets minor
+const publishedVersion = "major > 0 AND minor = 0"
+
+// getPublishedVersions returns every published version sharing the reference of the given article, latest first.
+// The given article may be the current version or any superseded one.
+func (r *repository) getPublishedVersions(articleID uuid.UUID) ([]models.Article, error) {
+	reference := r.db.Model(&models.Article{}).
+		Select("reference").
+		Where("id = ? AND "+publishedVersion, articleID)
+
+	var versions []models.Article
+
+	if err := r.db.Where("reference = (?) AND "+publishedVersion, reference).
+		Select("id", "major", "published_at", "correction_note").
+		Order("major DESC").
+		Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to find published versions of article %s: %v", articleID, err)
+	}
+
+	return versions, nil
+}
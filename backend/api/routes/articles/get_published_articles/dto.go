@@ -0,0 +1,144 @@
+package get_published_articles
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+	"vdm/core/dto/response_dto"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 50
+)
+
+// QueryParams are the query params read by the route, the only ones its cached responses are told apart by
+var QueryParams = []string{"category", "politicianId", "partyId", "tag", "from", "to", "cursor", "limit"}
+
+type RequestDTO struct {
+	Category     string `query:"category"`
+	PoliticianID string `query:"politicianId"`
+	PartyID      string `query:"partyId"`
+	Tag          string `query:"tag" validate:"max=50"`
+	From         string `query:"from"`
+	To           string `query:"to"`
+	Cursor       string `query:"cursor"`
+	Limit        int    `query:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+type ResponseDTO struct {
+	Articles   []response_dto.Article `json:"articles"`
+	NextCursor string                 `json:"nextCursor,omitempty"`
+}
+
+// cursor points to the last article of a page, articles are sorted by event date then id (both descending)
+type cursor struct {
+	EventDate time.Time
+	ID        uuid.UUID
+}
+
+type filter struct {
+	category     models.ArticleCategory
+	politicianID uuid.UUID
+	partyID      uuid.UUID
+	tag          string
+	from         time.Time
+	to           time.Time
+	after        *cursor
+	limit        int
+}
+
+func (dto RequestDTO) toFilter() (filter, error) {
+	f := filter{
+		category: models.ArticleCategory(dto.Category),
+		tag:      dto.Tag,
+		limit:    dto.Limit,
+	}
+
+	if f.limit == 0 {
+		f.limit = defaultLimit
+	}
+
+	if f.category != "" && !f.category.Valid() {
+		return filter{}, fmt.Errorf("invalid category: %s", dto.Category)
+	}
+
+	if dto.PoliticianID != "" {
+		politicianID, err := uuid.Parse(dto.PoliticianID)
+		if err != nil {
+			return filter{}, fmt.Errorf("invalid politician id: %s", dto.PoliticianID)
+		}
+		f.politicianID = politicianID
+	}
+
+	if dto.PartyID != "" {
+		partyID, err := uuid.Parse(dto.PartyID)
+		if err != nil {
+			return filter{}, fmt.Errorf("invalid party id: %s", dto.PartyID)
+		}
+		f.partyID = partyID
+	}
+
+	if dto.From != "" {
+		from, err := time.Parse(time.DateOnly, dto.From)
+		if err != nil {
+			return filter{}, fmt.Errorf("invalid from date: %s", dto.From)
+		}
+		f.from = from
+	}
+
+	if dto.To != "" {
+		to, err := time.Parse(time.DateOnly, dto.To)
+		if err != nil {
+			return filter{}, fmt.Errorf("invalid to date: %s", dto.To)
+		}
+		// to is inclusive
+		f.to = to.AddDate(0, 0, 1)
+	}
+
+	if !f.from.IsZero() && !f.to.IsZero() && !f.from.Before(f.to) {
+		return filter{}, fmt.Errorf("from date must not be after to date")
+	}
+
+	if dto.Cursor != "" {
+		after, err := decodeCursor(dto.Cursor)
+		if err != nil {
+			return filter{}, err
+		}
+		f.after = &after
+	}
+
+	return f, nil
+}
+
+func encodeCursor(c cursor) string {
+	raw := c.EventDate.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(encoded string) (cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	eventDate, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return cursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	var c cursor
+
+	if c.EventDate, err = time.Parse(time.RFC3339Nano, eventDate); err != nil {
+		return cursor{}, fmt.Errorf("invalid cursor")
+	}
+	if c.ID, err = uuid.Parse(id); err != nil {
+		return cursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	return c, nil
+}
@@ -19,12 +19,6 @@ func Group(db *gorm.DB) *fiberx.Group {
 	group := fiberx.NewGroup(Path)
 
 	group.Add(
-		// TODO: enable caching in prod
-		//fiberx.NewMiddleware(cache.New(cache.Config{
-		//	CacheControl: true,
-		//	Expiration:   24 * time.Hour,
-		//})),
-
 		fiberx.NewRoute(Method, Path, handler.getPublishedArticles),
 	)
 
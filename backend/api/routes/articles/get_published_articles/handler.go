@@ -3,6 +3,7 @@ package get_published_articles
 import (
 	"fmt"
 	"vdm/core/dto/response_dto"
+	"vdm/core/validation"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -16,15 +17,37 @@ type handler struct {
 }
 
 func (h *handler) getPublishedArticles(c *fiber.Ctx) error {
-	articles, err := h.repo.getPublishedArticles()
+	var reqDTO RequestDTO
+	if err := c.QueryParser(&reqDTO); err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid query params"}
+	}
+	if err := validation.Validate(reqDTO); err != nil {
+		return err
+	}
+
+	f, err := reqDTO.toFilter()
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: err.Error()}
+	}
+
+	// one extra article tells whether there is a next page
+	articles, err := h.repo.getPublishedArticles(f, f.limit+1)
 	if err != nil {
 		return fmt.Errorf("failed to get published articles: %v", err)
 	}
 
-	respDTO := make([]response_dto.Article, len(articles))
+	var respDTO ResponseDTO
+
+	if len(articles) > f.limit {
+		articles = articles[:f.limit]
+		last := articles[len(articles)-1]
+		respDTO.NextCursor = encodeCursor(cursor{EventDate: last.EventDate, ID: last.ID})
+	}
+
+	respDTO.Articles = make([]response_dto.Article, len(articles))
 
 	for i := range articles {
-		respDTO[i] = response_dto.NewArticle(articles[i])
+		respDTO.Articles[i] = response_dto.NewArticle(articles[i])
 	}
 
 	return c.Status(fiber.StatusOK).JSON(respDTO)
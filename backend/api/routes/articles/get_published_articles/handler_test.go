@@ -0,0 +1,61 @@
+package get_published_articles
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type nullRepository struct{}
+
+func (*nullRepository) getPublishedArticles(f filter, limit int) ([]models.Article, error) {
+	return nil, nil
+}
+
+func newAppWithNullRepo() *fiber.App {
+	app := fiberx.NewApp()
+	h := &handler{repo: &nullRepository{}}
+	app.Add(Method, Path, h.getPublishedArticles)
+	return app
+}
+
+func TestHandler_ErrBadRequest(t *testing.T) {
+	app := newAppWithNullRepo()
+
+	for _, target := range []string{
+		Path + "?category=DRAFT",
+		Path + "?politicianId=not-a-uuid",
+		Path + "?partyId=not-a-uuid",
+		Path + "?from=01/01/2020",
+		Path + "?from=2021-01-01&to=2020-01-01",
+		Path + "?limit=51",
+		Path + "?cursor=not-a-cursor",
+	} {
+		req := httptest.NewRequest(Method, target, nil)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode, target)
+	}
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	expected := cursor{EventDate: time.Date(2024, 6, 9, 20, 0, 0, 0, time.UTC), ID: uuid.New()}
+
+	actual, err := decodeCursor(encodeCursor(expected))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, expected.EventDate.Equal(actual.EventDate))
+	assert.Equal(t, expected.ID, actual.ID)
+}
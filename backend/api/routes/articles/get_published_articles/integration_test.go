@@ -2,11 +2,13 @@ package get_published_articles
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"io"
 	"net/http/httptest"
 	"slices"
 	"testing"
+	"time"
 	"vdm/core/dependencies/database"
 	"vdm/core/dto/response_dto"
 	"vdm/core/fiberx"
@@ -58,6 +60,8 @@ func loadTestData(c context.Context, t *testing.T) (container testcontainers.Con
 			Politicians: []*models.Politician{data.politicians[0]},
 			Tags:        []*models.ArticleTag{{Tag: "Nicolas Sarkozy"}},
 			Status:      models.ArticleStatusPublished,
+			Category:    models.ArticleCategoryLie,
+			EventDate:   time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
 		},
 		{
 			RedactorID:  redactor.ID,
@@ -65,12 +69,16 @@ func loadTestData(c context.Context, t *testing.T) (container testcontainers.Con
 			Politicians: []*models.Politician{data.politicians[1]},
 			Tags:        []*models.ArticleTag{{Tag: "François Hollande"}},
 			Status:      models.ArticleStatusPublished,
+			Category:    models.ArticleCategoryFalsehood,
+			EventDate:   time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
 		}, {
 			RedactorID:  redactor.ID,
 			Title:       "Article about Emmanuel Macron",
 			Politicians: []*models.Politician{data.politicians[2]},
 			Tags:        []*models.ArticleTag{{Tag: "Emmanuel Macron"}},
 			Status:      models.ArticleStatusPublished,
+			Category:    models.ArticleCategoryLie,
+			EventDate:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
 		},
 	}
 
@@ -87,7 +95,109 @@ func TestIntegration_Success(t *testing.T) {
 	app := fiberx.NewApp()
 	Group(connector.GormDB()).Register(app)
 
-	req := httptest.NewRequest(Method, Path, nil)
+	resDTO := getPublishedArticles(t, app, Path)
+
+	assert.Equal(t, len(data.articles), len(resDTO.Articles))
+	assert.Empty(t, resDTO.NextCursor)
+
+	for range data.articles {
+		assert.True(t, slices.ContainsFunc(resDTO.Articles, func(dto response_dto.Article) bool {
+			return len(dto.Tags) == 1 && len(dto.Politicians) == 1
+		}))
+	}
+}
+
+func TestIntegration_Success_Pagination(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Group(connector.GormDB()).Register(app)
+
+	firstPage := getPublishedArticles(t, app, Path+"?limit=2")
+	if assert.Equal(t, 2, len(firstPage.Articles)) {
+		assert.Equal(t, data.articles[2].ID, firstPage.Articles[0].ID)
+		assert.Equal(t, data.articles[1].ID, firstPage.Articles[1].ID)
+	}
+	assert.NotEmpty(t, firstPage.NextCursor)
+
+	secondPage := getPublishedArticles(t, app, Path+"?limit=2&cursor="+firstPage.NextCursor)
+	if assert.Equal(t, 1, len(secondPage.Articles)) {
+		assert.Equal(t, data.articles[0].ID, secondPage.Articles[0].ID)
+	}
+	assert.Empty(t, secondPage.NextCursor)
+}
+
+func TestIntegration_Success_Filters(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Group(connector.GormDB()).Register(app)
+
+	resDTO := getPublishedArticles(t, app, Path+"?category=LIE")
+	assert.Equal(t, 2, len(resDTO.Articles))
+
+	resDTO = getPublishedArticles(t, app, Path+"?politicianId="+data.politicians[1].ID.String())
+	if assert.Equal(t, 1, len(resDTO.Articles)) {
+		assert.Equal(t, data.articles[1].ID, resDTO.Articles[0].ID)
+	}
+
+	resDTO = getPublishedArticles(t, app, Path+"?tag=Emmanuel%20Macron")
+	if assert.Equal(t, 1, len(resDTO.Articles)) {
+		assert.Equal(t, data.articles[2].ID, resDTO.Articles[0].ID)
+	}
+
+	resDTO = getPublishedArticles(t, app, Path+"?from=2010-01-01&to=2015-01-01")
+	assert.Equal(t, 2, len(resDTO.Articles))
+
+	resDTO = getPublishedArticles(t, app, Path+"?category=FALSEHOOD&from=2016-01-01")
+	assert.Equal(t, 0, len(resDTO.Articles))
+}
+
+func TestIntegration_Success_PartyFilter(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	parties := []*models.Party{
+		{Code: "UMP", Name: "Union pour un mouvement populaire"},
+		{Code: "LR", Name: "Les Républicains"},
+	}
+	if err := connector.GormDB().Create(&parties).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	refoundation := time.Date(2015, 5, 30, 0, 0, 0, 0, time.UTC)
+	if err := connector.GormDB().Create([]*models.PoliticianAffiliation{
+		{
+			PoliticianID: data.politicians[0].ID,
+			PartyID:      parties[0].ID,
+			StartDate:    time.Date(2002, 11, 17, 0, 0, 0, 0, time.UTC),
+			EndDate:      sql.NullTime{Time: refoundation, Valid: true},
+		},
+		{PoliticianID: data.politicians[0].ID, PartyID: parties[1].ID, StartDate: refoundation},
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiberx.NewApp()
+	Group(connector.GormDB()).Register(app)
+
+	// the article about Nicolas Sarkozy dates back to his UMP years
+	resDTO := getPublishedArticles(t, app, Path+"?partyId="+parties[0].ID.String())
+	if assert.Equal(t, 1, len(resDTO.Articles)) {
+		assert.Equal(t, data.articles[0].ID, resDTO.Articles[0].ID)
+	}
+
+	resDTO = getPublishedArticles(t, app, Path+"?partyId="+parties[1].ID.String())
+	assert.Equal(t, 0, len(resDTO.Articles))
+}
+
+func getPublishedArticles(t *testing.T, app *fiber.App, target string) ResponseDTO {
+	req := httptest.NewRequest(Method, target, nil)
 
 	res, err := app.Test(req)
 	if err != nil {
@@ -104,16 +214,10 @@ func TestIntegration_Success(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	var resDTO []response_dto.Article
+	var resDTO ResponseDTO
 	if err = json.Unmarshal(resBody, &resDTO); err != nil {
 		t.Fatal(err)
 	}
 
-	assert.Equal(t, len(data.articles), len(resDTO))
-
-	for range data.articles {
-		assert.True(t, slices.ContainsFunc(resDTO, func(dto response_dto.Article) bool {
-			return len(dto.Tags) == 1 && len(dto.Politicians) == 1
-		}))
-	}
+	return resDTO
 }
@@ -3,22 +3,61 @@ package get_published_articles
 import (
 	"vdm/core/models"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
-	getPublishedArticles() ([]models.Article, error)
+	getPublishedArticles(f filter, limit int) ([]models.Article, error)
 }
 
 type repository struct {
 	db *gorm.DB
 }
 
-func (r *repository) getPublishedArticles() ([]models.Article, error) {
+func (r *repository) getPublishedArticles(f filter, limit int) ([]models.Article, error) {
 	var articles []models.Article
 
-	if err := r.db.Where("status = ?", models.ArticleStatusPublished).
-		Order("created_at DESC").
+	query := r.db.Where("status = ?", models.ArticleStatusPublished)
+
+	if f.category != "" {
+		query = query.Where("category = ?", f.category)
+	}
+
+	if f.politicianID != uuid.Nil {
+		query = query.Where("EXISTS (SELECT 1 FROM article_politicians ap WHERE ap.article_id = articles.id AND ap.politician_id = ?)", f.politicianID)
+	}
+
+	// the party of a politician is the one they were affiliated to at the event date
+	if f.partyID != uuid.Nil {
+		query = query.Where(`EXISTS (SELECT 1
+              FROM article_politicians ap
+                       JOIN politician_affiliations pa ON pa.politician_id = ap.politician_id AND pa.deleted_at IS NULL
+              WHERE ap.article_id = articles.id
+                AND pa.party_id = ?
+                AND pa.start_date <= articles.event_date
+                AND (pa.end_date IS NULL OR pa.end_date > articles.event_date))`, f.partyID)
+	}
+
+	if f.tag != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM article_tags t WHERE t.article_id = articles.id AND t.tag = ?)", f.tag)
+	}
+
+	if !f.from.IsZero() {
+		query = query.Where("event_date >= ?", f.from)
+	}
+
+	if !f.to.IsZero() {
+		query = query.Where("event_date < ?", f.to)
+	}
+
+	if f.after != nil {
+		query = query.Where("(event_date, id) < (?, ?)", f.after.EventDate, f.after.ID)
+	}
+
+	if err := query.
+		Order("event_date DESC, id DESC").
+		Limit(limit).
 		Select("id", "title", "event_date", "updated_at", "category").
 		Preload("Politicians", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "first_name", "last_name")
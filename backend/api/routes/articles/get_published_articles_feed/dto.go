@@ -0,0 +1,44 @@
+package get_published_articles_feed
+
+import (
+	"fmt"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+)
+
+// feedSize is the number of latest published articles listed in a feed
+const feedSize = 50
+
+type RequestDTO struct {
+	Category     string `query:"category"`
+	PoliticianID string `query:"politicianId"`
+	Tag          string `query:"tag" validate:"max=50"`
+}
+
+type filter struct {
+	category     models.ArticleCategory
+	politicianID uuid.UUID
+	tag          string
+}
+
+func (dto RequestDTO) toFilter() (filter, error) {
+	f := filter{
+		category: models.ArticleCategory(dto.Category),
+		tag:      dto.Tag,
+	}
+
+	if f.category != "" && !f.category.Valid() {
+		return filter{}, fmt.Errorf("invalid category: %s", dto.Category)
+	}
+
+	if dto.PoliticianID != "" {
+		politicianID, err := uuid.Parse(dto.PoliticianID)
+		if err != nil {
+			return filter{}, fmt.Errorf("invalid politician id: %s", dto.PoliticianID)
+		}
+		f.politicianID = politicianID
+	}
+
+	return f, nil
+}
@@ -0,0 +1,343 @@
+package get_published_articles_feed
+
+import (
+	"encoding/xml"
+	"time"
+	"unicode/utf8"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	formatAtom = "atom"
+	formatRSS  = "rss"
+	formatJSON = "json"
+
+	contentTypeAtom = "application/atom+xml; charset=utf-8"
+	contentTypeRSS  = "application/rss+xml; charset=utf-8"
+	contentTypeJSON = "application/feed+json; charset=utf-8"
+)
+
+const (
+	siteName = "Vigie du mensonge"
+	language = "fr-FR"
+
+	// schemes qualify the categories of an entry, which mix the article category, its tags and its politicians
+	schemeCategory   = "urn:vigie-du-mensonge:category"
+	schemeTag        = "urn:vigie-du-mensonge:tag"
+	schemePolitician = "urn:vigie-du-mensonge:politician"
+
+	summaryLength = 280
+)
+
+// feed is the format-agnostic content of a feed
+type feed struct {
+	title   string
+	selfURL string
+	homeURL string
+	updated time.Time
+	entries []entry
+}
+
+type entry struct {
+	id          string
+	url         string
+	title       string
+	summary     string
+	content     string
+	eventDate   time.Time
+	published   time.Time
+	updated     time.Time
+	category    models.ArticleCategory
+	tags        []string
+	politicians []politician
+}
+
+type politician struct {
+	ID       uuid.UUID `json:"id"`
+	FullName string    `json:"fullName"`
+}
+
+func newEntry(clientURL string, article models.Article) entry {
+	e := entry{
+		// the reference identifies the article across its versions, so that a correction updates the entry
+		id:        "urn:uuid:" + article.Reference.String(),
+		url:       clientURL + "/articles/" + article.ID.String(),
+		title:     article.Title,
+		summary:   truncate(article.Body, summaryLength),
+		content:   article.Body,
+		eventDate: article.EventDate,
+		published: article.UpdatedAt,
+		updated:   article.UpdatedAt,
+		category:  article.Category,
+	}
+
+	if article.PublishedAt != nil {
+		e.published = *article.PublishedAt
+	}
+
+	if article.CorrectionNote != "" {
+		e.content += "\n\nCorrection : " + article.CorrectionNote
+	}
+
+	for _, tag := range article.Tags {
+		e.tags = append(e.tags, tag.Tag)
+	}
+
+	for _, p := range article.Politicians {
+		e.politicians = append(e.politicians, politician{ID: p.ID, FullName: p.FirstName + " " + p.LastName})
+	}
+
+	return e
+}
+
+func truncate(s string, length int) string {
+	if utf8.RuneCountInString(s) <= length {
+		return s
+	}
+	return string([]rune(s)[:length]) + "…"
+}
+
+// Atom 1.0, RFC 4287
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Lang    string      `xml:"xml:lang,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomPerson  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term   string `xml:"term,attr"`
+	Scheme string `xml:"scheme,attr"`
+	Label  string `xml:"label,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Links      []atomLink     `xml:"link"`
+	Summary    atomText       `xml:"summary"`
+	Content    atomText       `xml:"content"`
+	Categories []atomCategory `xml:"category"`
+}
+
+func (f feed) atom() ([]byte, error) {
+	out := atomFeed{
+		Lang:    language,
+		ID:      f.selfURL,
+		Title:   f.title,
+		Updated: f.updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: f.selfURL},
+			{Rel: "alternate", Type: "text/html", Href: f.homeURL},
+		},
+		Author: atomPerson{Name: siteName, URI: f.homeURL},
+	}
+
+	for _, e := range f.entries {
+		categories := []atomCategory{{Term: string(e.category), Scheme: schemeCategory, Label: e.category.Label()}}
+		for _, tag := range e.tags {
+			categories = append(categories, atomCategory{Term: tag, Scheme: schemeTag})
+		}
+		for _, p := range e.politicians {
+			categories = append(categories, atomCategory{Term: p.ID.String(), Scheme: schemePolitician, Label: p.FullName})
+		}
+
+		out.Entries = append(out.Entries, atomEntry{
+			ID:         e.id,
+			Title:      e.title,
+			Updated:    e.updated.UTC().Format(time.RFC3339),
+			Published:  e.published.UTC().Format(time.RFC3339),
+			Links:      []atomLink{{Rel: "alternate", Type: "text/html", Href: e.url}},
+			Summary:    atomText{Type: "text", Body: e.summary},
+			Content:    atomText{Type: "text", Body: e.content},
+			Categories: categories,
+		})
+	}
+
+	return marshalXML(out)
+}
+
+// RSS 2.0
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Self          atomLink  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssCategory struct {
+	Domain string `xml:"domain,attr"`
+	Value  string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Description string        `xml:"description"`
+	Categories  []rssCategory `xml:"category"`
+}
+
+func (f feed) rss() ([]byte, error) {
+	out := rssFeed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         f.title,
+			Link:          f.homeURL,
+			Description:   "Les derniers mensonges et contre-vérités vérifiés par " + siteName,
+			Language:      language,
+			LastBuildDate: f.updated.UTC().Format(time.RFC1123Z),
+			Self:          atomLink{Rel: "self", Type: "application/rss+xml", Href: f.selfURL},
+		},
+	}
+
+	for _, e := range f.entries {
+		categories := []rssCategory{{Domain: schemeCategory, Value: e.category.Label()}}
+		for _, tag := range e.tags {
+			categories = append(categories, rssCategory{Domain: schemeTag, Value: tag})
+		}
+		for _, p := range e.politicians {
+			categories = append(categories, rssCategory{Domain: schemePolitician, Value: p.FullName})
+		}
+
+		out.Channel.Items = append(out.Channel.Items, rssItem{
+			Title:       e.title,
+			Link:        e.url,
+			GUID:        rssGUID{IsPermaLink: false, Value: e.id},
+			PubDate:     e.published.UTC().Format(time.RFC1123Z),
+			Description: e.summary,
+			Categories:  categories,
+		})
+	}
+
+	return marshalXML(out)
+}
+
+func marshalXML(v any) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// JSON Feed 1.1, https://jsonfeed.org/version/1.1
+
+type jsonFeed struct {
+	Version     string       `json:"version"`
+	Title       string       `json:"title"`
+	HomePageURL string       `json:"home_page_url"`
+	FeedURL     string       `json:"feed_url"`
+	Language    string       `json:"language"`
+	Authors     []jsonAuthor `json:"authors"`
+	Items       []jsonItem   `json:"items"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type jsonItem struct {
+	ID            string    `json:"id"`
+	URL           string    `json:"url"`
+	Title         string    `json:"title"`
+	Summary       string    `json:"summary"`
+	ContentText   string    `json:"content_text"`
+	DatePublished time.Time `json:"date_published"`
+	DateModified  time.Time `json:"date_modified"`
+	Tags          []string  `json:"tags,omitempty"`
+
+	// extensions are prefixed with an underscore, see https://jsonfeed.org/version/1.1#extensions-a-name-extensions-a
+	Extension jsonItemExtension `json:"_vigie_du_mensonge"`
+}
+
+type jsonItemExtension struct {
+	About         string                 `json:"about"`
+	Category      models.ArticleCategory `json:"category"`
+	CategoryLabel string                 `json:"categoryLabel"`
+	EventDate     time.Time              `json:"eventDate"`
+	Politicians   []politician           `json:"politicians"`
+}
+
+func (f feed) jsonFeed() jsonFeed {
+	out := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.title,
+		HomePageURL: f.homeURL,
+		FeedURL:     f.selfURL,
+		Language:    language,
+		Authors:     []jsonAuthor{{Name: siteName, URL: f.homeURL}},
+		Items:       make([]jsonItem, 0, len(f.entries)),
+	}
+
+	for _, e := range f.entries {
+		politicians := e.politicians
+		if politicians == nil {
+			politicians = []politician{}
+		}
+
+		out.Items = append(out.Items, jsonItem{
+			ID:            e.id,
+			URL:           e.url,
+			Title:         e.title,
+			Summary:       e.summary,
+			ContentText:   e.content,
+			DatePublished: e.published.UTC(),
+			DateModified:  e.updated.UTC(),
+			Tags:          e.tags,
+			Extension: jsonItemExtension{
+				About:         f.homeURL,
+				Category:      e.category,
+				CategoryLabel: e.category.Label(),
+				EventDate:     e.eventDate,
+				Politicians:   politicians,
+			},
+		})
+	}
+
+	return out
+}
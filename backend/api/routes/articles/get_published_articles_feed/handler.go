@@ -0,0 +1,124 @@
+package get_published_articles_feed
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+	"vdm/core/http_cache"
+	"vdm/core/models"
+	"vdm/core/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	getPublishedArticlesFeed(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo      Repository
+	clientURL string
+}
+
+func (h *handler) getPublishedArticlesFeed(c *fiber.Ctx) error {
+	format := c.Params(formatParam)
+	if format != formatAtom && format != formatRSS && format != formatJSON {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: "unknown feed format"}
+	}
+
+	var reqDTO RequestDTO
+	if err := c.QueryParser(&reqDTO); err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid query params"}
+	}
+	if err := validation.Validate(reqDTO); err != nil {
+		return err
+	}
+
+	f, err := reqDTO.toFilter()
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: err.Error()}
+	}
+
+	title := siteName
+	switch {
+	case f.politicianID != uuid.Nil:
+		politician, err := h.repo.findPolitician(f.politicianID)
+		if err != nil {
+			return fmt.Errorf("failed to find politician: %v", err)
+		}
+		if politician == nil {
+			return &fiber.Error{Code: fiber.StatusNotFound, Message: "politician not found"}
+		}
+		title += " · " + politician.FirstName + " " + politician.LastName
+	case f.tag != "":
+		title += " · " + f.tag
+	}
+	if f.category != "" {
+		title += " · " + f.category.Label()
+	}
+
+	state, err := h.repo.getFeedState(f)
+	if err != nil {
+		return fmt.Errorf("failed to get feed state: %v", err)
+	}
+
+	lastModified := time.Unix(0, 0).UTC()
+	if state.LastModified != nil {
+		lastModified = state.LastModified.UTC()
+	}
+
+	// the latest update alone would miss an article leaving the feed, hence the count
+	etag := fmt.Sprintf(`W/"%x-%d"`, lastModified.UnixNano(), state.Count)
+
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderLastModified, lastModified.Format(http.TimeFormat))
+	// revalidated on every poll, which the validators above make cheap
+	c.Set(fiber.HeaderCacheControl, "public, no-cache")
+
+	if http_cache.NotModified(c.Get(fiber.HeaderIfNoneMatch), c.Get(fiber.HeaderIfModifiedSince), etag, lastModified) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	articles, err := h.repo.getFeedArticles(f, feedSize)
+	if err != nil {
+		return fmt.Errorf("failed to get feed articles: %v", err)
+	}
+
+	out := newFeed(title, c.BaseURL()+c.OriginalURL(), h.clientURL, lastModified, articles)
+
+	switch format {
+	case formatJSON:
+		return c.Status(fiber.StatusOK).JSON(out.jsonFeed(), contentTypeJSON)
+	case formatRSS:
+		body, err := out.rss()
+		if err != nil {
+			return fmt.Errorf("failed to render rss feed: %v", err)
+		}
+		c.Set(fiber.HeaderContentType, contentTypeRSS)
+		return c.Status(fiber.StatusOK).Send(body)
+	default:
+		body, err := out.atom()
+		if err != nil {
+			return fmt.Errorf("failed to render atom feed: %v", err)
+		}
+		c.Set(fiber.HeaderContentType, contentTypeAtom)
+		return c.Status(fiber.StatusOK).Send(body)
+	}
+}
+
+func newFeed(title, selfURL, clientURL string, updated time.Time, articles []models.Article) feed {
+	f := feed{
+		title:   title,
+		selfURL: selfURL,
+		homeURL: clientURL,
+		updated: updated,
+		entries: make([]entry, len(articles)),
+	}
+
+	for i := range articles {
+		f.entries[i] = newEntry(clientURL, articles[i])
+	}
+
+	return f
+}
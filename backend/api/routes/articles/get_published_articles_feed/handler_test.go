@@ -0,0 +1,232 @@
+package get_published_articles_feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubRepository struct {
+	state      feedState
+	articles   []models.Article
+	politician *models.Politician
+}
+
+func (r *stubRepository) getFeedState(f filter) (feedState, error) {
+	return r.state, nil
+}
+
+func (r *stubRepository) getFeedArticles(f filter, limit int) ([]models.Article, error) {
+	return r.articles, nil
+}
+
+func (r *stubRepository) findPolitician(politicianID uuid.UUID) (*models.Politician, error) {
+	return r.politician, nil
+}
+
+func newAppWithRepo(repo Repository) *fiber.App {
+	app := fiberx.NewApp()
+	h := &handler{repo: repo, clientURL: "https://vigie.test"}
+	app.Add(Method, Path, h.getPublishedArticlesFeed)
+	return app
+}
+
+func newStubRepository() *stubRepository {
+	updatedAt := time.Date(2024, 6, 10, 8, 30, 0, 0, time.UTC)
+	politician := &models.Politician{ID: uuid.New(), FirstName: "Emmanuel", LastName: "Macron"}
+
+	return &stubRepository{
+		state: feedState{LastModified: &updatedAt, Count: 1},
+		articles: []models.Article{{
+			ID:          uuid.New(),
+			Reference:   uuid.New(),
+			Title:       "Article about Emmanuel Macron",
+			Body:        "Body",
+			Category:    models.ArticleCategoryLie,
+			EventDate:   time.Date(2024, 6, 9, 20, 0, 0, 0, time.UTC),
+			UpdatedAt:   updatedAt,
+			PublishedAt: &updatedAt,
+			Politicians: []*models.Politician{politician},
+			Tags:        []*models.ArticleTag{{Tag: "Européennes"}},
+		}},
+		politician: politician,
+	}
+}
+
+func TestHandler_ErrBadRequest(t *testing.T) {
+	app := newAppWithRepo(newStubRepository())
+
+	for _, target := range []string{
+		"/feed.atom?category=DRAFT",
+		"/feed.rss?politicianId=not-a-uuid",
+	} {
+		req := httptest.NewRequest(Method, target, nil)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode, target)
+	}
+}
+
+func TestHandler_ErrNotFound(t *testing.T) {
+	repo := newStubRepository()
+	repo.politician = nil
+	app := newAppWithRepo(repo)
+
+	for _, target := range []string{
+		"/feed.xml",
+		"/feed.json?politicianId=" + uuid.NewString(),
+	} {
+		req := httptest.NewRequest(Method, target, nil)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode, target)
+	}
+}
+
+func TestHandler_Formats(t *testing.T) {
+	repo := newStubRepository()
+	app := newAppWithRepo(repo)
+	article := repo.articles[0]
+
+	t.Run("atom", func(t *testing.T) {
+		req := httptest.NewRequest(Method, "/feed.atom?tag=Européennes", nil)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Equal(t, contentTypeAtom, res.Header.Get(fiber.HeaderContentType))
+
+		var out atomFeed
+		if err = xml.NewDecoder(res.Body).Decode(&out); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, siteName+" · Européennes", out.Title)
+		assert.Equal(t, "2024-06-10T08:30:00Z", out.Updated)
+		if assert.Len(t, out.Entries, 1) {
+			entry := out.Entries[0]
+			assert.Equal(t, "urn:uuid:"+article.Reference.String(), entry.ID)
+			assert.Equal(t, "https://vigie.test/articles/"+article.ID.String(), entry.Links[0].Href)
+			assert.Contains(t, entry.Categories, atomCategory{Term: "LIE", Scheme: schemeCategory, Label: "Mensonge"})
+			assert.Contains(t, entry.Categories, atomCategory{Term: "Européennes", Scheme: schemeTag})
+			assert.Contains(t, entry.Categories, atomCategory{Term: repo.politician.ID.String(), Scheme: schemePolitician, Label: "Emmanuel Macron"})
+		}
+	})
+
+	t.Run("rss", func(t *testing.T) {
+		req := httptest.NewRequest(Method, "/feed.rss?politicianId="+repo.politician.ID.String(), nil)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Equal(t, contentTypeRSS, res.Header.Get(fiber.HeaderContentType))
+
+		var out rssFeed
+		if err = xml.NewDecoder(res.Body).Decode(&out); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2.0", out.Version)
+		assert.Equal(t, siteName+" · Emmanuel Macron", out.Channel.Title)
+		if assert.Len(t, out.Channel.Items, 1) {
+			item := out.Channel.Items[0]
+			assert.Equal(t, "urn:uuid:"+article.Reference.String(), item.GUID.Value)
+			assert.False(t, item.GUID.IsPermaLink)
+			assert.Equal(t, "Mon, 10 Jun 2024 08:30:00 +0000", item.PubDate)
+			assert.Contains(t, item.Categories, rssCategory{Domain: schemePolitician, Value: "Emmanuel Macron"})
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		req := httptest.NewRequest(Method, "/feed.json", nil)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Equal(t, contentTypeJSON, res.Header.Get(fiber.HeaderContentType))
+
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out jsonFeed
+		if err = json.Unmarshal(body, &out); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "https://jsonfeed.org/version/1.1", out.Version)
+		if assert.Len(t, out.Items, 1) {
+			item := out.Items[0]
+			assert.Equal(t, "urn:uuid:"+article.Reference.String(), item.ID)
+			assert.Equal(t, []string{"Européennes"}, item.Tags)
+			assert.Equal(t, models.ArticleCategoryLie, item.Extension.Category)
+			assert.Equal(t, []politician{{ID: repo.politician.ID, FullName: "Emmanuel Macron"}}, item.Extension.Politicians)
+		}
+	})
+}
+
+func TestHandler_NotModified(t *testing.T) {
+	app := newAppWithRepo(newStubRepository())
+
+	req := httptest.NewRequest(Method, "/feed.atom", nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = res.Body.Close()
+
+	etag := res.Header.Get(fiber.HeaderETag)
+	lastModified := res.Header.Get(fiber.HeaderLastModified)
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, "Mon, 10 Jun 2024 08:30:00 GMT", lastModified)
+
+	for _, tc := range []struct {
+		header   string
+		value    string
+		expected int
+	}{
+		{fiber.HeaderIfNoneMatch, etag, fiber.StatusNotModified},
+		{fiber.HeaderIfNoneMatch, `"other", ` + etag, fiber.StatusNotModified},
+		{fiber.HeaderIfNoneMatch, `W/"other"`, fiber.StatusOK},
+		{fiber.HeaderIfModifiedSince, lastModified, fiber.StatusNotModified},
+		{fiber.HeaderIfModifiedSince, time.Date(2024, 6, 10, 8, 29, 59, 0, time.UTC).Format(http.TimeFormat), fiber.StatusOK},
+	} {
+		req = httptest.NewRequest(Method, "/feed.atom", nil)
+		req.Header.Set(tc.header, tc.value)
+		res, err = app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, tc.expected, res.StatusCode, tc.header+": "+tc.value)
+	}
+}
@@ -0,0 +1,183 @@
+package get_published_articles_feed
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	politicians []*models.Politician
+	articles    []*models.Article
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.politicians = []*models.Politician{
+		{FirstName: "François", LastName: "Hollande"},
+		{FirstName: "Emmanuel", LastName: "Macron"},
+	}
+
+	if err = connector.GormDB().Create(&data.politicians).Error; err != nil {
+		return
+	}
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123"}
+
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	publishedAt := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	data.articles = []*models.Article{
+		{
+			RedactorID:  redactor.ID,
+			Title:       "Article about François Hollande",
+			Politicians: []*models.Politician{data.politicians[0]},
+			Tags:        []*models.ArticleTag{{Tag: "François Hollande"}},
+			Status:      models.ArticleStatusPublished,
+			Category:    models.ArticleCategoryFalsehood,
+			EventDate:   time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+		}, {
+			RedactorID:  redactor.ID,
+			Title:       "Article about Emmanuel Macron",
+			Politicians: []*models.Politician{data.politicians[1]},
+			Tags:        []*models.ArticleTag{{Tag: "Emmanuel Macron"}},
+			Status:      models.ArticleStatusPublished,
+			Category:    models.ArticleCategoryLie,
+			EventDate:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			PublishedAt: &publishedAt,
+		}, {
+			RedactorID:  redactor.ID,
+			Title:       "Draft about Emmanuel Macron",
+			Politicians: []*models.Politician{data.politicians[1]},
+			Status:      models.ArticleStatusDraft,
+			Category:    models.ArticleCategoryLie,
+			EventDate:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	err = connector.GormDB().Create(&data.articles).Error
+
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route("https://vigie.test", connector.GormDB()).Register(app)
+
+	out := getJSONFeed(t, app, "/feed.json")
+	if assert.Len(t, out.Items, 2) {
+		// published_at first, versions without it come last
+		assert.Equal(t, "urn:uuid:"+data.articles[1].Reference.String(), out.Items[0].ID)
+		assert.Equal(t, "urn:uuid:"+data.articles[0].Reference.String(), out.Items[1].ID)
+	}
+
+	out = getJSONFeed(t, app, "/feed.json?politicianId="+data.politicians[1].ID.String())
+	assert.Equal(t, siteName+" · Emmanuel Macron", out.Title)
+	if assert.Len(t, out.Items, 1) {
+		assert.Equal(t, "https://vigie.test/articles/"+data.articles[1].ID.String(), out.Items[0].URL)
+		assert.Equal(t, []politician{{ID: data.politicians[1].ID, FullName: "Emmanuel Macron"}}, out.Items[0].Extension.Politicians)
+	}
+
+	out = getJSONFeed(t, app, "/feed.json?tag=Fran%C3%A7ois%20Hollande&category=FALSEHOOD")
+	if assert.Len(t, out.Items, 1) {
+		assert.Equal(t, []string{"François Hollande"}, out.Items[0].Tags)
+	}
+}
+
+func TestIntegration_NotModified(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route("https://vigie.test", connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/feed.atom", nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = res.Body.Close()
+
+	etag := res.Header.Get(fiber.HeaderETag)
+
+	req = httptest.NewRequest(Method, "/feed.atom", nil)
+	req.Header.Set(fiber.HeaderIfNoneMatch, etag)
+	res, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNotModified, res.StatusCode)
+
+	// archiving an article changes the feed even though no published article was updated
+	if err = connector.GormDB().Model(data.articles[0]).Update("status", models.ArticleStatusArchived).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	req = httptest.NewRequest(Method, "/feed.atom", nil)
+	req.Header.Set(fiber.HeaderIfNoneMatch, etag)
+	res, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	assert.NotEqual(t, etag, res.Header.Get(fiber.HeaderETag))
+}
+
+func getJSONFeed(t *testing.T, app *fiber.App, target string) jsonFeed {
+	req := httptest.NewRequest(Method, target, nil)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code 200, got %d", res.StatusCode)
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out jsonFeed
+	if err = json.Unmarshal(resBody, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	return out
+}
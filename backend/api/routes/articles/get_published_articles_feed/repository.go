@@ -0,0 +1,92 @@
+package get_published_articles_feed
+
+import (
+	"fmt"
+	"time"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	getFeedState(f filter) (feedState, error)
+	getFeedArticles(f filter, limit int) ([]models.Article, error)
+	findPolitician(politicianID uuid.UUID) (*models.Politician, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// feedState changes whenever an article enters, leaves or is updated in the feed.
+type feedState struct {
+	LastModified *time.Time
+	Count        int64
+}
+
+func (r *repository) published(f filter) *gorm.DB {
+	query := r.db.Model(&models.Article{}).Where("status = ?", models.ArticleStatusPublished)
+
+	if f.category != "" {
+		query = query.Where("category = ?", f.category)
+	}
+
+	if f.politicianID != uuid.Nil {
+		query = query.Where("EXISTS (SELECT 1 FROM article_politicians ap WHERE ap.article_id = articles.id AND ap.politician_id = ?)", f.politicianID)
+	}
+
+	if f.tag != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM article_tags t WHERE t.article_id = articles.id AND t.tag = ?)", f.tag)
+	}
+
+	return query
+}
+
+func (r *repository) getFeedState(f filter) (feedState, error) {
+	var state feedState
+
+	if err := r.published(f).
+		Select("MAX(updated_at) AS last_modified, COUNT(*) AS count").
+		Scan(&state).Error; err != nil {
+		return feedState{}, fmt.Errorf("failed to get feed state: %v", err)
+	}
+
+	return state, nil
+}
+
+// getFeedArticles returns the latest published articles, versions published before published_at existed come last.
+func (r *repository) getFeedArticles(f filter, limit int) ([]models.Article, error) {
+	var articles []models.Article
+
+	if err := r.published(f).
+		Order("published_at DESC NULLS LAST, updated_at DESC, id DESC").
+		Limit(limit).
+		Select("id", "reference", "title", "body", "event_date", "updated_at", "published_at", "category", "correction_note").
+		Preload("Politicians", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "first_name", "last_name")
+		}).
+		Preload("Tags").
+		Find(&articles).Error; err != nil {
+		return nil, fmt.Errorf("failed to get feed articles: %v", err)
+	}
+
+	return articles, nil
+}
+
+func (r *repository) findPolitician(politicianID uuid.UUID) (*models.Politician, error) {
+	var politicians []models.Politician
+
+	if err := r.db.Where("id = ?", politicianID).
+		Select("id", "first_name", "last_name").
+		Limit(1).
+		Find(&politicians).Error; err != nil {
+		return nil, fmt.Errorf("failed to find Politician{ID=%s}: %v", politicianID, err)
+	}
+
+	if len(politicians) == 0 {
+		return nil, nil
+	}
+
+	return &politicians[0], nil
+}
@@ -0,0 +1,22 @@
+package get_published_articles_feed
+
+import (
+	"vdm/core/fiberx"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	formatParam = "format"
+
+	Path   = "/feed.:" + formatParam
+	Method = fiber.MethodGet
+)
+
+// Route serves the feeds of published articles. Links point to the articles on the client at clientURL.
+func Route(clientURL string, db *gorm.DB) *fiberx.Route {
+	repo := &repository{db}
+	handler := &handler{repo, clientURL}
+	return fiberx.NewRoute(Method, Path, handler.getPublishedArticlesFeed)
+}
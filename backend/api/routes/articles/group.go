@@ -1,10 +1,15 @@
 package articles
 
 import (
+	"slices"
 	"vdm/api/routes/articles/find_published_article"
+	"vdm/api/routes/articles/get_published_article_versions"
 	"vdm/api/routes/articles/get_published_articles"
+	"vdm/api/routes/articles/get_published_articles_feed"
+	"vdm/api/routes/articles/search_published_articles"
 	"vdm/core/dependencies"
 	"vdm/core/fiberx"
+	"vdm/core/http_cache"
 )
 
 const Prefix = "/articles"
@@ -13,8 +18,22 @@ func Group(deps *dependencies.Dependencies) *fiberx.Group {
 	group := fiberx.NewGroup(Prefix)
 
 	group.Add(
+		// the feeds answer conditional requests from their own validators, which are cheaper than a cached body
+		// and would be replaced by the ETag of the cache, so they are registered before it.
+		// Also before find_published_article, whose path would match "/feed.:format".
+		get_published_articles_feed.Route(deps.Config.ClientURL, deps.GormDB()),
+
+		// articles embed politicians, whose changes must be seen too
+		fiberx.NewMiddleware(deps.Cache.Middleware(
+			slices.Concat(get_published_articles.QueryParams, search_published_articles.QueryParams),
+			http_cache.ScopeArticles, http_cache.ScopePoliticians,
+		)),
+
 		get_published_articles.Group(deps.GormDB()),
-		find_published_article.Route(deps.GormDB()),
+		// must be registered before find_published_article, whose path would match "/search"
+		search_published_articles.Route(deps.GormDB()),
+		find_published_article.Route(deps.Config.ClientURL, deps.GormDB()),
+		get_published_article_versions.Route(deps.GormDB()),
 	)
 
 	return group
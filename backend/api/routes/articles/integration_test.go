@@ -0,0 +1,84 @@
+package articles
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"vdm/core/dependencies"
+	"vdm/core/env"
+	"vdm/core/fiberx"
+	"vdm/core/http_cache"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegration_FeedBypassesCache(t *testing.T) {
+	c := context.Background()
+	container, connector := test_utils.NewTestContainerConnector(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+	db := connector.GormDB()
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123"}
+	if err := db.Create(redactor).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	publish := func(title string) {
+		article := &models.Article{
+			RedactorID: redactor.ID,
+			Title:      title,
+			Body:       "Body",
+			Status:     models.ArticleStatusPublished,
+			Category:   models.ArticleCategoryLie,
+			EventDate:  time.Date(2024, 6, 9, 0, 0, 0, 0, time.UTC),
+		}
+		if err := db.Create(article).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	publish("First article")
+
+	cache := http_cache.New(http_cache.NewMemoryStore(100), time.Hour, time.Minute)
+	deps := dependencies.New(env.Config{ClientURL: "https://vigie.test"}, connector, nil, cache, nil, nil)
+
+	app := fiberx.NewApp()
+	Group(deps).Register(app)
+
+	get := func(target, ifNoneMatch string) (int, string) {
+		req := httptest.NewRequest(fiber.MethodGet, target, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set(fiber.HeaderIfNoneMatch, ifNoneMatch)
+		}
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+		return res.StatusCode, res.Header.Get(fiber.HeaderETag)
+	}
+
+	status, etag := get(Prefix+"/feed.atom", "")
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.True(t, strings.HasPrefix(etag, `W/"`), "the feed keeps its own validator: %s", etag)
+
+	status, _ = get(Prefix+"/feed.atom", etag)
+	assert.Equal(t, fiber.StatusNotModified, status)
+
+	// the cache is not invalidated, yet the feed sees the new article
+	publish("Second article")
+
+	status, newETag := get(Prefix+"/feed.atom", etag)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.NotEqual(t, etag, newETag)
+
+	// the other routes of the group are still cached
+	_, listETag := get(Prefix, "")
+	assert.False(t, strings.HasPrefix(listETag, "W/"), "the list is served by the cache: %s", listETag)
+	status, _ = get(Prefix, listETag)
+	assert.Equal(t, fiber.StatusNotModified, status)
+}
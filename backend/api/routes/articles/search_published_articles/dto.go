@@ -0,0 +1,27 @@
+package search_published_articles
+
+import "vdm/core/dto/response_dto"
+
+const (
+	queryParam  = "q"
+	limitParam  = "limit"
+	offsetParam = "offset"
+
+	minQueryLen  = 2
+	maxQueryLen  = 200
+	defaultLimit = 20
+	maxLimit     = 50
+)
+
+// QueryParams lists the query params of the search, the cache keys its responses on them
+var QueryParams = []string{queryParam, limitParam, offsetParam}
+
+type ResultDTO struct {
+	response_dto.Article
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet,omitempty"`
+}
+
+type ResponseDTO struct {
+	Results []ResultDTO `json:"results"`
+}
@@ -0,0 +1,41 @@
+package search_published_articles
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	searchPublishedArticles(c *fiber.Ctx) error
+}
+
+type handler struct {
+	svc Service
+}
+
+func (h *handler) searchPublishedArticles(c *fiber.Ctx) error {
+	query := strings.TrimSpace(c.Query(queryParam))
+	if queryLen := utf8.RuneCountInString(query); queryLen < minQueryLen || queryLen > maxQueryLen {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: fmt.Sprintf("query param <%s> must be between %d and %d characters", queryParam, minQueryLen, maxQueryLen)}
+	}
+
+	limit := c.QueryInt(limitParam, defaultLimit)
+	if limit < 1 || limit > maxLimit {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: fmt.Sprintf("query param <%s> must be between 1 and %d", limitParam, maxLimit)}
+	}
+
+	offset := c.QueryInt(offsetParam, 0)
+	if offset < 0 {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: fmt.Sprintf("query param <%s> must be positive", offsetParam)}
+	}
+
+	respDTO, err := h.svc.searchPublishedArticles(query, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(respDTO)
+}
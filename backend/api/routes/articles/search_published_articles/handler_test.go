@@ -0,0 +1,44 @@
+package search_published_articles
+
+import (
+	"net/http/httptest"
+	"testing"
+	"vdm/core/fiberx"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type nullService struct{}
+
+func (*nullService) searchPublishedArticles(query string, limit, offset int) (ResponseDTO, error) {
+	return ResponseDTO{}, nil
+}
+
+func newAppWithNullSvc() *fiber.App {
+	app := fiberx.NewApp()
+	h := &handler{svc: &nullService{}}
+	app.Add(Method, Path, h.searchPublishedArticles)
+	return app
+}
+
+func TestHandler_ErrBadRequest(t *testing.T) {
+	app := newAppWithNullSvc()
+
+	for _, target := range []string{
+		Path,
+		Path + "?q=a",
+		Path + "?q=macron&limit=0",
+		Path + "?q=macron&limit=51",
+		Path + "?q=macron&offset=-1",
+	} {
+		req := httptest.NewRequest(Method, target, nil)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode, target)
+	}
+}
@@ -0,0 +1,171 @@
+package search_published_articles
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	politicians []*models.Politician
+	articles    []*models.Article
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.politicians = []*models.Politician{
+		{FirstName: "Emmanuel", LastName: "Macron"},
+		{FirstName: "François", LastName: "Hollande"},
+	}
+
+	if err = connector.GormDB().Create(&data.politicians).Error; err != nil {
+		return
+	}
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	data.articles = []*models.Article{
+		{
+			RedactorID:  redactor.ID,
+			Title:       "Le chômage n'a jamais été aussi bas",
+			Body:        "Le président affirme que la réforme des retraites est financée, ce que contredisent les chiffres.",
+			Politicians: []*models.Politician{data.politicians[0]},
+			Tags:        []*models.ArticleTag{{Tag: "Retraites"}},
+			Status:      models.ArticleStatusPublished,
+			Category:    models.ArticleCategoryLie,
+			EventDate:   time.Date(2023, 3, 22, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			RedactorID:  redactor.ID,
+			Title:       "La courbe du chômage s'inverse",
+			Body:        "L'ancien président annonçait une inversion de la courbe du chômage avant la fin de l'année.",
+			Politicians: []*models.Politician{data.politicians[1]},
+			Tags:        []*models.ArticleTag{{Tag: "Emploi"}},
+			Status:      models.ArticleStatusPublished,
+			Category:    models.ArticleCategoryFalsehood,
+			EventDate:   time.Date(2013, 9, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			RedactorID:  redactor.ID,
+			Title:       "Brouillon sur la réforme des retraites",
+			Body:        "Ce brouillon parle aussi de la réforme des retraites mais n'est pas publié.",
+			Politicians: []*models.Politician{data.politicians[0]},
+			Status:      models.ArticleStatusDraft,
+			Category:    models.ArticleCategoryLie,
+			EventDate:   time.Date(2023, 3, 22, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	err = connector.GormDB().Create(&data.articles).Error
+	return
+}
+
+func search(t *testing.T, app *fiber.App, query string) ResponseDTO {
+	req := httptest.NewRequest(Method, Path+"?"+queryParam+"="+url.QueryEscape(query), nil)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code 200, got %d", res.StatusCode)
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resDTO ResponseDTO
+	if err = json.Unmarshal(resBody, &resDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	return resDTO
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	// accents and inflections are ignored, drafts are never returned
+	resDTO := search(t, app, "reforme retraite")
+	if assert.Equal(t, 1, len(resDTO.Results)) {
+		assert.Equal(t, data.articles[0].ID, resDTO.Results[0].ID)
+		assert.Contains(t, resDTO.Results[0].Snippet, "<mark>")
+		assert.Equal(t, 1, len(resDTO.Results[0].Politicians))
+	}
+
+	// politician names are searchable
+	resDTO = search(t, app, "hollande")
+	if assert.Equal(t, 1, len(resDTO.Results)) {
+		assert.Equal(t, data.articles[1].ID, resDTO.Results[0].ID)
+	}
+
+	// an article mentioning the term in its title and body ranks first
+	resDTO = search(t, app, "chomage")
+	if assert.Equal(t, 2, len(resDTO.Results)) {
+		assert.Equal(t, data.articles[1].ID, resDTO.Results[0].ID)
+		assert.GreaterOrEqual(t, resDTO.Results[0].Rank, resDTO.Results[1].Rank)
+	}
+
+	resDTO = search(t, app, "sarkozy")
+	assert.Equal(t, 0, len(resDTO.Results))
+}
+
+func TestIntegration_SearchVectorFollowsLinks(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+	db := connector.GormDB()
+
+	app := fiberx.NewApp()
+	Route(db).Register(app)
+
+	// a renamed politician is found under their new name only
+	if err := db.Model(data.politicians[1]).Update("last_name", "Mitterrand").Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(search(t, app, "hollande").Results))
+	if resDTO := search(t, app, "mitterrand"); assert.Equal(t, 1, len(resDTO.Results)) {
+		assert.Equal(t, data.articles[1].ID, resDTO.Results[0].ID)
+	}
+
+	// tags are searchable until they are removed
+	assert.Equal(t, 1, len(search(t, app, "emploi").Results))
+	if err := db.Where("article_id = ?", data.articles[1].ID).Delete(&models.ArticleTag{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(search(t, app, "emploi").Results))
+}
@@ -0,0 +1,72 @@
+package search_published_articles
+
+import (
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	highlightStart = "<mark>"
+	highlightStop  = "</mark>"
+
+	headlineOptions = `StartSel=` + highlightStart + `, StopSel=` + highlightStop + `, MaxFragments=2, MinWords=10, MaxWords=30, FragmentDelimiter=" … "`
+)
+
+// searchSQL ranks published articles against a websearch query, using the GIN index of articles.search_vector.
+// Title and politician names weigh the most, then tags, then the body (see article_search_vector in schema.sql).
+// The french_unaccent configuration (see schema.sql) stems french words and ignores accents.
+const searchSQL = `
+SELECT a.id,
+       ts_rank_cd(a.search_vector, q.query)                     AS rank,
+       ts_headline('french_unaccent', a.body, q.query, ?)       AS headline
+FROM articles a
+         CROSS JOIN websearch_to_tsquery('french_unaccent', ?) AS q(query)
+WHERE a.status = ?
+  AND a.deleted_at IS NULL
+  AND a.search_vector @@ q.query
+ORDER BY rank DESC, a.event_date DESC, a.id
+LIMIT ? OFFSET ?`
+
+type searchHit struct {
+	ID       uuid.UUID
+	Rank     float64
+	Headline string
+}
+
+type Repository interface {
+	searchPublishedArticles(query string, limit, offset int) ([]searchHit, error)
+	findPublishedArticles(articleIDs []uuid.UUID) ([]models.Article, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) searchPublishedArticles(query string, limit, offset int) ([]searchHit, error) {
+	var hits []searchHit
+
+	if err := r.db.Raw(searchSQL, headlineOptions, query, models.ArticleStatusPublished, limit, offset).
+		Scan(&hits).Error; err != nil {
+		return nil, err
+	}
+
+	return hits, nil
+}
+
+func (r *repository) findPublishedArticles(articleIDs []uuid.UUID) ([]models.Article, error) {
+	var articles []models.Article
+
+	if err := r.db.Where("id IN ? AND status = ?", articleIDs, models.ArticleStatusPublished).
+		Select("id", "title", "event_date", "updated_at", "category").
+		Preload("Politicians", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "first_name", "last_name")
+		}).
+		Preload("Tags").
+		Find(&articles).Error; err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
@@ -0,0 +1,20 @@
+package search_published_articles
+
+import (
+	"vdm/core/fiberx"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	Path   = "/search"
+	Method = fiber.MethodGet
+)
+
+func Route(db *gorm.DB) *fiberx.Route {
+	repo := &repository{db}
+	svc := &service{repo}
+	handler := &handler{svc}
+	return fiberx.NewRoute(Method, Path, handler.searchPublishedArticles)
+}
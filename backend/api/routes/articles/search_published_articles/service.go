@@ -0,0 +1,89 @@
+package search_published_articles
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"vdm/core/dto/response_dto"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	searchPublishedArticles(query string, limit, offset int) (ResponseDTO, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func (s *service) searchPublishedArticles(query string, limit, offset int) (ResponseDTO, error) {
+	hits, err := s.repo.searchPublishedArticles(query, limit, offset)
+	if err != nil {
+		return ResponseDTO{}, fmt.Errorf("failed to search published articles: %v", err)
+	}
+
+	respDTO := ResponseDTO{Results: make([]ResultDTO, 0, len(hits))}
+	if len(hits) == 0 {
+		return respDTO, nil
+	}
+
+	articleIDs := make([]uuid.UUID, len(hits))
+	for i := range hits {
+		articleIDs[i] = hits[i].ID
+	}
+
+	articles, err := s.repo.findPublishedArticles(articleIDs)
+	if err != nil {
+		return ResponseDTO{}, fmt.Errorf("failed to find published articles: %v", err)
+	}
+
+	articlesByID := make(map[uuid.UUID]models.Article, len(articles))
+	for i := range articles {
+		articlesByID[articles[i].ID] = articles[i]
+	}
+
+	// hits are ranked by the database, keep that order
+	for _, hit := range hits {
+		article, ok := articlesByID[hit.ID]
+		if !ok {
+			continue
+		}
+
+		respDTO.Results = append(respDTO.Results, ResultDTO{
+			Article: response_dto.NewArticle(article),
+			Rank:    hit.Rank,
+			Snippet: sanitizeHeadline(hit.Headline),
+		})
+	}
+
+	return respDTO, nil
+}
+
+// sanitizeHeadline escapes the article text returned by ts_headline while keeping the <mark> highlights.
+func sanitizeHeadline(headline string) string {
+	var sb strings.Builder
+
+	for headline != "" {
+		start := strings.Index(headline, highlightStart)
+		if start < 0 {
+			sb.WriteString(html.EscapeString(headline))
+			break
+		}
+
+		sb.WriteString(html.EscapeString(headline[:start]))
+		headline = headline[start+len(highlightStart):]
+
+		stop := strings.Index(headline, highlightStop)
+		if stop < 0 {
+			sb.WriteString(html.EscapeString(headline))
+			break
+		}
+
+		sb.WriteString(highlightStart + html.EscapeString(headline[:stop]) + highlightStop)
+		headline = headline[stop+len(highlightStop):]
+	}
+
+	return sb.String()
+}
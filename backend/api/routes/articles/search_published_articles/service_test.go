@@ -0,0 +1,17 @@
+package search_published_articles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeHeadline(t *testing.T) {
+	assert.Equal(t,
+		"le <mark>président</mark> a dit &lt;script&gt; &amp; <mark>menti</mark>",
+		sanitizeHeadline("le <mark>président</mark> a dit <script> & <mark>menti</mark>"),
+	)
+	assert.Equal(t, "&lt;b&gt;sans surlignage&lt;/b&gt;", sanitizeHeadline("<b>sans surlignage</b>"))
+	assert.Equal(t, "<mark>&lt;i&gt;</mark>", sanitizeHeadline("<mark><i></mark>"))
+	assert.Equal(t, "non fermé &lt;/i&gt;", sanitizeHeadline("<mark>non fermé </i>"))
+}
@@ -6,6 +6,9 @@ import (
 	"vdm/api/routes/auth/routes/process_sign_up"
 	"vdm/api/routes/auth/routes/refresh"
 	"vdm/api/routes/auth/routes/sign_in"
+	"vdm/api/routes/auth/routes/sign_in_mfa"
+	"vdm/api/routes/auth/routes/sign_in_passkey"
+	"vdm/api/routes/auth/routes/sign_in_passkey_options"
 	"vdm/api/routes/auth/routes/sign_out"
 	"vdm/core/dependencies"
 	"vdm/core/fiberx"
@@ -18,11 +21,14 @@ func Group(deps *dependencies.Dependencies) *fiberx.Group {
 
 	group.Add(
 		inquire_sign_up.Route(deps.Config.Security, deps.Config.ClientURL, deps.GormDB(), deps.Mailer),
+		sign_in_passkey_options.Route(deps.GormDB(), deps.WebAuthn),
 
 		set_auth_cookies.Middleware(deps.Config.Security),
 
 		process_sign_up.Route(deps.GormDB(), deps.Config.Security),
-		sign_in.Route(deps.GormDB(), deps.Config.Security),
+		sign_in.Route(deps.GormDB(), deps.Config.Security, deps.Lockout, deps.Mailer, deps.Config.ClientURL),
+		sign_in_mfa.Route(deps.GormDB(), deps.Config.Security),
+		sign_in_passkey.Route(deps.GormDB(), deps.Config.Security, deps.WebAuthn),
 		refresh.Route(deps.GormDB(), deps.Config.Security),
 		sign_out.Route(deps.GormDB(), deps.Config.Security),
 	)
@@ -23,6 +23,11 @@ func (h *handler) setAuthCookies(c *fiber.Ctx) error {
 		return err
 	}
 
+	// no token is issued until the second factor is checked
+	if pending, _ := c.Locals(local_keys.MfaChallenge).(bool); pending {
+		return nil
+	}
+
 	accessToken, ok := c.Locals(local_keys.AccessToken).(locals.AccessToken)
 	if !ok {
 		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "access token not found"}
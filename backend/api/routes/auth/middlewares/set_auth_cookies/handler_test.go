@@ -52,3 +52,23 @@ func TestHandler_Success(t *testing.T) {
 	assert.Equal(t, refreshToken.Token.String(), res.Cookies()[1].Value)
 	assert.Equal(t, http.SameSiteStrictMode, res.Cookies()[1].SameSite)
 }
+
+func TestHandler_MfaChallenge_NoCookie(t *testing.T) {
+	app := fiberx.NewApp()
+
+	Middleware(env.SecurityConfig{AccessCookieName: "jwt", RefreshCookieName: "rft"}).Register(app)
+
+	app.Get("/", func(c *fiber.Ctx) error {
+		c.Locals(local_keys.MfaChallenge, true)
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	res, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	assert.Empty(t, res.Cookies())
+}
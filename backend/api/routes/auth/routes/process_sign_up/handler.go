@@ -1,6 +1,7 @@
 package process_sign_up
 
 import (
+	"vdm/core/device"
 	"vdm/core/locals/local_keys"
 	"vdm/core/models"
 	"vdm/core/validation"
@@ -25,7 +26,7 @@ func (h *handler) processSignUp(c *fiber.Ctx) error {
 		return err
 	}
 
-	accessToken, refreshToken, err := h.svc.createUserAndBuildTokens(req)
+	accessToken, refreshToken, err := h.svc.createUserAndBuildTokens(req, device.FromCtx(c))
 	if err != nil {
 		return err
 	}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http/httptest"
 	"testing"
+	"vdm/core/device"
 	"vdm/core/fiberx"
 	"vdm/core/locals"
 
@@ -14,7 +15,7 @@ import (
 
 type nullService struct{}
 
-func (nullService) createUserAndBuildTokens(req RequestDTO) (locals.AccessToken, locals.RefreshToken, error) {
+func (nullService) createUserAndBuildTokens(req RequestDTO, dev device.Device) (locals.AccessToken, locals.RefreshToken, error) {
 	return locals.AccessToken{}, locals.RefreshToken{}, nil
 }
 
@@ -8,7 +8,7 @@ import (
 )
 
 type Repository interface {
-	createUserAndRefreshToken(user *models.User, rft *models.UserToken) error
+	createUser(user *models.User, openSession func(tx *gorm.DB) error) error
 	userExistsByTag(tag string) (bool, error)
 }
 
@@ -16,7 +16,8 @@ type repository struct {
 	db *gorm.DB
 }
 
-func (r *repository) createUserAndRefreshToken(user *models.User, rft *models.UserToken) (err error) {
+// createUser creates the user as a redactor, then opens their session within the same transaction
+func (r *repository) createUser(user *models.User, openSession func(tx *gorm.DB) error) (err error) {
 	tx := r.db.Begin()
 
 	defer func() {
@@ -46,11 +47,7 @@ func (r *repository) createUserAndRefreshToken(user *models.User, rft *models.Us
 		return
 	}
 
-	rft.UserID = user.ID
-	if err = tx.Create(rft).Error; err != nil {
-		return
-	}
-
+	err = openSession(tx)
 	return
 }
 
@@ -16,12 +16,9 @@ const (
 func Route(db *gorm.DB, cfg env.SecurityConfig) *fiberx.Route {
 	repo := &repository{db}
 	svc := &service{
-		repo:               repo,
-		accessTokenSecret:  cfg.AccessTokenSecret,
-		accessTokenTTL:     cfg.AccessTokenTTL,
-		refreshTokenTTL:    cfg.RefreshTokenTTL,
-		refreshTokenSecret: cfg.RefreshTokenSecret,
-		emailTokenSecret:   cfg.EmailTokenSecret,
+		repo:             repo,
+		security:         cfg,
+		emailTokenSecret: cfg.EmailTokenSecret,
 	}
 	handler := &handler{svc: svc}
 
@@ -4,32 +4,31 @@ import (
 	"fmt"
 	"math/rand"
 	"time"
-	"vdm/core/hmac_utils"
+	"vdm/core/device"
+	"vdm/core/env"
 	"vdm/core/jwt_utils"
 	"vdm/core/locals"
 	"vdm/core/models"
+	"vdm/core/session"
 
-	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 type Service interface {
-	createUserAndBuildTokens(req RequestDTO) (locals.AccessToken, locals.RefreshToken, error)
+	createUserAndBuildTokens(req RequestDTO, dev device.Device) (locals.AccessToken, locals.RefreshToken, error)
 }
 
 type service struct {
-	accessTokenSecret []byte
-	accessTokenTTL    time.Duration
-
-	refreshTokenSecret []byte
-	refreshTokenTTL    time.Duration
+	// security holds the secrets and lifetimes of the session tokens
+	security env.SecurityConfig
 
 	emailTokenSecret []byte
 
 	repo Repository
 }
 
-func (s *service) createUserAndBuildTokens(req RequestDTO) (locals.AccessToken, locals.RefreshToken, error) {
+func (s *service) createUserAndBuildTokens(req RequestDTO, dev device.Device) (locals.AccessToken, locals.RefreshToken, error) {
 	tokenUser, err := jwt_utils.ParseJWT(req.Token, s.emailTokenSecret)
 	if err != nil {
 		return locals.AccessToken{}, locals.RefreshToken{}, fmt.Errorf("failed to parse token: %v", err)
@@ -49,27 +48,18 @@ func (s *service) createUserAndBuildTokens(req RequestDTO) (locals.AccessToken,
 		user.Password = string(hashedPassword)
 	}
 
-	rft := uuid.New()
-	usrTok := &models.UserToken{UserID: user.ID, Hash: hmac_utils.HashUUID(rft, s.refreshTokenSecret),
-		Expiry: time.Now().Add(s.refreshTokenTTL), Category: models.UserTokenCategoryRefresh}
-
-	if err := s.repo.createUserAndRefreshToken(user, usrTok); err != nil {
-		return locals.AccessToken{}, locals.RefreshToken{}, fmt.Errorf("failed to create user and refresh token: %v", err)
-	}
+	var accessToken locals.AccessToken
+	var refreshToken locals.RefreshToken
 
-	jwtExpiry := time.Now().Add(s.accessTokenTTL)
-	jwt, err := jwt_utils.GenerateJWT(locals.AuthedUser{ID: user.ID, Email: user.Email}, s.accessTokenSecret, jwtExpiry)
-	if err != nil {
-		return locals.AccessToken{}, locals.RefreshToken{}, fmt.Errorf("failed to generate JWT: %v", err)
+	// the user is not created without their first session
+	if err := s.repo.createUser(user, func(tx *gorm.DB) (err error) {
+		accessToken, refreshToken, err = session.Open(tx, s.security, *user, dev)
+		return err
+	}); err != nil {
+		return locals.AccessToken{}, locals.RefreshToken{}, fmt.Errorf("failed to create user and open session: %v", err)
 	}
 
-	return locals.AccessToken{
-			Token:  jwt,
-			Expiry: jwtExpiry,
-		}, locals.RefreshToken{
-			Token:  rft,
-			Expiry: usrTok.Expiry,
-		}, nil
+	return accessToken, refreshToken, nil
 }
 
 func (s *service) generateUserTag(username string) (string, error) {
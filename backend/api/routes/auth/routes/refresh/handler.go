@@ -1,6 +1,7 @@
 package refresh
 
 import (
+	"vdm/core/device"
 	"vdm/core/locals/local_keys"
 
 	"github.com/gofiber/fiber/v2"
@@ -22,7 +23,7 @@ func (h *handler) refresh(c *fiber.Ctx) error {
 		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid refresh token"}
 	}
 
-	user, accessToken, refreshToken, err := h.svc.refresh(rft)
+	user, accessToken, refreshToken, err := h.svc.refresh(rft, device.FromCtx(c))
 	if err != nil {
 		return err
 	}
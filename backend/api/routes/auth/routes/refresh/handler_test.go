@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"vdm/core/device"
 	"vdm/core/fiberx"
 	"vdm/core/locals"
 	"vdm/core/locals/local_keys"
@@ -16,7 +17,7 @@ import (
 
 type nullService struct{}
 
-func (nullService) refresh(rftID uuid.UUID) (models.User, locals.AccessToken, locals.RefreshToken, error) {
+func (nullService) refresh(rftID uuid.UUID, dev device.Device) (models.User, locals.AccessToken, locals.RefreshToken, error) {
 	return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, nil
 }
 
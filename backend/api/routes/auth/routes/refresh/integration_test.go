@@ -34,6 +34,13 @@ var secret = []byte("secret")
 var validUsrTok = &models.UserToken{UserID: testUser.ID, Hash: hmac_utils.HashUUID(validRft, secret), Category: models.UserTokenCategoryRefresh, Expiry: time.Now().Add(1 * time.Minute)}
 var expiredUsrTok = &models.UserToken{UserID: testUser.ID, Hash: hmac_utils.HashUUID(expiredRft, secret), Category: models.UserTokenCategoryRefresh, Expiry: time.Now().Add(-1 * time.Minute)}
 
+// a family whose first token was rotated a minute ago, into its current token
+var family = uuid.New()
+var consumedRft = uuid.New()
+var consumedAt = time.Now().Add(-1 * time.Minute)
+var consumedUsrTok = &models.UserToken{UserID: testUser.ID, Hash: hmac_utils.HashUUID(consumedRft, secret), Category: models.UserTokenCategoryRefresh, Expiry: time.Now().Add(1 * time.Minute), FamilyID: &family, ConsumedAt: &consumedAt}
+var currentUsrTok = &models.UserToken{UserID: testUser.ID, Hash: hmac_utils.HashUUID(uuid.New(), secret), Category: models.UserTokenCategoryRefresh, Expiry: time.Now().Add(1 * time.Minute), FamilyID: &family}
+
 func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector) {
 	container, connector = test_utils.NewTestContainerConnector(c, t)
 
@@ -56,7 +63,7 @@ func loadTestData(c context.Context, t *testing.T) (container testcontainers.Con
 		return
 	}
 
-	err = db.Create([]*models.UserToken{validUsrTok, expiredUsrTok}).Error
+	err = db.Create([]*models.UserToken{validUsrTok, expiredUsrTok, consumedUsrTok, currentUsrTok}).Error
 
 	return
 }
@@ -144,3 +151,88 @@ func TestIntegration_Refresh_ErrUnauthorized(t *testing.T) {
 
 	assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
 }
+
+func postRefresh(t *testing.T, app *fiber.App, cfg env.SecurityConfig, rft uuid.UUID) int {
+	req := httptest.NewRequest(Method, Path, nil)
+	req.AddCookie(&http.Cookie{Name: cfg.RefreshCookieName, Value: rft.String()})
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	return res.StatusCode
+}
+
+func TestIntegration_Refresh_RotatesToken(t *testing.T) {
+	c := context.Background()
+	container, connector := loadTestData(c, t)
+	t.Cleanup(func() { cleanupTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	dummyCfg := env.SecurityConfig{
+		AccessTokenSecret:  []byte("dummySecret"),
+		AccessTokenTTL:     1 * time.Minute,
+		RefreshTokenSecret: secret,
+		RefreshTokenTTL:    1 * time.Minute,
+		AccessCookieName:   "jwt",
+		RefreshCookieName:  "rft",
+	}
+	Route(connector.GormDB(), dummyCfg).Register(app)
+
+	assert.Equal(t, fiber.StatusOK, postRefresh(t, app, dummyCfg, validRft))
+
+	var presented models.UserToken
+	if err := connector.GormDB().First(&presented, "id = ?", validUsrTok.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.NotNil(t, presented.ConsumedAt)
+	if assert.NotNil(t, presented.FamilyID) {
+		var next models.UserToken
+		if err := connector.GormDB().First(&next, "family_id = ? AND consumed_at IS NULL", *presented.FamilyID).Error; err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, testUser.ID, next.UserID)
+		assert.NotEqual(t, presented.Hash, next.Hash)
+		// the session outlives the rotation
+		assert.WithinDuration(t, presented.CreatedAt, next.CreatedAt, time.Millisecond)
+	}
+
+	// replayed right after its rotation, the token is refused without revoking its family
+	assert.Equal(t, fiber.StatusUnauthorized, postRefresh(t, app, dummyCfg, validRft))
+
+	var familySize int64
+	if err := connector.GormDB().Model(&models.UserToken{}).Where("family_id = ?", presented.FamilyID).Count(&familySize).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(2), familySize)
+}
+
+func TestIntegration_Refresh_ReuseRevokesFamily(t *testing.T) {
+	c := context.Background()
+	container, connector := loadTestData(c, t)
+	t.Cleanup(func() { cleanupTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	dummyCfg := env.SecurityConfig{
+		AccessTokenSecret:  []byte("dummySecret"),
+		AccessTokenTTL:     1 * time.Minute,
+		RefreshTokenSecret: secret,
+		RefreshTokenTTL:    1 * time.Minute,
+		AccessCookieName:   "jwt",
+		RefreshCookieName:  "rft",
+	}
+	Route(connector.GormDB(), dummyCfg).Register(app)
+
+	assert.Equal(t, fiber.StatusUnauthorized, postRefresh(t, app, dummyCfg, consumedRft))
+
+	var familySize int64
+	if err := connector.GormDB().Model(&models.UserToken{}).Where("family_id = ?", family).Count(&familySize).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(0), familySize)
+
+	// other families are left untouched
+	assert.Equal(t, fiber.StatusOK, postRefresh(t, app, dummyCfg, validRft))
+}
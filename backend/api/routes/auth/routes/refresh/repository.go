@@ -1,61 +1,109 @@
 package refresh
 
 import (
-	"vdm/core/logger"
+	"errors"
+	"time"
 	"vdm/core/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// errTokenReused is returned when a consumed refresh token is presented again, its family is revoked by then
+var errTokenReused = errors.New("refresh token reused")
+
 type Repository interface {
-	findValidRefreshToken(hash string) (models.UserToken, error)
-	createRefreshToken(rft *models.UserToken) error
+	rotateRefreshToken(hash string, next *models.UserToken) (*models.UserToken, error)
 }
 
 type repository struct {
 	db *gorm.DB
 }
 
-func (r *repository) findValidRefreshToken(hash string) (models.UserToken, error) {
-	var rft models.UserToken
-	if err := r.db.Model(&models.UserToken{}).
-		Where("hash = ? AND category = ? AND expiry > NOW()", hash, models.UserTokenCategoryRefresh).
-		Preload("User.Roles", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id", "name")
-		}).
-		First(&rft).Error; err != nil {
-		return models.UserToken{}, err
-	}
-	return rft, nil
-}
+// rotateRefreshToken consumes the refresh token identified by hash and creates next in the same family, i.e. the same session.
+// It returns nil when there is no such token, when it expired, or when it was consumed less than reuseGracePeriod ago.
+// When the token was already consumed more than reuseGracePeriod ago, the whole family is revoked and errTokenReused is returned.
+func (r *repository) rotateRefreshToken(hash string, next *models.UserToken) (*models.UserToken, error) {
+	var rotated, revoked *models.UserToken
 
-func (r *repository) createRefreshToken(rft *models.UserToken) (err error) {
-	tx := r.db.Begin()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var tokens []models.UserToken
 
-	defer func() {
-		if err != nil {
-			if rbErr := tx.Rollback().Error; rbErr != nil {
-				logger.Error("failed to rollback transaction", logger.Err(rbErr))
+		// concurrent rotations of the same token are serialized, so that only one of them consumes it
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("hash = ? AND category = ?", hash, models.UserTokenCategoryRefresh).
+			Limit(1).
+			Find(&tokens).Error; err != nil {
+			return err
+		}
+		if len(tokens) == 0 {
+			return nil
+		}
+
+		token := tokens[0]
+		family := token.ID
+		if token.FamilyID != nil {
+			family = *token.FamilyID
+		}
+
+		if token.ConsumedAt != nil {
+			// a client racing itself, e.g. from two tabs, replays the token right after its rotation
+			if time.Since(*token.ConsumedAt) < reuseGracePeriod {
+				return nil
 			}
+			revoked = &token
+			return tx.Where("family_id = ? OR id = ?", family, token.ID).
+				Delete(&models.UserToken{}).Error
+		}
 
-			return
+		if token.Expired() {
+			return nil
 		}
 
-		if cmErr := tx.Commit().Error; cmErr != nil {
-			logger.Error("failed to commit transaction", logger.Err(cmErr))
-			err = cmErr
+		now := time.Now()
+
+		if err := tx.Model(&models.UserToken{}).
+			Where("id = ?", token.ID).
+			Updates(map[string]any{"consumed_at": now, "family_id": family}).Error; err != nil {
+			return err
+		}
+
+		// consumed tokens are only kept until they expire, then they could not be replayed anyway
+		if err := tx.Where("family_id = ? AND expiry <= ?", family, now).
+			Delete(&models.UserToken{}).Error; err != nil {
+			return err
+		}
+
+		next.UserID = token.UserID
+		next.FamilyID = &family
+		next.CreatedAt = token.CreatedAt
+		if token.DeviceLabel != "" {
+			next.DeviceLabel = token.DeviceLabel
+		}
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+
+		var user models.User
+		if err := tx.Preload("Roles", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name")
+		}).
+			First(&user, "id = ?", token.UserID).Error; err != nil {
+			return err
 		}
-	}()
 
-	if err = tx.Model(&models.UserToken{}).
-		Where("user_id = ? AND category = ?", rft.UserID, models.UserTokenCategoryRefresh).
-		Delete(&models.UserToken{}).Error; err != nil {
-		return
+		token.User = &user
+		token.ConsumedAt = &now
+		rotated = &token
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err = tx.Create(rft).Error; err != nil {
-		return
+	if revoked != nil {
+		return revoked, errTokenReused
 	}
 
-	return
+	return rotated, nil
 }
@@ -1,19 +1,25 @@
 package refresh
 
 import (
+	"errors"
 	"fmt"
 	"time"
+	"vdm/core/device"
 	"vdm/core/hmac_utils"
 	"vdm/core/jwt_utils"
 	"vdm/core/locals"
+	"vdm/core/logger"
 	"vdm/core/models"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
+// reuseGracePeriod tolerates the replay of a refresh token right after its rotation, without revoking its family
+const reuseGracePeriod = 10 * time.Second
+
 type Service interface {
-	refresh(token uuid.UUID) (models.User, locals.AccessToken, locals.RefreshToken, error)
+	refresh(token uuid.UUID, dev device.Device) (models.User, locals.AccessToken, locals.RefreshToken, error)
 }
 
 type service struct {
@@ -26,33 +32,47 @@ type service struct {
 	repo Repository
 }
 
-func (s *service) refresh(rft uuid.UUID) (models.User, locals.AccessToken, locals.RefreshToken, error) {
-	usrTok, err := s.repo.findValidRefreshToken(hmac_utils.HashUUID(rft, s.refreshTokenSecret))
+func (s *service) refresh(rft uuid.UUID, dev device.Device) (models.User, locals.AccessToken, locals.RefreshToken, error) {
+	next := uuid.New()
+	now := time.Now()
+
+	// the device label is only a fallback, the one of the session is kept
+	usrTok := models.UserToken{
+		Expiry:      now.Add(s.refreshTokenTTL),
+		Hash:        hmac_utils.HashUUID(next, s.refreshTokenSecret),
+		Category:    models.UserTokenCategoryRefresh,
+		DeviceLabel: dev.Label(),
+		UserAgent:   dev.UserAgent,
+		IP:          dev.IP,
+		LastUsedAt:  now,
+	}
+
+	presented, err := s.repo.rotateRefreshToken(hmac_utils.HashUUID(rft, s.refreshTokenSecret), &usrTok)
+	if errors.Is(err, errTokenReused) {
+		// the token was stolen, or the legitimate client was served the next one and the thief is replaying it
+		logger.Warn("refresh token reuse detected, token family revoked",
+			logger.Any("event", "refresh_token_reuse"),
+			logger.Any("userId", presented.UserID),
+			logger.Any("familyId", presented.FamilyID),
+			logger.Any("consumedAt", presented.ConsumedAt),
+			logger.Any("ip", dev.IP))
+		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, &fiber.Error{Code: fiber.StatusUnauthorized, Message: "invalid refresh rft"}
+	}
 	if err != nil {
+		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, fmt.Errorf("failed to rotate refresh rft: %v", err)
+	}
+	if presented == nil {
 		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, &fiber.Error{Code: fiber.StatusUnauthorized, Message: "invalid refresh rft"}
 	}
 
-	user := usrTok.User
+	user := presented.User
 	if user == nil {
 		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, &fiber.Error{Code: fiber.StatusInternalServerError, Message: "unexpected nil user"}
 	}
 
-	rft = uuid.New()
-
-	usrTok = models.UserToken{
-		UserID:   usrTok.UserID,
-		Expiry:   time.Now().Add(s.refreshTokenTTL),
-		Hash:     hmac_utils.HashUUID(rft, s.refreshTokenSecret),
-		Category: models.UserTokenCategoryRefresh,
-	}
-
-	if err = s.repo.createRefreshToken(&usrTok); err != nil {
-		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, fmt.Errorf("failed to create refresh rft: %v", err)
-	}
-
 	jwtExpiry := time.Now().Add(s.accessTokenTTL)
 	jwt, err := jwt_utils.GenerateJWT(
-		locals.AuthedUser{ID: user.ID, Email: user.Email},
+		locals.AuthedUser{ID: user.ID, Email: user.Email, SessionID: *usrTok.FamilyID},
 		s.accessTokenSecret, jwtExpiry)
 	if err != nil {
 		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, fmt.Errorf("failed to generate JWT: %v", err)
@@ -63,7 +83,7 @@ func (s *service) refresh(rft uuid.UUID) (models.User, locals.AccessToken, local
 			Token:  jwt,
 			Expiry: jwtExpiry,
 		}, locals.RefreshToken{
-			Token:  rft,
+			Token:  next,
 			Expiry: usrTok.Expiry,
 		}, nil
 }
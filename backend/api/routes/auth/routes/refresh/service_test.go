@@ -0,0 +1,86 @@
+package refresh
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"vdm/core/device"
+	"vdm/core/jwt_utils"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubRepository struct {
+	presented *models.UserToken
+	err       error
+	next      *models.UserToken
+}
+
+func (r *stubRepository) rotateRefreshToken(hash string, next *models.UserToken) (*models.UserToken, error) {
+	r.next = next
+	if r.presented != nil && r.err == nil {
+		next.FamilyID = r.presented.FamilyID
+	}
+	return r.presented, r.err
+}
+
+func newTestService(repo Repository) *service {
+	return &service{
+		accessTokenSecret:  []byte("access"),
+		accessTokenTTL:     time.Minute,
+		refreshTokenSecret: []byte("refresh"),
+		refreshTokenTTL:    time.Hour,
+		repo:               repo,
+	}
+}
+
+func TestService_Refresh_ErrUnauthorized(t *testing.T) {
+	family := uuid.New()
+	consumedAt := time.Now().Add(-time.Hour)
+
+	for name, repo := range map[string]*stubRepository{
+		"unknown or expired": {},
+		"reused": {
+			presented: &models.UserToken{UserID: uuid.New(), FamilyID: &family, ConsumedAt: &consumedAt},
+			err:       errTokenReused,
+		},
+	} {
+		_, _, _, err := newTestService(repo).refresh(uuid.New(), device.Device{IP: "192.0.2.1"})
+
+		var fiberErr *fiber.Error
+		if assert.True(t, errors.As(err, &fiberErr), name) {
+			assert.Equal(t, fiber.StatusUnauthorized, fiberErr.Code, name)
+		}
+	}
+}
+
+func TestService_Refresh_Success(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Tag: "user0123"}
+	family := uuid.New()
+	repo := &stubRepository{presented: &models.UserToken{UserID: user.ID, User: user, FamilyID: &family}}
+
+	rft := uuid.New()
+	actualUser, accessToken, refreshToken, err := newTestService(repo).refresh(rft, device.Device{IP: "192.0.2.1", UserAgent: "curl/8.5.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, user.ID, actualUser.ID)
+	assert.NotEmpty(t, accessToken.Token)
+	assert.NotEqual(t, rft, refreshToken.Token)
+	if assert.NotNil(t, repo.next) {
+		assert.Equal(t, models.UserTokenCategoryRefresh, repo.next.Category)
+		assert.Equal(t, refreshToken.Expiry, repo.next.Expiry)
+		assert.Equal(t, "192.0.2.1", repo.next.IP)
+		assert.Equal(t, "curl/8.5.0", repo.next.UserAgent)
+	}
+
+	authedUser, err := jwt_utils.ParseJWT(accessToken.Token, []byte("access"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, family, authedUser.SessionID)
+}
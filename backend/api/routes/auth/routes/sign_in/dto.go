@@ -15,4 +15,13 @@ type ResponseDTO struct {
 	RefreshTokenExpiry time.Time         `json:"refreshTokenExpiry"`
 	Roles              []models.RoleName `json:"roles,omitempty"`
 	Tag                string            `json:"tag"`
+
+	MfaEnrollmentRequired bool `json:"mfaEnrollmentRequired,omitempty"`
+}
+
+// MfaChallengeDTO answers a valid password when the user enrolled a second factor, the tokens are issued by sign_in_mfa
+type MfaChallengeDTO struct {
+	MfaRequired    bool      `json:"mfaRequired"`
+	MfaToken       string    `json:"mfaToken"`
+	MfaTokenExpiry time.Time `json:"mfaTokenExpiry"`
 }
@@ -1,7 +1,12 @@
 package sign_in
 
 import (
+	"errors"
+	"math"
+	"strconv"
+	"vdm/core/device"
 	"vdm/core/locals/local_keys"
+	"vdm/core/lockout"
 	"vdm/core/validation"
 
 	"github.com/gofiber/fiber/v2"
@@ -24,7 +29,28 @@ func (h *handler) signIn(c *fiber.Ctx) error {
 		return err
 	}
 
-	user, accessToken, refreshToken, err := h.svc.signIn(req)
+	user, mfaEnabled, err := h.svc.authenticate(c.UserContext(), req, device.FromCtx(c).IP)
+	if err != nil {
+		var lockErr *lockout.Error
+		if errors.As(err, &lockErr) {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(lockErr.RetryAfter.Seconds()))))
+		}
+		return err
+	}
+
+	// the tokens are issued by the second step, set_auth_cookies has nothing to set
+	if mfaEnabled {
+		challenge, err := h.svc.challenge(user)
+		if err != nil {
+			return err
+		}
+
+		c.Locals(local_keys.MfaChallenge, true)
+
+		return c.Status(fiber.StatusOK).JSON(challenge)
+	}
+
+	accessToken, refreshToken, err := h.svc.openSession(user, device.FromCtx(c))
 	if err != nil {
 		return err
 	}
@@ -37,5 +63,7 @@ func (h *handler) signIn(c *fiber.Ctx) error {
 		RefreshTokenExpiry: refreshToken.Expiry,
 		Roles:              user.RoleNames(),
 		Tag:                user.Tag,
+		// the user can sign in until they enroll, but not reach the routes of their privileged roles
+		MfaEnrollmentRequired: user.RequiresMFA(),
 	})
 }
@@ -0,0 +1,95 @@
+package sign_in
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/device"
+	"vdm/core/env"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/lockout"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type lockedOutService struct{}
+
+func (lockedOutService) authenticate(ctx context.Context, req RequestDTO, ip string) (models.User, bool, error) {
+	return models.User{}, false, &lockout.Error{RetryAfter: 90*time.Second + time.Millisecond}
+}
+
+func (lockedOutService) challenge(user models.User) (MfaChallengeDTO, error) {
+	return MfaChallengeDTO{}, nil
+}
+
+func (lockedOutService) openSession(user models.User, dev device.Device) (locals.AccessToken, locals.RefreshToken, error) {
+	return locals.AccessToken{}, locals.RefreshToken{}, nil
+}
+
+func TestHandler_ErrTooManyRequests(t *testing.T) {
+	h := &handler{svc: lockedOutService{}}
+
+	app := fiberx.NewApp()
+	app.Add(Method, Path, h.signIn)
+
+	b, _ := json.Marshal(RequestDTO{Email: "user@email.com", Password: "Test123!"})
+	req := httptest.NewRequest(Method, Path, bytes.NewReader(b))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusTooManyRequests, res.StatusCode)
+	// rounded up, so that the next attempt is never made too early
+	assert.Equal(t, "91", res.Header.Get(fiber.HeaderRetryAfter))
+}
+
+func TestHandler_CountsFailuresByForwardedIP(t *testing.T) {
+	user := newTestUser(t)
+	svc := &service{
+		repo: &stubRepository{user: user},
+		guard: lockout.New(lockout.NewMemoryStore(), env.LockoutConfig{
+			BackoffAfter:       1,
+			BackoffBase:        time.Hour,
+			BackoffMax:         time.Hour,
+			AccountMaxFailures: 100,
+			IPMaxFailures:      100,
+			Duration:           time.Hour,
+			FailuresTTL:        time.Hour,
+		}),
+	}
+	h := &handler{svc}
+
+	// requests made by app.Test come from 0.0.0.0, standing for the ingress
+	app := fiberx.NewApp(fiberx.WithProxy(env.ProxyConfig{Header: "X-Real-Ip", TrustedProxies: []string{"0.0.0.0/8"}}))
+	app.Add(Method, Path, h.signIn)
+
+	signIn := func(email, realIP string) int {
+		b, _ := json.Marshal(RequestDTO{Email: email, Password: "wrong"})
+		req := httptest.NewRequest(Method, Path, bytes.NewReader(b))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Set("X-Real-Ip", realIP)
+
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+		return res.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusUnauthorized, signIn("first@email.com", "203.0.113.1"))
+	// the first client backs off, on any account
+	assert.Equal(t, fiber.StatusTooManyRequests, signIn("second@email.com", "203.0.113.1"))
+	// the second client, behind the same ingress, has its own counter
+	assert.Equal(t, fiber.StatusUnauthorized, signIn("second@email.com", "203.0.113.2"))
+}
@@ -12,10 +12,12 @@ import (
 	"vdm/core/dependencies/database"
 	"vdm/core/env"
 	"vdm/core/fiberx"
+	"vdm/core/lockout"
 	"vdm/core/models"
 	"vdm/test_utils"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/testcontainers/testcontainers-go"
 	"golang.org/x/crypto/bcrypt"
@@ -73,7 +75,14 @@ func TestIntegration_Success(t *testing.T) {
 
 	dummyCfg := env.SecurityConfig{AccessTokenSecret: []byte("dummySecret"), AccessTokenTTL: 1 * time.Minute, RefreshTokenTTL: 1 * time.Minute}
 
-	Route(connector.GormDB(), dummyCfg).Register(app)
+	Route(connector.GormDB(), dummyCfg, lockout.New(lockout.NewMemoryStore(), testLockoutConfig), nil, "").Register(app)
+
+	// a session started on another device
+	otherFamily := uuid.New()
+	if err := connector.GormDB().Create(&models.UserToken{UserID: testUser.ID, Hash: "other", Category: models.UserTokenCategoryRefresh,
+		Expiry: time.Now().Add(time.Minute), FamilyID: &otherFamily}).Error; err != nil {
+		t.Fatal(err)
+	}
 
 	reqDTO := RequestDTO{
 		Email:    testUser.Email,
@@ -83,6 +92,7 @@ func TestIntegration_Success(t *testing.T) {
 
 	req := httptest.NewRequest(Method, Path, bytes.NewReader(b))
 	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderUserAgent, "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:131.0) Gecko/20100101 Firefox/131.0")
 
 	res, err := app.Test(req)
 	if err != nil {
@@ -109,7 +119,16 @@ func TestIntegration_Success(t *testing.T) {
 		Count(&tokenCount).Error; err != nil {
 		t.Fatal(err)
 	}
-	assert.Equal(t, int64(1), tokenCount)
+	assert.Equal(t, int64(2), tokenCount)
+
+	var session models.UserToken
+	if err = connector.GormDB().
+		Where("user_id = ? AND family_id <> ?", testUser.ID, otherFamily).
+		First(&session).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Firefox on Windows", session.DeviceLabel)
+	assert.NotEmpty(t, session.IP)
 }
 
 func TestIntegration_WrongPassword(t *testing.T) {
@@ -121,7 +140,7 @@ func TestIntegration_WrongPassword(t *testing.T) {
 
 	dummyCfg := env.SecurityConfig{AccessTokenSecret: []byte("dummySecret"), AccessTokenTTL: 1 * time.Minute, RefreshTokenTTL: 1 * time.Minute}
 
-	Route(connector.GormDB(), dummyCfg).Register(app)
+	Route(connector.GormDB(), dummyCfg, lockout.New(lockout.NewMemoryStore(), testLockoutConfig), nil, "").Register(app)
 
 	reqDTO := RequestDTO{
 		Email:    testUser.Email,
@@ -150,7 +169,7 @@ func TestIntegration_EmailNotFound(t *testing.T) {
 
 	dummyCfg := env.SecurityConfig{AccessTokenSecret: []byte("dummySecret"), AccessTokenTTL: 1 * time.Minute, RefreshTokenTTL: 1 * time.Minute}
 
-	Route(connector.GormDB(), dummyCfg).Register(app)
+	Route(connector.GormDB(), dummyCfg, lockout.New(lockout.NewMemoryStore(), testLockoutConfig), nil, "").Register(app)
 
 	reqDTO := RequestDTO{
 		Email:    "unknown_" + strconv.FormatInt(time.Now().UnixNano(), 10) + "@email.com",
@@ -169,3 +188,50 @@ func TestIntegration_EmailNotFound(t *testing.T) {
 
 	assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
 }
+
+func TestIntegration_MfaChallenge(t *testing.T) {
+	c := context.Background()
+	container, connector := loadTestData(c, t)
+	t.Cleanup(func() { cleanupTestData(c, t, container, connector) })
+
+	confirmedAt := time.Now()
+	if err := connector.GormDB().Create(&models.UserTOTP{UserID: testUser.ID, Secret: "encrypted", ConfirmedAt: &confirmedAt}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiberx.NewApp()
+
+	dummyCfg := env.SecurityConfig{AccessTokenSecret: []byte("dummySecret"), AccessTokenTTL: 1 * time.Minute, RefreshTokenTTL: 1 * time.Minute,
+		MfaTokenSecret: []byte("mfa"), MfaTokenTTL: 1 * time.Minute}
+
+	Route(connector.GormDB(), dummyCfg, lockout.New(lockout.NewMemoryStore(), testLockoutConfig), nil, "").Register(app)
+
+	b, _ := json.Marshal(RequestDTO{Email: testUser.Email, Password: "Test123!"})
+
+	req := httptest.NewRequest(Method, Path, bytes.NewReader(b))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	var dto MfaChallengeDTO
+	if err = json.NewDecoder(res.Body).Decode(&dto); err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, dto.MfaRequired)
+	assert.NotEmpty(t, dto.MfaToken)
+
+	// no session is opened before the second step
+	var tokenCount int64
+	if err = connector.GormDB().Model(&models.UserToken{}).
+		Where("user_id = ?", testUser.ID).
+		Count(&tokenCount).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(0), tokenCount)
+}
@@ -1,15 +1,16 @@
 package sign_in
 
 import (
-	"vdm/core/logger"
 	"vdm/core/models"
+	"vdm/core/session"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
 	findUserByEmail(email string) (models.User, error)
-	createRefreshToken(rft *models.UserToken) error
+	totpConfirmed(userID uuid.UUID) (bool, error)
 }
 
 type repository struct {
@@ -30,29 +31,6 @@ func (r *repository) findUserByEmail(email string) (models.User, error) {
 	return user, nil
 }
 
-func (r *repository) createRefreshToken(rft *models.UserToken) (err error) {
-	tx := r.db.Begin()
-
-	defer func() {
-		if err != nil {
-			if rbErr := tx.Rollback().Error; rbErr != nil {
-				logger.Error("failed to rollback transaction", logger.Err(rbErr))
-			}
-			return
-		}
-
-		if cmErr := tx.Commit().Error; cmErr != nil {
-			logger.Error("failed to commit transaction", logger.Err(cmErr))
-			err = cmErr
-		}
-	}()
-
-	if err = tx.Model(&models.UserToken{}).
-		Where("user_id = ? AND category = ?", rft.UserID, models.UserTokenCategoryRefresh).
-		Delete(&models.UserToken{}).Error; err != nil {
-		return
-	}
-
-	err = tx.Create(rft).Error
-	return
+func (r *repository) totpConfirmed(userID uuid.UUID) (bool, error) {
+	return session.TOTPConfirmed(r.db, userID)
 }
@@ -1,8 +1,11 @@
 package sign_in
 
 import (
+	"vdm/core/dependencies/mailer"
 	"vdm/core/env"
 	"vdm/core/fiberx"
+	"vdm/core/lockout"
+	"vdm/core/session"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -13,14 +16,16 @@ const (
 	Method = fiber.MethodPost
 )
 
-func Route(db *gorm.DB, cfg env.SecurityConfig) *fiberx.Route {
+func Route(db *gorm.DB, cfg env.SecurityConfig, guard *lockout.Guard, mailer mailer.Mailer, clientURL string) *fiberx.Route {
 	repo := &repository{db}
 	svc := &service{
-		repo:               repo,
-		accessTokenSecret:  cfg.AccessTokenSecret,
-		accessTokenTTL:     cfg.AccessTokenTTL,
-		refreshTokenTTL:    cfg.RefreshTokenTTL,
-		refreshTokenSecret: cfg.RefreshTokenSecret,
+		repo:           repo,
+		sessions:       session.NewOpener(db, cfg),
+		mfaTokenSecret: cfg.MfaTokenSecret,
+		mfaTokenTTL:    cfg.MfaTokenTTL,
+		guard:          guard,
+		mailer:         mailer,
+		clientURL:      clientURL,
 	}
 	handler := &handler{svc}
 
@@ -1,64 +1,104 @@
 package sign_in
 
 import (
+	"context"
 	"fmt"
 	"time"
-	"vdm/core/hmac_utils"
+	"vdm/core/dependencies/mailer"
+	"vdm/core/device"
 	"vdm/core/jwt_utils"
 	"vdm/core/locals"
+	"vdm/core/lockout"
+	"vdm/core/logger"
 	"vdm/core/models"
+	"vdm/core/session"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type Service interface {
-	signIn(req RequestDTO) (models.User, locals.AccessToken, locals.RefreshToken, error)
+	authenticate(ctx context.Context, req RequestDTO, ip string) (models.User, bool, error)
+	challenge(user models.User) (MfaChallengeDTO, error)
+	openSession(user models.User, dev device.Device) (locals.AccessToken, locals.RefreshToken, error)
 }
 
 type service struct {
-	accessTokenSecret []byte
-	accessTokenTTL    time.Duration
+	sessions session.Opener
 
-	refreshTokenSecret []byte
-	refreshTokenTTL    time.Duration
+	mfaTokenSecret []byte
+	mfaTokenTTL    time.Duration
+
+	guard     *lockout.Guard
+	mailer    mailer.Mailer
+	clientURL string
 
 	repo Repository
 }
 
-func (s *service) signIn(req RequestDTO) (models.User, locals.AccessToken, locals.RefreshToken, error) {
+// authenticate checks the password of the user, and tells whether they enrolled a second factor to check next.
+// The account and the IP are refused for a while after too many failures, whether the account exists or not.
+func (s *service) authenticate(ctx context.Context, req RequestDTO, ip string) (models.User, bool, error) {
+	account := lockout.Account(req.Email)
+	if err := s.guard.Check(ctx, account, lockout.IP(ip)); err != nil {
+		return models.User{}, false, err
+	}
+
 	user, err := s.repo.findUserByEmail(req.Email)
 	if err != nil {
-		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, &fiber.Error{Code: fiber.StatusUnauthorized, Message: "user not found"}
+		s.guard.Fail(ctx, account, lockout.IP(ip))
+		return models.User{}, false, &fiber.Error{Code: fiber.StatusUnauthorized, Message: "user not found"}
 	}
 
 	if err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, &fiber.Error{Code: fiber.StatusUnauthorized, Message: "wrong password"}
+		for _, locked := range s.guard.Fail(ctx, account, lockout.IP(ip)) {
+			if locked == account {
+				s.notifyLockout(user, req.Email)
+			}
+		}
+		return models.User{}, false, &fiber.Error{Code: fiber.StatusUnauthorized, Message: "wrong password"}
 	}
 
-	rft := uuid.New()
+	// the IP keeps its failures, an attacker could otherwise reset them by signing in to their own account
+	s.guard.Reset(ctx, account)
 
-	usrTok := models.UserToken{
-		UserID:   user.ID,
-		Expiry:   time.Now().Add(s.refreshTokenTTL),
-		Hash:     hmac_utils.HashUUID(rft, s.refreshTokenSecret),
-		Category: models.UserTokenCategoryRefresh,
+	mfaEnabled, err := s.repo.totpConfirmed(user.ID)
+	if err != nil {
+		return models.User{}, false, fmt.Errorf("failed to check totp: %v", err)
 	}
 
-	if err = s.repo.createRefreshToken(&usrTok); err != nil {
-		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, fmt.Errorf("failed to create refresh rft: %v", err)
+	return user, mfaEnabled, nil
+}
+
+// notifyLockout tells the user their account was locked, the failed sign-in being answered whether the email was sent or not
+func (s *service) notifyLockout(user models.User, email string) {
+	logger.Warn("account locked out",
+		logger.Any("event", "sign_in_lockout"),
+		logger.Any("userId", user.ID))
+
+	if err := s.mailer.Send(
+		email,
+		"Votre compte Vigie du mensonge est temporairement verrouillé",
+		fmt.Sprintf("Suite à plusieurs tentatives de connexion échouées, la connexion à votre compte Vigie du mensonge par mot de passe est bloquée pendant %d minutes.\n", int(s.guard.Duration().Minutes()))+
+			"Si ces tentatives ne viennent pas de vous, nous vous conseillons de modifier votre mot de passe à partir de la page de connexion (lien ci-dessous)."+
+			"\n\n"+s.clientURL+"/sign-in",
+	); err != nil {
+		logger.Error("failed to send lockout email", logger.Err(err), logger.Any("userId", user.ID))
 	}
+}
+
+// challenge signs the proof that the password of the user was checked, to be presented with the second factor
+func (s *service) challenge(user models.User) (MfaChallengeDTO, error) {
+	expiry := time.Now().Add(s.mfaTokenTTL)
 
-	jwtExpiry := time.Now().Add(s.accessTokenTTL)
-	jwt, err := jwt_utils.GenerateJWT(locals.AuthedUser{ID: user.ID, Email: user.Email},
-		s.accessTokenSecret, jwtExpiry)
+	token, err := jwt_utils.GenerateJWT(locals.AuthedUser{ID: user.ID}, s.mfaTokenSecret, expiry)
 	if err != nil {
-		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, fmt.Errorf("failed to generate JWT: %v", err)
+		return MfaChallengeDTO{}, fmt.Errorf("failed to generate mfa token: %v", err)
 	}
 
-	return user,
-		locals.AccessToken{Token: jwt, Expiry: jwtExpiry},
-		locals.RefreshToken{Token: rft, Expiry: usrTok.Expiry},
-		nil
+	return MfaChallengeDTO{MfaRequired: true, MfaToken: token, MfaTokenExpiry: expiry}, nil
+}
+
+func (s *service) openSession(user models.User, dev device.Device) (locals.AccessToken, locals.RefreshToken, error) {
+	return s.sessions.Open(user, dev)
 }
@@ -0,0 +1,146 @@
+package sign_in
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+	"vdm/core/env"
+	"vdm/core/lockout"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var testLockoutConfig = env.LockoutConfig{
+	BackoffAfter:       3,
+	BackoffBase:        time.Millisecond,
+	BackoffMax:         time.Millisecond,
+	AccountMaxFailures: 5,
+	IPMaxFailures:      20,
+	Duration:           30 * time.Minute,
+	FailuresTTL:        time.Hour,
+}
+
+type stubRepository struct {
+	user *models.User
+}
+
+func (r *stubRepository) findUserByEmail(email string) (models.User, error) {
+	if r.user == nil || r.user.Email != email {
+		return models.User{}, gorm.ErrRecordNotFound
+	}
+	return *r.user, nil
+}
+
+func (r *stubRepository) totpConfirmed(userID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func newTestUser(t *testing.T) *models.User {
+	pwd, err := bcrypt.GenerateFromPassword([]byte("Test123!"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &models.User{ID: uuid.New(), Email: "user@email.com", Tag: "user0123", Password: string(pwd)}
+}
+
+// fail waits for the backoff of the previous failures, then fails once more
+func fail(t *testing.T, svc *service, req RequestDTO, ip string) {
+	time.Sleep(2 * testLockoutConfig.BackoffMax)
+
+	_, _, err := svc.authenticate(context.Background(), req, ip)
+
+	var fiberErr *fiber.Error
+	if assert.True(t, errors.As(err, &fiberErr)) {
+		assert.Equal(t, fiber.StatusUnauthorized, fiberErr.Code)
+	}
+}
+
+func TestService_Authenticate_LocksOutAccount(t *testing.T) {
+	user := newTestUser(t)
+
+	mockCtrl := gomock.NewController(t)
+	mailer := test_utils.NewMockMailer(mockCtrl)
+	// the email is sent once, when the account gets locked
+	mailer.EXPECT().Send(user.Email, "Votre compte Vigie du mensonge est temporairement verrouillé", gomock.Any()).Return(nil).Times(1)
+
+	svc := &service{
+		repo:      &stubRepository{user: user},
+		guard:     lockout.New(lockout.NewMemoryStore(), testLockoutConfig),
+		mailer:    mailer,
+		clientURL: "http://localhost:5173",
+	}
+
+	wrong := RequestDTO{Email: user.Email, Password: "wrong"}
+	for i := range testLockoutConfig.AccountMaxFailures {
+		// the attempts come from several IPs, only the account is locked
+		fail(t, svc, wrong, "192.0.2."+strconv.FormatInt(i+1, 10))
+	}
+
+	// the right password is refused as well, from any IP
+	_, _, err := svc.authenticate(context.Background(), RequestDTO{Email: "USER@email.com", Password: "Test123!"}, "198.51.100.1")
+
+	var lockErr *lockout.Error
+	if assert.True(t, errors.As(err, &lockErr)) {
+		assert.InDelta(t, testLockoutConfig.Duration, lockErr.RetryAfter, float64(time.Second))
+	}
+}
+
+func TestService_Authenticate_UnknownAccount(t *testing.T) {
+	// no email is sent for accounts that do not exist
+	mockCtrl := gomock.NewController(t)
+	mailer := test_utils.NewMockMailer(mockCtrl)
+
+	svc := &service{
+		repo:   &stubRepository{},
+		guard:  lockout.New(lockout.NewMemoryStore(), testLockoutConfig),
+		mailer: mailer,
+	}
+
+	req := RequestDTO{Email: "unknown@email.com", Password: "Test123!"}
+	for range testLockoutConfig.AccountMaxFailures {
+		fail(t, svc, req, "192.0.2.1")
+	}
+
+	_, _, err := svc.authenticate(context.Background(), req, "198.51.100.1")
+
+	var fiberErr *fiber.Error
+	if assert.True(t, errors.As(err, &fiberErr)) {
+		assert.Equal(t, fiber.StatusTooManyRequests, fiberErr.Code)
+	}
+}
+
+func TestService_Authenticate_SuccessResetsAccount(t *testing.T) {
+	user := newTestUser(t)
+
+	svc := &service{
+		repo:  &stubRepository{user: user},
+		guard: lockout.New(lockout.NewMemoryStore(), testLockoutConfig),
+	}
+
+	wrong := RequestDTO{Email: user.Email, Password: "wrong"}
+	for range testLockoutConfig.AccountMaxFailures - 1 {
+		fail(t, svc, wrong, "192.0.2.1")
+	}
+
+	time.Sleep(2 * testLockoutConfig.BackoffMax)
+
+	actualUser, _, err := svc.authenticate(context.Background(), RequestDTO{Email: user.Email, Password: "Test123!"}, "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, user.ID, actualUser.ID)
+
+	// the failures of the account start over, the lockout is not reached
+	for range testLockoutConfig.AccountMaxFailures - 1 {
+		fail(t, svc, wrong, "192.0.2.1")
+	}
+}
@@ -0,0 +1,20 @@
+package sign_in_mfa
+
+import (
+	"time"
+	"vdm/core/models"
+)
+
+// RequestDTO carries the mfaToken returned by sign_in, with either a TOTP code or a recovery code
+type RequestDTO struct {
+	MfaToken     string `json:"mfaToken" validate:"required"`
+	Code         string `json:"code" validate:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode string `json:"recoveryCode" validate:"required_without=Code,omitempty,max=32"`
+}
+
+type ResponseDTO struct {
+	AccessTokenExpiry  time.Time         `json:"accessTokenExpiry"`
+	RefreshTokenExpiry time.Time         `json:"refreshTokenExpiry"`
+	Roles              []models.RoleName `json:"roles,omitempty"`
+	Tag                string            `json:"tag"`
+}
@@ -0,0 +1,48 @@
+package sign_in_mfa
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"vdm/core/device"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type nullService struct{}
+
+func (nullService) signInMfa(req RequestDTO, dev device.Device) (models.User, locals.AccessToken, locals.RefreshToken, error) {
+	return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, nil
+}
+
+func TestHandler_ErrBadRequest(t *testing.T) {
+	h := &handler{svc: nullService{}}
+
+	app := fiberx.NewApp()
+	app.Add(Method, Path, h.signInMfa)
+
+	for name, reqDTO := range map[string]RequestDTO{
+		"no token":      {Code: "123456"},
+		"no code":       {MfaToken: "token"},
+		"short code":    {MfaToken: "token", Code: "12345"},
+		"alpha code":    {MfaToken: "token", Code: "abcdef"},
+		"long recovery": {MfaToken: "token", RecoveryCode: string(bytes.Repeat([]byte("a"), 33))},
+	} {
+		b, _ := json.Marshal(reqDTO)
+		req := httptest.NewRequest(Method, Path, bytes.NewReader(b))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode, name)
+	}
+}
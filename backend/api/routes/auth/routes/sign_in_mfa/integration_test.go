@@ -0,0 +1,148 @@
+package sign_in_mfa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/aes_utils"
+	"vdm/core/dependencies/database"
+	"vdm/core/env"
+	"vdm/core/fiberx"
+	"vdm/core/hmac_utils"
+	"vdm/core/jwt_utils"
+	"vdm/core/locals"
+	"vdm/core/models"
+	"vdm/core/totp"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+var testCfg = env.SecurityConfig{
+	AccessTokenSecret:  []byte("access"),
+	AccessTokenTTL:     time.Minute,
+	RefreshTokenSecret: []byte("refresh"),
+	RefreshTokenTTL:    time.Minute,
+	MfaTokenSecret:     []byte("mfa"),
+	MfaEncryptionKey:   bytes.Repeat([]byte("k"), 32),
+}
+
+type testData struct {
+	user   *models.User
+	secret string
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	role := &models.Role{Name: models.RoleModerator}
+	data.user = &models.User{Email: "moderator@test.com", Tag: "moderator0123", Password: "x", Roles: []*models.Role{role}}
+	if err = connector.GormDB().Create(data.user).Error; err != nil {
+		return
+	}
+
+	if data.secret, err = totp.GenerateSecret(); err != nil {
+		return
+	}
+	var encrypted string
+	if encrypted, err = aes_utils.Encrypt(data.secret, testCfg.MfaEncryptionKey); err != nil {
+		return
+	}
+
+	confirmedAt := time.Now()
+	if err = connector.GormDB().Create(&models.UserTOTP{UserID: data.user.ID, Secret: encrypted, ConfirmedAt: &confirmedAt}).Error; err != nil {
+		return
+	}
+
+	err = connector.GormDB().Create(&models.UserRecoveryCode{UserID: data.user.ID,
+		Hash: hmac_utils.HashString(totp.NormalizeRecoveryCode("abcde-23456"), testCfg.MfaEncryptionKey)}).Error
+	return
+}
+
+func postSignInMfa(t *testing.T, app *fiber.App, reqDTO RequestDTO) int {
+	b, _ := json.Marshal(reqDTO)
+	req := httptest.NewRequest(Method, Path, bytes.NewReader(b))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	return res.StatusCode
+}
+
+func newMfaToken(t *testing.T, user *models.User) string {
+	token, err := jwt_utils.GenerateJWT(locals.AuthedUser{ID: user.ID}, testCfg.MfaTokenSecret, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB(), testCfg).Register(app)
+
+	code, err := totp.Code(data.secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a wrong code first
+	assert.Equal(t, fiber.StatusUnauthorized, postSignInMfa(t, app, RequestDTO{MfaToken: newMfaToken(t, data.user), RecoveryCode: "wrong"}))
+
+	assert.Equal(t, fiber.StatusOK, postSignInMfa(t, app, RequestDTO{MfaToken: newMfaToken(t, data.user), Code: code}))
+
+	var userTOTP models.UserTOTP
+	if err = connector.GormDB().First(&userTOTP, "user_id = ?", data.user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, totp.Step(time.Now()), userTOTP.LastUsedStep)
+	assert.Equal(t, 0, userTOTP.FailedAttempts)
+
+	var sessions int64
+	if err = connector.GormDB().Model(&models.UserToken{}).
+		Where("user_id = ? AND category = ?", data.user.ID, models.UserTokenCategoryRefresh).
+		Count(&sessions).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(1), sessions)
+
+	// the code can't be replayed, the recovery code is used once
+	assert.Equal(t, fiber.StatusUnauthorized, postSignInMfa(t, app, RequestDTO{MfaToken: newMfaToken(t, data.user), Code: code}))
+	assert.Equal(t, fiber.StatusOK, postSignInMfa(t, app, RequestDTO{MfaToken: newMfaToken(t, data.user), RecoveryCode: "ABCDE-23456"}))
+	assert.Equal(t, fiber.StatusUnauthorized, postSignInMfa(t, app, RequestDTO{MfaToken: newMfaToken(t, data.user), RecoveryCode: "ABCDE-23456"}))
+}
+
+func TestIntegration_ErrTooManyRequests(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB(), testCfg).Register(app)
+
+	for range totp.MaxFailedAttempts {
+		assert.Equal(t, fiber.StatusUnauthorized, postSignInMfa(t, app, RequestDTO{MfaToken: newMfaToken(t, data.user), RecoveryCode: "wrong"}))
+	}
+
+	assert.Equal(t, fiber.StatusTooManyRequests, postSignInMfa(t, app, RequestDTO{MfaToken: newMfaToken(t, data.user), RecoveryCode: "abcde-23456"}))
+}
@@ -0,0 +1,93 @@
+package sign_in_mfa
+
+import (
+	"time"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	findTOTP(userID uuid.UUID) (*models.UserTOTP, error)
+	useTOTPStep(userID uuid.UUID, step int64) (bool, error)
+	useRecoveryCode(userID uuid.UUID, hash string) (bool, error)
+	recordFailedAttempt(userID uuid.UUID, lockoutDuration time.Duration) error
+	findUser(userID uuid.UUID) (models.User, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) findTOTP(userID uuid.UUID) (*models.UserTOTP, error) {
+	var totps []models.UserTOTP
+	if err := r.db.Where("user_id = ?", userID).
+		Limit(1).
+		Find(&totps).Error; err != nil {
+		return nil, err
+	}
+	if len(totps) == 0 {
+		return nil, nil
+	}
+	return &totps[0], nil
+}
+
+// useTOTPStep records the step of an accepted code and resets the failed attempts.
+// It returns false when a concurrent request used the same step, or a later one, first.
+func (r *repository) useTOTPStep(userID uuid.UUID, step int64) (bool, error) {
+	res := r.db.Model(&models.UserTOTP{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Updates(map[string]any{"last_used_step": step, "failed_attempts": 0, "last_failed_at": nil})
+	return res.RowsAffected > 0, res.Error
+}
+
+// useRecoveryCode consumes the unused recovery code identified by hash and resets the failed attempts
+func (r *repository) useRecoveryCode(userID uuid.UUID, hash string) (bool, error) {
+	var used bool
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.UserRecoveryCode{}).
+			Where("user_id = ? AND hash = ? AND used_at IS NULL", userID, hash).
+			Update("used_at", time.Now())
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		used = true
+		return tx.Model(&models.UserTOTP{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]any{"failed_attempts": 0, "last_failed_at": nil}).Error
+	})
+
+	return used, err
+}
+
+// recordFailedAttempt counts consecutive failures, starting over once the previous one is older than lockoutDuration
+func (r *repository) recordFailedAttempt(userID uuid.UUID, lockoutDuration time.Duration) error {
+	now := time.Now()
+	return r.db.Model(&models.UserTOTP{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]any{
+			"failed_attempts": gorm.Expr("CASE WHEN last_failed_at IS NULL OR last_failed_at < ? THEN 1 ELSE failed_attempts + 1 END",
+				now.Add(-lockoutDuration)),
+			"last_failed_at": now,
+		}).Error
+}
+
+func (r *repository) findUser(userID uuid.UUID) (models.User, error) {
+	var user models.User
+	if err := r.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Preload("Roles", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name")
+		}).
+		Select("id", "tag").
+		First(&user).Error; err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
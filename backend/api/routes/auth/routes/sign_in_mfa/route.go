@@ -0,0 +1,28 @@
+package sign_in_mfa
+
+import (
+	"vdm/core/env"
+	"vdm/core/fiberx"
+	"vdm/core/session"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	Path   = "/sign-in/mfa"
+	Method = fiber.MethodPost
+)
+
+func Route(db *gorm.DB, cfg env.SecurityConfig) *fiberx.Route {
+	repo := &repository{db}
+	svc := &service{
+		repo:             repo,
+		sessions:         session.NewOpener(db, cfg),
+		mfaTokenSecret:   cfg.MfaTokenSecret,
+		mfaEncryptionKey: cfg.MfaEncryptionKey,
+	}
+	handler := &handler{svc}
+
+	return fiberx.NewRoute(Method, Path, handler.signInMfa)
+}
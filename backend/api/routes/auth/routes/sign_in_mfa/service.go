@@ -0,0 +1,103 @@
+package sign_in_mfa
+
+import (
+	"fmt"
+	"time"
+	"vdm/core/aes_utils"
+	"vdm/core/device"
+	"vdm/core/hmac_utils"
+	"vdm/core/jwt_utils"
+	"vdm/core/locals"
+	"vdm/core/models"
+	"vdm/core/session"
+	"vdm/core/totp"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Service interface {
+	signInMfa(req RequestDTO, dev device.Device) (models.User, locals.AccessToken, locals.RefreshToken, error)
+}
+
+type service struct {
+	sessions session.Opener
+
+	mfaTokenSecret   []byte
+	mfaEncryptionKey []byte
+
+	repo Repository
+}
+
+func (s *service) signInMfa(req RequestDTO, dev device.Device) (models.User, locals.AccessToken, locals.RefreshToken, error) {
+	challenged, err := jwt_utils.ParseJWT(req.MfaToken, s.mfaTokenSecret)
+	if err != nil {
+		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, &fiber.Error{Code: fiber.StatusUnauthorized, Message: "invalid mfa token"}
+	}
+
+	userTOTP, err := s.repo.findTOTP(challenged.ID)
+	if err != nil {
+		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, fmt.Errorf("failed to find totp: %v", err)
+	}
+	if userTOTP == nil || !userTOTP.Confirmed() {
+		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, &fiber.Error{Code: fiber.StatusUnauthorized, Message: "totp not enabled"}
+	}
+
+	if userTOTP.FailedAttempts >= totp.MaxFailedAttempts && userTOTP.LastFailedAt != nil && time.Since(*userTOTP.LastFailedAt) < totp.LockoutDuration {
+		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, &fiber.Error{Code: fiber.StatusTooManyRequests, Message: "too many failed attempts"}
+	}
+
+	verified, err := s.verify(req, userTOTP)
+	if err != nil {
+		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, err
+	}
+	if !verified {
+		if err = s.repo.recordFailedAttempt(userTOTP.UserID, totp.LockoutDuration); err != nil {
+			return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, fmt.Errorf("failed to record failed attempt: %v", err)
+		}
+		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, &fiber.Error{Code: fiber.StatusUnauthorized, Message: "invalid code"}
+	}
+
+	user, err := s.repo.findUser(userTOTP.UserID)
+	if err != nil {
+		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, fmt.Errorf("failed to find user: %v", err)
+	}
+
+	accessToken, refreshToken, err := s.openSession(user, dev)
+	if err != nil {
+		return models.User{}, locals.AccessToken{}, locals.RefreshToken{}, err
+	}
+
+	return user, accessToken, refreshToken, nil
+}
+
+// verify consumes the TOTP code or the recovery code of req, neither can be used twice
+func (s *service) verify(req RequestDTO, userTOTP *models.UserTOTP) (bool, error) {
+	if req.Code == "" {
+		hash := hmac_utils.HashString(totp.NormalizeRecoveryCode(req.RecoveryCode), s.mfaEncryptionKey)
+		used, err := s.repo.useRecoveryCode(userTOTP.UserID, hash)
+		if err != nil {
+			return false, fmt.Errorf("failed to use recovery code: %v", err)
+		}
+		return used, nil
+	}
+
+	secret, err := aes_utils.Decrypt(userTOTP.Secret, s.mfaEncryptionKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to decrypt totp secret: %v", err)
+	}
+
+	step, ok := totp.Validate(secret, req.Code, time.Now())
+	if !ok || step <= userTOTP.LastUsedStep {
+		return false, nil
+	}
+
+	used, err := s.repo.useTOTPStep(userTOTP.UserID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to use totp step: %v", err)
+	}
+	return used, nil
+}
+
+func (s *service) openSession(user models.User, dev device.Device) (locals.AccessToken, locals.RefreshToken, error) {
+	return s.sessions.Open(user, dev)
+}
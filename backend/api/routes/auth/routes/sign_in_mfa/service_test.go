@@ -0,0 +1,185 @@
+package sign_in_mfa
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+	"vdm/core/aes_utils"
+	"vdm/core/device"
+	"vdm/core/hmac_utils"
+	"vdm/core/jwt_utils"
+	"vdm/core/locals"
+	"vdm/core/models"
+	"vdm/core/totp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var encryptionKey = bytes.Repeat([]byte("k"), 32)
+
+type stubRepository struct {
+	totp          *models.UserTOTP
+	recoveryCodes map[string]bool
+	failures      int
+}
+
+func (r *stubRepository) findTOTP(userID uuid.UUID) (*models.UserTOTP, error) {
+	return r.totp, nil
+}
+
+func (r *stubRepository) useTOTPStep(userID uuid.UUID, step int64) (bool, error) {
+	if step <= r.totp.LastUsedStep {
+		return false, nil
+	}
+	r.totp.LastUsedStep = step
+	return true, nil
+}
+
+func (r *stubRepository) useRecoveryCode(userID uuid.UUID, hash string) (bool, error) {
+	if unused, ok := r.recoveryCodes[hash]; !ok || !unused {
+		return false, nil
+	}
+	r.recoveryCodes[hash] = false
+	return true, nil
+}
+
+func (r *stubRepository) recordFailedAttempt(userID uuid.UUID, lockoutDuration time.Duration) error {
+	r.failures++
+	return nil
+}
+
+func (r *stubRepository) findUser(userID uuid.UUID) (models.User, error) {
+	return models.User{ID: userID, Tag: "user0123"}, nil
+}
+
+type stubSessions struct {
+	opened int
+}
+
+func (s *stubSessions) Open(user models.User, dev device.Device) (locals.AccessToken, locals.RefreshToken, error) {
+	s.opened++
+	return locals.AccessToken{Token: "access"}, locals.RefreshToken{Token: uuid.New()}, nil
+}
+
+func newTestService(t *testing.T, recoveryCode string) (*service, *stubRepository, *stubSessions, string) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := aes_utils.Encrypt(secret, encryptionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	confirmedAt := time.Now()
+	repo := &stubRepository{
+		totp:          &models.UserTOTP{UserID: uuid.New(), Secret: encrypted, ConfirmedAt: &confirmedAt},
+		recoveryCodes: map[string]bool{hmac_utils.HashString(totp.NormalizeRecoveryCode(recoveryCode), encryptionKey): true},
+	}
+
+	sessions := &stubSessions{}
+
+	svc := &service{
+		sessions:         sessions,
+		mfaTokenSecret:   []byte("mfa"),
+		mfaEncryptionKey: encryptionKey,
+		repo:             repo,
+	}
+
+	return svc, repo, sessions, secret
+}
+
+func mfaToken(t *testing.T, userID uuid.UUID, secret string) string {
+	token, err := jwt_utils.GenerateJWT(locals.AuthedUser{ID: userID}, []byte(secret), time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func assertStatus(t *testing.T, err error, status int) {
+	var fiberErr *fiber.Error
+	if assert.True(t, errors.As(err, &fiberErr), err) {
+		assert.Equal(t, status, fiberErr.Code)
+	}
+}
+
+func TestService_SignInMfa_Code(t *testing.T) {
+	svc, repo, sessions, secret := newTestService(t, "abcde-23456")
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := RequestDTO{MfaToken: mfaToken(t, repo.totp.UserID, "mfa"), Code: code}
+
+	user, accessToken, _, err := svc.signInMfa(req, device.Device{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, repo.totp.UserID, user.ID)
+	assert.NotEmpty(t, accessToken.Token)
+	assert.Equal(t, 1, sessions.opened)
+
+	// the code can't be replayed
+	_, _, _, err = svc.signInMfa(req, device.Device{})
+	assertStatus(t, err, fiber.StatusUnauthorized)
+	assert.Equal(t, 1, repo.failures)
+}
+
+func TestService_SignInMfa_RecoveryCode(t *testing.T) {
+	svc, repo, sessions, _ := newTestService(t, "abcde-23456")
+
+	req := RequestDTO{MfaToken: mfaToken(t, repo.totp.UserID, "mfa"), RecoveryCode: "ABCDE23456"}
+
+	if _, _, _, err := svc.signInMfa(req, device.Device{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a recovery code is used once
+	_, _, _, err := svc.signInMfa(req, device.Device{})
+	assertStatus(t, err, fiber.StatusUnauthorized)
+	assert.Equal(t, 1, sessions.opened)
+}
+
+func TestService_SignInMfa_ErrUnauthorized(t *testing.T) {
+	svc, repo, sessions, _ := newTestService(t, "abcde-23456")
+
+	// signed with another secret, e.g. an access token
+	_, _, _, err := svc.signInMfa(RequestDTO{MfaToken: mfaToken(t, repo.totp.UserID, "access"), Code: "123456"}, device.Device{})
+	assertStatus(t, err, fiber.StatusUnauthorized)
+	assert.Equal(t, 0, repo.failures)
+
+	_, _, _, err = svc.signInMfa(RequestDTO{MfaToken: mfaToken(t, repo.totp.UserID, "mfa"), RecoveryCode: "wrong"}, device.Device{})
+	assertStatus(t, err, fiber.StatusUnauthorized)
+	assert.Equal(t, 1, repo.failures)
+
+	// pending enrollments don't count
+	repo.totp.ConfirmedAt = nil
+	_, _, _, err = svc.signInMfa(RequestDTO{MfaToken: mfaToken(t, repo.totp.UserID, "mfa"), Code: "123456"}, device.Device{})
+	assertStatus(t, err, fiber.StatusUnauthorized)
+	assert.Equal(t, 0, sessions.opened)
+}
+
+func TestService_SignInMfa_ErrTooManyRequests(t *testing.T) {
+	svc, repo, _, secret := newTestService(t, "abcde-23456")
+
+	lastFailedAt := time.Now().Add(-time.Minute)
+	repo.totp.FailedAttempts = totp.MaxFailedAttempts
+	repo.totp.LastFailedAt = &lastFailedAt
+
+	// even a valid code is refused during the lockout
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, _, err = svc.signInMfa(RequestDTO{MfaToken: mfaToken(t, repo.totp.UserID, "mfa"), Code: code}, device.Device{})
+	assertStatus(t, err, fiber.StatusTooManyRequests)
+
+	lastFailedAt = time.Now().Add(-totp.LockoutDuration)
+	_, _, _, err = svc.signInMfa(RequestDTO{MfaToken: mfaToken(t, repo.totp.UserID, "mfa"), Code: code}, device.Device{})
+	assert.NoError(t, err)
+}
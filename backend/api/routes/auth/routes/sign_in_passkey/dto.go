@@ -0,0 +1,15 @@
+package sign_in_passkey
+
+import (
+	"time"
+	"vdm/core/models"
+)
+
+type ResponseDTO struct {
+	AccessTokenExpiry  time.Time         `json:"accessTokenExpiry"`
+	RefreshTokenExpiry time.Time         `json:"refreshTokenExpiry"`
+	Roles              []models.RoleName `json:"roles,omitempty"`
+	Tag                string            `json:"tag"`
+
+	MfaEnrollmentRequired bool `json:"mfaEnrollmentRequired,omitempty"`
+}
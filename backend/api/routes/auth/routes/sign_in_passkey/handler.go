@@ -0,0 +1,43 @@
+package sign_in_passkey
+
+import (
+	"vdm/core/device"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	signInPasskey(c *fiber.Ctx) error
+}
+
+type handler struct {
+	svc Service
+}
+
+// signInPasskey takes the credential returned by navigator.credentials.get as body
+func (h *handler) signInPasskey(c *fiber.Ctx) error {
+	dev := device.FromCtx(c)
+
+	user, mfaEnabled, err := h.svc.authenticate(c.Body(), dev)
+	if err != nil {
+		return err
+	}
+
+	accessToken, refreshToken, err := h.svc.openSession(user, dev)
+	if err != nil {
+		return err
+	}
+
+	c.Locals(local_keys.AccessToken, accessToken)
+	c.Locals(local_keys.RefreshToken, refreshToken)
+
+	return c.Status(fiber.StatusOK).JSON(ResponseDTO{
+		AccessTokenExpiry:  accessToken.Expiry,
+		RefreshTokenExpiry: refreshToken.Expiry,
+		Roles:              user.RoleNames(),
+		Tag:                user.Tag,
+		// a passkey verifies the user itself, but the privileged roles still require a TOTP to be enrolled
+		MfaEnrollmentRequired: user.RequiresMFA() && !mfaEnabled,
+	})
+}
@@ -0,0 +1,142 @@
+package sign_in_passkey
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/api/routes/auth/middlewares/set_auth_cookies"
+	"vdm/api/routes/auth/routes/sign_in_passkey_options"
+	"vdm/core/env"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/core/passkey"
+	"vdm/test_utils"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var testCfg = env.SecurityConfig{
+	AccessTokenSecret:    []byte("access"),
+	AccessTokenTTL:       time.Minute,
+	RefreshTokenSecret:   []byte("refresh"),
+	RefreshTokenTTL:      time.Minute,
+	AccessCookieName:     "jwt",
+	RefreshCookieName:    "rft",
+	WebAuthnRPID:         "localhost",
+	WebAuthnRPName:       "Vigie du mensonge",
+	WebAuthnRPOrigins:    []string{testOrigin},
+	WebAuthnChallengeTTL: time.Minute,
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector := test_utils.NewTestContainerConnector(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	role := &models.Role{Name: models.RoleModerator}
+	user := &models.User{Email: "moderator@test.com", Tag: "moderator0123", Password: "x", Roles: []*models.Role{role}}
+	if err := connector.GormDB().Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	webAuthn, err := passkey.New(testCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the passkey registered by register_passkey
+	authenticator, err := test_utils.NewPasskeyAuthenticator(testOrigin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	passkeyUser := passkey.User{ID: user.ID, Email: user.Email}
+	creation, session, err := webAuthn.BeginRegistration(passkeyUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := authenticator.Create(*creation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	credential, err := webAuthn.CreateCredential(passkeyUser, *session, parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userPasskey := passkey.NewUserPasskey(user.ID, "laptop", credential)
+	if err = connector.GormDB().Create(&userPasskey).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiberx.NewApp()
+	sign_in_passkey_options.Route(connector.GormDB(), webAuthn).Register(app)
+	set_auth_cookies.Middleware(testCfg).Register(app)
+	Route(connector.GormDB(), testCfg, webAuthn).Register(app)
+
+	res, err := app.Test(httptest.NewRequest(sign_in_passkey_options.Method, sign_in_passkey_options.Path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var assertion protocol.CredentialAssertion
+	err = json.NewDecoder(res.Body).Decode(&assertion)
+	_ = res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err = authenticator.Get(assertion)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(Method, Path, bytes.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	res, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	var dto ResponseDTO
+	if err = json.NewDecoder(res.Body).Decode(&dto); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "moderator0123", dto.Tag)
+	assert.Equal(t, []models.RoleName{models.RoleModerator}, dto.Roles)
+	assert.True(t, dto.MfaEnrollmentRequired)
+
+	cookies := map[string]bool{}
+	for _, cookie := range res.Cookies() {
+		cookies[cookie.Name] = cookie.Value != ""
+	}
+	assert.True(t, cookies["jwt"])
+	assert.True(t, cookies["rft"])
+
+	var stored models.UserPasskey
+	if err = connector.GormDB().First(&stored, "id = ?", userPasskey.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(1), stored.SignCount)
+	assert.NotNil(t, stored.LastUsedAt)
+
+	// the assertion can't be replayed
+	req = httptest.NewRequest(Method, Path, bytes.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	res, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+}
@@ -0,0 +1,79 @@
+package sign_in_passkey
+
+import (
+	"time"
+	"vdm/core/models"
+	"vdm/core/passkey"
+	"vdm/core/session"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	consumeChallenge(challenge string) (*models.WebAuthnChallenge, error)
+	findPasskeyUser(userID uuid.UUID) (passkey.User, error)
+	usePasskey(userID uuid.UUID, credentialID []byte, signCount uint32, flags protocol.AuthenticatorFlags) error
+	findUser(userID uuid.UUID) (models.User, error)
+	totpConfirmed(userID uuid.UUID) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// consumeChallenge deletes the unexpired sign-in challenge and returns it, nil when there is none
+func (r *repository) consumeChallenge(challenge string) (*models.WebAuthnChallenge, error) {
+	var challenges []models.WebAuthnChallenge
+	if err := r.db.Clauses(clause.Returning{}).
+		Where("challenge = ? AND ceremony = ? AND user_id IS NULL AND expiry > ?", challenge, models.WebAuthnCeremonyLogin, time.Now()).
+		Delete(&challenges).Error; err != nil {
+		return nil, err
+	}
+	if len(challenges) == 0 {
+		return nil, nil
+	}
+	return &challenges[0], nil
+}
+
+func (r *repository) findPasskeyUser(userID uuid.UUID) (passkey.User, error) {
+	var user models.User
+	if err := r.db.Select("id", "email").
+		First(&user, "id = ?", userID).Error; err != nil {
+		return passkey.User{}, err
+	}
+
+	var passkeys []models.UserPasskey
+	if err := r.db.Where("user_id = ?", userID).
+		Find(&passkeys).Error; err != nil {
+		return passkey.User{}, err
+	}
+
+	return passkey.User{ID: user.ID, Email: user.Email, Passkeys: passkeys}, nil
+}
+
+func (r *repository) usePasskey(userID uuid.UUID, credentialID []byte, signCount uint32, flags protocol.AuthenticatorFlags) error {
+	return r.db.Model(&models.UserPasskey{}).
+		Where("user_id = ? AND credential_id = ?", userID, credentialID).
+		Updates(map[string]any{"sign_count": signCount, "flags": int16(flags), "last_used_at": time.Now()}).Error
+}
+
+func (r *repository) findUser(userID uuid.UUID) (models.User, error) {
+	var user models.User
+	if err := r.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Preload("Roles", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name")
+		}).
+		Select("id", "email", "tag").
+		First(&user).Error; err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
+
+func (r *repository) totpConfirmed(userID uuid.UUID) (bool, error) {
+	return session.TOTPConfirmed(r.db, userID)
+}
@@ -0,0 +1,28 @@
+package sign_in_passkey
+
+import (
+	"vdm/core/env"
+	"vdm/core/fiberx"
+	"vdm/core/session"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	Path   = "/sign-in/passkey"
+	Method = fiber.MethodPost
+)
+
+func Route(db *gorm.DB, cfg env.SecurityConfig, webAuthn *webauthn.WebAuthn) *fiberx.Route {
+	repo := &repository{db}
+	svc := &service{
+		repo:     repo,
+		webAuthn: webAuthn,
+		sessions: session.NewOpener(db, cfg),
+	}
+	handler := &handler{svc}
+
+	return fiberx.NewRoute(Method, Path, handler.signInPasskey)
+}
@@ -0,0 +1,103 @@
+package sign_in_passkey
+
+import (
+	"errors"
+	"fmt"
+	"vdm/core/device"
+	"vdm/core/locals"
+	"vdm/core/logger"
+	"vdm/core/models"
+	"vdm/core/passkey"
+	"vdm/core/session"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	authenticate(body []byte, dev device.Device) (models.User, bool, error)
+	openSession(user models.User, dev device.Device) (locals.AccessToken, locals.RefreshToken, error)
+}
+
+type service struct {
+	webAuthn *webauthn.WebAuthn
+
+	sessions session.Opener
+
+	repo Repository
+}
+
+// authenticate verifies the assertion of a passkey against the challenge of sign_in_passkey_options.
+// The passkey verified the user with a PIN or biometrics, so no second factor is asked for.
+// It tells whether the user enrolled a TOTP, which their roles may require.
+func (s *service) authenticate(body []byte, dev device.Device) (models.User, bool, error) {
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(body)
+	if err != nil {
+		return models.User{}, false, &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid credential"}
+	}
+
+	// the challenge is single use, whether the assertion is valid or not
+	challenge, err := s.repo.consumeChallenge(parsed.Response.CollectedClientData.Challenge)
+	if err != nil {
+		return models.User{}, false, fmt.Errorf("failed to consume webauthn challenge: %v", err)
+	}
+	if challenge == nil {
+		return models.User{}, false, &fiber.Error{Code: fiber.StatusUnauthorized, Message: "unknown or expired challenge"}
+	}
+
+	session, err := passkey.Session(*challenge)
+	if err != nil {
+		return models.User{}, false, err
+	}
+
+	var lookupErr error
+	found, credential, err := s.webAuthn.ValidatePasskeyLogin(func(rawID, userHandle []byte) (webauthn.User, error) {
+		userID, err := passkey.UserID(userHandle)
+		if err != nil {
+			return nil, err
+		}
+		user, err := s.repo.findPasskeyUser(userID)
+		lookupErr = err
+		return user, err
+	}, session, parsed)
+	if lookupErr != nil && !errors.Is(lookupErr, gorm.ErrRecordNotFound) {
+		return models.User{}, false, fmt.Errorf("failed to find passkey user: %v", lookupErr)
+	}
+	if err != nil {
+		return models.User{}, false, &fiber.Error{Code: fiber.StatusUnauthorized, Message: "invalid passkey"}
+	}
+
+	userID := found.(passkey.User).ID
+
+	if credential.Authenticator.CloneWarning {
+		// the private key of the passkey was copied out of the authenticator, or the authenticator is malfunctioning
+		logger.Warn("passkey sign count went backwards, sign-in refused",
+			logger.Any("event", "passkey_clone_warning"),
+			logger.Any("userId", userID),
+			logger.Any("signCount", credential.Authenticator.SignCount),
+			logger.Any("ip", dev.IP))
+		return models.User{}, false, &fiber.Error{Code: fiber.StatusUnauthorized, Message: "invalid passkey"}
+	}
+
+	if err = s.repo.usePasskey(userID, credential.ID, credential.Authenticator.SignCount, credential.Flags.ProtocolValue()); err != nil {
+		return models.User{}, false, fmt.Errorf("failed to use passkey: %v", err)
+	}
+
+	user, err := s.repo.findUser(userID)
+	if err != nil {
+		return models.User{}, false, fmt.Errorf("failed to find user: %v", err)
+	}
+
+	mfaEnabled, err := s.repo.totpConfirmed(userID)
+	if err != nil {
+		return models.User{}, false, fmt.Errorf("failed to check totp: %v", err)
+	}
+
+	return user, mfaEnabled, nil
+}
+
+func (s *service) openSession(user models.User, dev device.Device) (locals.AccessToken, locals.RefreshToken, error) {
+	return s.sessions.Open(user, dev)
+}
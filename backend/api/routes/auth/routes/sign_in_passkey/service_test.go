@@ -0,0 +1,186 @@
+package sign_in_passkey
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"vdm/core/device"
+	"vdm/core/env"
+	"vdm/core/models"
+	"vdm/core/passkey"
+	"vdm/test_utils"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+const testOrigin = "http://localhost:5173"
+
+type stubRepository struct {
+	challenges map[string]models.WebAuthnChallenge
+	user       passkey.User
+	usedCount  uint32
+	used       bool
+}
+
+func (r *stubRepository) consumeChallenge(challenge string) (*models.WebAuthnChallenge, error) {
+	consumed, ok := r.challenges[challenge]
+	if !ok {
+		return nil, nil
+	}
+	delete(r.challenges, challenge)
+	return &consumed, nil
+}
+
+func (r *stubRepository) findPasskeyUser(userID uuid.UUID) (passkey.User, error) {
+	if userID != r.user.ID {
+		return passkey.User{}, gorm.ErrRecordNotFound
+	}
+	return r.user, nil
+}
+
+func (r *stubRepository) usePasskey(userID uuid.UUID, credentialID []byte, signCount uint32, flags protocol.AuthenticatorFlags) error {
+	r.used, r.usedCount = true, signCount
+	return nil
+}
+
+func (r *stubRepository) findUser(userID uuid.UUID) (models.User, error) {
+	return models.User{ID: userID, Tag: "user0123"}, nil
+}
+
+func (r *stubRepository) totpConfirmed(userID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+type testEnv struct {
+	svc           *service
+	repo          *stubRepository
+	authenticator *test_utils.PasskeyAuthenticator
+}
+
+// newTestEnv registers a passkey of the software authenticator for a user
+func newTestEnv(t *testing.T) testEnv {
+	webAuthn, err := passkey.New(env.SecurityConfig{
+		WebAuthnRPID:         "localhost",
+		WebAuthnRPName:       "Vigie du mensonge",
+		WebAuthnRPOrigins:    []string{testOrigin},
+		WebAuthnChallengeTTL: time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authenticator, err := test_utils.NewPasskeyAuthenticator(testOrigin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := passkey.User{ID: uuid.New(), Email: "user@test.com"}
+	creation, session, err := webAuthn.BeginRegistration(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := authenticator.Create(*creation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	credential, err := webAuthn.CreateCredential(user, *session, parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user.Passkeys = []models.UserPasskey{passkey.NewUserPasskey(user.ID, "laptop", credential)}
+
+	repo := &stubRepository{challenges: map[string]models.WebAuthnChallenge{}, user: user}
+	return testEnv{
+		svc: &service{
+			repo:     repo,
+			webAuthn: webAuthn,
+		},
+		repo:          repo,
+		authenticator: authenticator,
+	}
+}
+
+// assert begins a sign-in the way sign_in_passkey_options does and answers it with the authenticator
+func (e testEnv) assert(t *testing.T) []byte {
+	assertion, session, err := e.svc.webAuthn.BeginDiscoverableLogin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	challenge, err := passkey.NewChallenge(models.WebAuthnCeremonyLogin, nil, session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.repo.challenges[challenge.Challenge] = challenge
+
+	body, err := e.authenticator.Get(*assertion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func assertFiberErr(t *testing.T, want int, err error, name string) {
+	var fiberErr *fiber.Error
+	if assert.True(t, errors.As(err, &fiberErr), name) {
+		assert.Equal(t, want, fiberErr.Code, name)
+	}
+}
+
+func TestService_Success(t *testing.T) {
+	e := newTestEnv(t)
+
+	user, mfaEnabled, err := e.svc.authenticate(e.assert(t), device.Device{IP: "192.0.2.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, e.repo.user.ID, user.ID)
+	assert.False(t, mfaEnabled)
+	assert.True(t, e.repo.used)
+	assert.Equal(t, uint32(1), e.repo.usedCount)
+}
+
+func TestService_ErrUnauthorized(t *testing.T) {
+	e := newTestEnv(t)
+
+	// the challenge can't be replayed
+	body := e.assert(t)
+	if _, _, err := e.svc.authenticate(body, device.Device{}); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err := e.svc.authenticate(body, device.Device{})
+	assertFiberErr(t, fiber.StatusUnauthorized, err, "replayed challenge")
+
+	// the stored sign count is ahead of the authenticator, it was cloned
+	e.repo.user.Passkeys[0].SignCount = 10
+	e.repo.used = false
+	_, _, err = e.svc.authenticate(e.assert(t), device.Device{})
+	assertFiberErr(t, fiber.StatusUnauthorized, err, "clone warning")
+	assert.False(t, e.repo.used)
+	e.repo.user.Passkeys[0].SignCount = 0
+
+	// the passkey was deleted
+	e.repo.user.Passkeys = nil
+	_, _, err = e.svc.authenticate(e.assert(t), device.Device{})
+	assertFiberErr(t, fiber.StatusUnauthorized, err, "deleted passkey")
+
+	// the user was deleted
+	e.repo.user.ID = uuid.New()
+	_, _, err = e.svc.authenticate(e.assert(t), device.Device{})
+	assertFiberErr(t, fiber.StatusUnauthorized, err, "deleted user")
+}
+
+func TestService_ErrBadRequest(t *testing.T) {
+	e := newTestEnv(t)
+
+	_, _, err := e.svc.authenticate([]byte(`{}`), device.Device{})
+	assertFiberErr(t, fiber.StatusBadRequest, err, "invalid credential")
+}
@@ -0,0 +1,24 @@
+package sign_in_passkey_options
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	signInPasskeyOptions(c *fiber.Ctx) error
+}
+
+type handler struct {
+	svc Service
+}
+
+// signInPasskeyOptions answers the options to pass to navigator.credentials.get, no user is asked for:
+// the user picks one of their passkeys and is known from it
+func (h *handler) signInPasskeyOptions(c *fiber.Ctx) error {
+	assertion, err := h.svc.signInPasskeyOptions()
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(assertion)
+}
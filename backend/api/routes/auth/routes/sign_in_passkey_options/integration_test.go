@@ -0,0 +1,60 @@
+package sign_in_passkey_options
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/env"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/core/passkey"
+	"vdm/test_utils"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector := test_utils.NewTestContainerConnector(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	webAuthn, err := passkey.New(env.SecurityConfig{
+		WebAuthnRPID:         "localhost",
+		WebAuthnRPName:       "Vigie du mensonge",
+		WebAuthnRPOrigins:    []string{"http://localhost:5173"},
+		WebAuthnChallengeTTL: time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB(), webAuthn).Register(app)
+
+	res, err := app.Test(httptest.NewRequest(Method, Path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	var assertion protocol.CredentialAssertion
+	if err = json.NewDecoder(res.Body).Decode(&assertion); err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, assertion.Response.AllowedCredentials)
+	assert.Equal(t, protocol.VerificationRequired, assertion.Response.UserVerification)
+
+	var challenge models.WebAuthnChallenge
+	if err = connector.GormDB().First(&challenge, "challenge = ?", assertion.Response.Challenge.String()).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, models.WebAuthnCeremonyLogin, challenge.Ceremony)
+	assert.Nil(t, challenge.UserID)
+	assert.True(t, challenge.Expiry.After(time.Now()))
+}
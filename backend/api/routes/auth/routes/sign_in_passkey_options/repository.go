@@ -0,0 +1,27 @@
+package sign_in_passkey_options
+
+import (
+	"time"
+	"vdm/core/models"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	createChallenge(challenge *models.WebAuthnChallenge) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) createChallenge(challenge *models.WebAuthnChallenge) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// abandoned ceremonies are cleaned up
+		if err := tx.Where("expiry <= ?", time.Now()).
+			Delete(&models.WebAuthnChallenge{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(challenge).Error
+	})
+}
@@ -0,0 +1,21 @@
+package sign_in_passkey_options
+
+import (
+	"vdm/core/fiberx"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	Path   = "/sign-in/passkey/options"
+	Method = fiber.MethodPost
+)
+
+func Route(db *gorm.DB, webAuthn *webauthn.WebAuthn) *fiberx.Route {
+	repo := &repository{db}
+	svc := &service{repo: repo, webAuthn: webAuthn}
+	handler := &handler{svc}
+	return fiberx.NewRoute(Method, Path, handler.signInPasskeyOptions)
+}
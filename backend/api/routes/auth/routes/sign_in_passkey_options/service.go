@@ -0,0 +1,37 @@
+package sign_in_passkey_options
+
+import (
+	"fmt"
+	"vdm/core/models"
+	"vdm/core/passkey"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+type Service interface {
+	signInPasskeyOptions() (*protocol.CredentialAssertion, error)
+}
+
+type service struct {
+	repo     Repository
+	webAuthn *webauthn.WebAuthn
+}
+
+func (s *service) signInPasskeyOptions() (*protocol.CredentialAssertion, error) {
+	assertion, session, err := s.webAuthn.BeginDiscoverableLogin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin passkey sign-in: %v", err)
+	}
+
+	challenge, err := passkey.NewChallenge(models.WebAuthnCeremonyLogin, nil, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.createChallenge(&challenge); err != nil {
+		return nil, fmt.Errorf("failed to create webauthn challenge: %v", err)
+	}
+
+	return assertion, nil
+}
@@ -101,3 +101,48 @@ func TestIntegration_Success(t *testing.T) {
 	}
 	assert.Equal(t, int64(0), tokenCount)
 }
+
+func TestIntegration_Success_KeepsOtherSessions(t *testing.T) {
+	c := context.Background()
+	container, connector := loadTestData(c, t)
+	t.Cleanup(func() { cleanupTestData(c, t, container, connector) })
+
+	current, other := uuid.New(), uuid.New()
+	if err := connector.GormDB().Create([]*models.UserToken{
+		{UserID: testUser.ID, Hash: "current", Expiry: time.Now().Add(10 * time.Minute), Category: models.UserTokenCategoryRefresh, FamilyID: &current},
+		{UserID: testUser.ID, Hash: "other", Expiry: time.Now().Add(10 * time.Minute), Category: models.UserTokenCategoryRefresh, FamilyID: &other},
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiberx.NewApp()
+
+	dummyCfg := env.SecurityConfig{AccessTokenSecret: []byte("dummySecret"),
+		AccessCookieName: "access"}
+
+	Route(connector.GormDB(), dummyCfg).Register(app)
+
+	req := httptest.NewRequest(Method, Path, nil)
+
+	if jwt, err := jwt_utils.GenerateJWT(locals.AuthedUser{ID: testUser.ID, SessionID: current}, dummyCfg.AccessTokenSecret, time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	} else {
+		req.AddCookie(&http.Cookie{Name: dummyCfg.AccessCookieName, Value: jwt})
+	}
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNoContent, res.StatusCode)
+
+	// only the current session is revoked
+	var hashes []string
+	if err := connector.GormDB().Model(&models.UserToken{}).Where("user_id = ?", testUser.ID).
+		Order("hash").Pluck("hash", &hashes).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"", "other"}, hashes)
+}
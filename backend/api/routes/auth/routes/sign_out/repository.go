@@ -9,6 +9,7 @@ import (
 
 type Repository interface {
 	deleteRefreshTokens(userID uuid.UUID) error
+	deleteSession(userID, sessionID uuid.UUID) error
 }
 
 type repository struct {
@@ -19,3 +20,8 @@ func (r repository) deleteRefreshTokens(userID uuid.UUID) error {
 	return r.db.Where("user_id = ? AND category = ?", userID, models.UserTokenCategoryRefresh).
 		Delete(&models.UserToken{}).Error
 }
+
+func (r repository) deleteSession(userID, sessionID uuid.UUID) error {
+	return r.db.Where("user_id = ? AND category = ? AND family_id = ?", userID, models.UserTokenCategoryRefresh, sessionID).
+		Delete(&models.UserToken{}).Error
+}
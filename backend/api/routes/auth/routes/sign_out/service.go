@@ -3,6 +3,8 @@ package sign_out
 import (
 	"vdm/core/jwt_utils"
 	"vdm/core/logger"
+
+	"github.com/google/uuid"
 )
 
 type Service interface {
@@ -21,7 +23,15 @@ func (s *service) signOut(accessToken string) {
 		return
 	}
 
-	if err = s.repo.deleteRefreshTokens(authedUser.ID); err != nil {
-		logger.Error("Failed to delete refresh tokens", logger.Err(err))
+	// access tokens issued before sessions were tracked sign out everywhere
+	if authedUser.SessionID == uuid.Nil {
+		if err = s.repo.deleteRefreshTokens(authedUser.ID); err != nil {
+			logger.Error("Failed to delete refresh tokens", logger.Err(err))
+		}
+		return
+	}
+
+	if err = s.repo.deleteSession(authedUser.ID, authedUser.SessionID); err != nil {
+		logger.Error("Failed to delete session", logger.Err(err))
 	}
 }
@@ -0,0 +1,24 @@
+package governments
+
+import (
+	"vdm/api/routes/governments/routes/find_cabinet_at_date"
+	"vdm/api/routes/governments/routes/find_government"
+	"vdm/api/routes/governments/routes/get_governments"
+	"vdm/core/dependencies"
+	"vdm/core/fiberx"
+)
+
+const Prefix = "/governments"
+
+func Group(deps *dependencies.Dependencies) *fiberx.Group {
+	group := fiberx.NewGroup(Prefix)
+
+	group.Add(
+		get_governments.Route(deps.GormDB()),
+		// must be registered before find_government, whose path would match "/cabinet"
+		find_cabinet_at_date.Route(deps.GormDB()),
+		find_government.Route(deps.GormDB()),
+	)
+
+	return group
+}
@@ -0,0 +1,36 @@
+package find_cabinet_at_date
+
+import (
+	"fmt"
+	"time"
+	"vdm/core/dto/response_dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const dateParam = "date"
+
+type Handler interface {
+	findCabinetAtDate(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) findCabinetAtDate(c *fiber.Ctx) error {
+	date, err := time.Parse(time.DateOnly, c.Query(dateParam))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: fmt.Sprintf("query param <%s> must be a date formatted as YYYY-MM-DD", dateParam)}
+	}
+
+	government, err := h.repo.findGovernmentAtDate(date)
+	if err != nil {
+		return fmt.Errorf("failed to find government at date: %v", err)
+	}
+	if government == nil {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("no government in office on %s", date.Format(time.DateOnly))}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response_dto.NewGovernment(*government))
+}
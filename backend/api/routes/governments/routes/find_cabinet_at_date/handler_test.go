@@ -0,0 +1,35 @@
+package find_cabinet_at_date
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type nullRepository struct{}
+
+func (*nullRepository) findGovernmentAtDate(date time.Time) (*models.Government, error) {
+	return nil, nil
+}
+
+func TestHandler_ErrBadRequest(t *testing.T) {
+	app := fiberx.NewApp()
+	h := &handler{repo: &nullRepository{}}
+	app.Add(Method, Path, h.findCabinetAtDate)
+
+	for _, target := range []string{Path, Path + "?date=09/01/2024"} {
+		req := httptest.NewRequest(Method, target, nil)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode, target)
+	}
+}
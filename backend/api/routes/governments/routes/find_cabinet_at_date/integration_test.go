@@ -0,0 +1,126 @@
+package find_cabinet_at_date
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/dto/response_dto"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	politicians := []*models.Politician{
+		{FirstName: "Élisabeth", LastName: "Borne"},
+		{FirstName: "Gabriel", LastName: "Attal"},
+		{FirstName: "Pap", LastName: "Ndiaye"},
+	}
+	if err = connector.GormDB().Create(&politicians).Error; err != nil {
+		return
+	}
+
+	government := &models.Government{
+		PrimeMinisterID: politicians[0].ID,
+		Reference:       43,
+		StartDate:       time.Date(2022, 5, 16, 0, 0, 0, 0, time.UTC),
+		EndDate:         sql.NullTime{Time: time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+	if err = connector.GormDB().Create(government).Error; err != nil {
+		return
+	}
+
+	// the education ministry changed hands during the government
+	occupations := []*models.Occupation{
+		{
+			PoliticianID: politicians[2].ID,
+			GovernmentID: &government.ID,
+			Code:         "MIN_EDUC",
+			Title:        "Ministre de l'Éducation nationale et de la Jeunesse",
+			StartDate:    government.StartDate,
+			EndDate:      sql.NullTime{Time: time.Date(2023, 7, 20, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+		{
+			PoliticianID: politicians[1].ID,
+			GovernmentID: &government.ID,
+			Code:         "MIN_EDUC",
+			Title:        "Ministre de l'Éducation nationale et de la Jeunesse",
+			StartDate:    time.Date(2023, 7, 20, 0, 0, 0, 0, time.UTC),
+			EndDate:      government.EndDate,
+		},
+	}
+
+	err = connector.GormDB().Create(&occupations).Error
+	return
+}
+
+func findCabinet(t *testing.T, app *fiber.App, date string) (int, response_dto.Government) {
+	req := httptest.NewRequest(Method, Path+"?date="+date, nil)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var resDTO response_dto.Government
+
+	if res.StatusCode == fiber.StatusOK {
+		resBody, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = json.Unmarshal(resBody, &resDTO); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return res.StatusCode, resDTO
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	status, resDTO := findCabinet(t, app, "2023-01-01")
+	if assert.Equal(t, fiber.StatusOK, status) {
+		assert.Equal(t, int16(43), resDTO.Reference)
+		if assert.Equal(t, 1, len(resDTO.Cabinet)) && assert.NotNil(t, resDTO.Cabinet[0].Politician) {
+			assert.Equal(t, "Pap Ndiaye", resDTO.Cabinet[0].Politician.FullName)
+		}
+	}
+
+	status, resDTO = findCabinet(t, app, "2023-09-01")
+	if assert.Equal(t, fiber.StatusOK, status) {
+		if assert.Equal(t, 1, len(resDTO.Cabinet)) && assert.NotNil(t, resDTO.Cabinet[0].Politician) {
+			assert.Equal(t, "Gabriel Attal", resDTO.Cabinet[0].Politician.FullName)
+		}
+	}
+
+	status, _ = findCabinet(t, app, "2020-01-01")
+	assert.Equal(t, fiber.StatusNotFound, status)
+}
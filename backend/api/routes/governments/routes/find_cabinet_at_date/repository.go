@@ -0,0 +1,45 @@
+package find_cabinet_at_date
+
+import (
+	"errors"
+	"time"
+	"vdm/core/models"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	findGovernmentAtDate(date time.Time) (*models.Government, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// findGovernmentAtDate returns the government in office at date, with only the occupations held at that date.
+func (r *repository) findGovernmentAtDate(date time.Time) (*models.Government, error) {
+	var government models.Government
+
+	if err := r.db.Where("start_date <= ? AND (end_date IS NULL OR end_date > ?)", date, date).
+		Order("start_date DESC").
+		Preload("PrimeMinister", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "first_name", "last_name")
+		}).
+		Preload("Occupations", func(db *gorm.DB) *gorm.DB {
+			return db.Where("start_date <= ? AND (end_date IS NULL OR end_date > ?)", date, date).
+				Order("start_date ASC, code ASC")
+		}).
+		Preload("Occupations.Politician", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "first_name", "last_name")
+		}).
+		First(&government).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &government, nil
+}
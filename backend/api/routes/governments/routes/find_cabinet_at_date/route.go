@@ -0,0 +1,19 @@
+package find_cabinet_at_date
+
+import (
+	"vdm/core/fiberx"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	Path   = "/cabinet"
+	Method = fiber.MethodGet
+)
+
+func Route(db *gorm.DB) *fiberx.Route {
+	repo := &repository{db}
+	handler := &handler{repo}
+	return fiberx.NewRoute(Method, Path, handler.findCabinetAtDate)
+}
@@ -0,0 +1,35 @@
+package find_government
+
+import (
+	"fmt"
+	"strconv"
+	"vdm/core/dto/response_dto"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	findGovernment(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) findGovernment(c *fiber.Ctx) error {
+	reference, err := strconv.ParseInt(c.Params(local_keys.GovernmentReference), 10, 16)
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid government reference"}
+	}
+
+	government, err := h.repo.findGovernment(int16(reference))
+	if err != nil {
+		return fmt.Errorf("failed to find government: %v", err)
+	}
+	if government == nil {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("government with reference %d not found", reference)}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response_dto.NewGovernment(*government))
+}
@@ -0,0 +1,34 @@
+package find_government
+
+import (
+	"net/http/httptest"
+	"testing"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type nullRepository struct{}
+
+func (*nullRepository) findGovernment(reference int16) (*models.Government, error) {
+	return nil, nil
+}
+
+func TestHandler_ErrBadRequest(t *testing.T) {
+	app := fiberx.NewApp()
+	h := &handler{repo: &nullRepository{}}
+	app.Add(Method, Path, h.findGovernment)
+
+	for _, target := range []string{"/abc", "/99999"} {
+		req := httptest.NewRequest(Method, target, nil)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode, target)
+	}
+}
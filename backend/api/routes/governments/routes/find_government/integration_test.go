@@ -0,0 +1,139 @@
+package find_government
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/dto/response_dto"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	government *models.Government
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	politicians := []*models.Politician{
+		{FirstName: "Gabriel", LastName: "Attal"},
+		{FirstName: "Gérald", LastName: "Darmanin"},
+	}
+	if err = connector.GormDB().Create(&politicians).Error; err != nil {
+		return
+	}
+
+	data.government = &models.Government{
+		PrimeMinisterID: politicians[0].ID,
+		Reference:       44,
+		StartDate:       time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+		EndDate:         sql.NullTime{Time: time.Date(2024, 9, 21, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+	if err = connector.GormDB().Create(data.government).Error; err != nil {
+		return
+	}
+
+	occupations := []*models.Occupation{
+		{
+			PoliticianID: politicians[1].ID,
+			GovernmentID: &data.government.ID,
+			Code:         "MIN_INT",
+			Title:        "Ministre de l'Intérieur et des Outre-mer",
+			StartDate:    data.government.StartDate,
+			EndDate:      data.government.EndDate,
+		},
+		{
+			PoliticianID: politicians[0].ID,
+			GovernmentID: &data.government.ID,
+			Code:         "PM",
+			Title:        "Premier ministre",
+			StartDate:    data.government.StartDate,
+			EndDate:      data.government.EndDate,
+		},
+	}
+
+	err = connector.GormDB().Create(&occupations).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, _ := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/44", nil)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code 200, got %d", res.StatusCode)
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resDTO response_dto.Government
+	if err = json.Unmarshal(resBody, &resDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, int16(44), resDTO.Reference)
+	if assert.NotNil(t, resDTO.PrimeMinister) {
+		assert.Equal(t, "Gabriel Attal", resDTO.PrimeMinister.FullName)
+	}
+
+	if assert.Equal(t, 2, len(resDTO.Cabinet)) {
+		assert.Equal(t, "MIN_INT", resDTO.Cabinet[0].Code)
+		if assert.NotNil(t, resDTO.Cabinet[0].Politician) {
+			assert.Equal(t, "Gérald Darmanin", resDTO.Cabinet[0].Politician.FullName)
+		}
+	}
+}
+
+func TestIntegration_ErrNotFound(t *testing.T) {
+	c := context.Background()
+	container, connector, _ := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/1", nil)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
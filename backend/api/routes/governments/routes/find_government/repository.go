@@ -0,0 +1,41 @@
+package find_government
+
+import (
+	"errors"
+	"vdm/core/models"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	findGovernment(reference int16) (*models.Government, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) findGovernment(reference int16) (*models.Government, error) {
+	var government models.Government
+
+	if err := r.db.Where("reference = ?", reference).
+		Preload("PrimeMinister", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "first_name", "last_name")
+		}).
+		Preload("Occupations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_date ASC, code ASC")
+		}).
+		Preload("Occupations.Politician", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "first_name", "last_name")
+		}).
+		First(&government).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &government, nil
+}
@@ -0,0 +1,31 @@
+package get_governments
+
+import (
+	"fmt"
+	"vdm/core/dto/response_dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	getGovernments(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) getGovernments(c *fiber.Ctx) error {
+	governments, err := h.repo.getGovernments()
+	if err != nil {
+		return fmt.Errorf("failed to get governments: %v", err)
+	}
+
+	respDTO := make([]response_dto.Government, len(governments))
+
+	for i := range governments {
+		respDTO[i] = response_dto.NewGovernment(governments[i])
+	}
+
+	return c.Status(fiber.StatusOK).JSON(respDTO)
+}
@@ -0,0 +1,139 @@
+package get_governments
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/dto/response_dto"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	governments []*models.Government
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	primeMinisters := []*models.Politician{
+		{FirstName: "Élisabeth", LastName: "Borne"},
+		{FirstName: "Gabriel", LastName: "Attal"},
+	}
+	if err = connector.GormDB().Create(&primeMinisters).Error; err != nil {
+		return
+	}
+
+	data.governments = []*models.Government{
+		{
+			PrimeMinisterID: primeMinisters[0].ID,
+			Reference:       43,
+			StartDate:       time.Date(2022, 5, 16, 0, 0, 0, 0, time.UTC),
+			EndDate:         sql.NullTime{Time: time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+		{
+			PrimeMinisterID: primeMinisters[1].ID,
+			Reference:       44,
+			StartDate:       time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+			EndDate:         sql.NullTime{Time: time.Date(2024, 9, 21, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+	}
+
+	if err = connector.GormDB().Create(&data.governments).Error; err != nil {
+		return
+	}
+
+	ministers := []*models.Politician{
+		{FirstName: "Bruno", LastName: "Le Maire"},
+		{FirstName: "Gérald", LastName: "Darmanin"},
+	}
+	if err = connector.GormDB().Create(&ministers).Error; err != nil {
+		return
+	}
+
+	err = connector.GormDB().Create([]*models.Occupation{
+		{
+			PoliticianID: ministers[1].ID,
+			GovernmentID: &data.governments[1].ID,
+			Code:         "MINISTRE_INTERIEUR",
+			Title:        "Ministre de l'Intérieur",
+			StartDate:    time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			PoliticianID: ministers[0].ID,
+			GovernmentID: &data.governments[1].ID,
+			Code:         "MINISTRE_ECONOMIE",
+			Title:        "Ministre de l'Économie",
+			StartDate:    time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+		},
+	}).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, _ := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, Path, nil)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code 200, got %d", res.StatusCode)
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resDTO []response_dto.Government
+	if err = json.Unmarshal(resBody, &resDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Equal(t, 2, len(resDTO)) {
+		// most recent first
+		assert.Equal(t, int16(44), resDTO[0].Reference)
+		assert.Equal(t, int16(43), resDTO[1].Reference)
+		if assert.NotNil(t, resDTO[0].PrimeMinister) {
+			assert.Equal(t, "Gabriel Attal", resDTO[0].PrimeMinister.FullName)
+		}
+		assert.NotNil(t, resDTO[0].EndDate)
+		// the cabinet is listed with its politicians
+		if assert.Equal(t, 2, len(resDTO[0].Cabinet)) {
+			assert.Equal(t, "MINISTRE_ECONOMIE", resDTO[0].Cabinet[0].Code)
+			if assert.NotNil(t, resDTO[0].Cabinet[0].Politician) {
+				assert.Equal(t, "Bruno Le Maire", resDTO[0].Cabinet[0].Politician.FullName)
+			}
+		}
+		assert.Empty(t, resDTO[1].Cabinet)
+	}
+}
@@ -0,0 +1,36 @@
+package get_governments
+
+import (
+	"vdm/core/models"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	getGovernments() ([]models.Government, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) getGovernments() ([]models.Government, error) {
+	var governments []models.Government
+
+	if err := r.db.Order("start_date DESC").
+		Preload("PrimeMinister", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "first_name", "last_name")
+		}).
+		// the listing shows every cabinet, without a request per government
+		Preload("Occupations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_date ASC, code ASC")
+		}).
+		Preload("Occupations.Politician", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "first_name", "last_name")
+		}).
+		Find(&governments).Error; err != nil {
+		return nil, err
+	}
+
+	return governments, nil
+}
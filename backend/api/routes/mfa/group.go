@@ -0,0 +1,23 @@
+package mfa
+
+import (
+	"vdm/api/routes/mfa/routes/confirm_totp"
+	"vdm/api/routes/mfa/routes/disable_totp"
+	"vdm/api/routes/mfa/routes/enroll_totp"
+	"vdm/core/dependencies"
+	"vdm/core/fiberx"
+)
+
+const Prefix = "/mfa"
+
+func Group(deps *dependencies.Dependencies) *fiberx.Group {
+	group := fiberx.NewGroup(Prefix)
+
+	group.Add(
+		enroll_totp.Route(deps.GormDB(), deps.Config.Security),
+		confirm_totp.Route(deps.GormDB(), deps.Config.Security),
+		disable_totp.Route(deps.GormDB(), deps.Config.Security),
+	)
+
+	return group
+}
@@ -0,0 +1,10 @@
+package confirm_totp
+
+type RequestDTO struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type ResponseDTO struct {
+	// RecoveryCodes are only returned once, they are stored hashed
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
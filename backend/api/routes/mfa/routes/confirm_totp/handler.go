@@ -0,0 +1,39 @@
+package confirm_totp
+
+import (
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	confirmTOTP(c *fiber.Ctx) error
+}
+
+type handler struct {
+	svc Service
+}
+
+func (h *handler) confirmTOTP(c *fiber.Ctx) error {
+	var req RequestDTO
+	if err := c.BodyParser(&req); err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid request body"}
+	}
+	if err := validation.Validate(req); err != nil {
+		return err
+	}
+
+	authedUser, ok := c.Locals(local_keys.AuthedUser).(locals.AuthedUser)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "can't locals authed user"}
+	}
+
+	recoveryCodes, err := h.svc.confirmTOTP(authedUser, req.Code)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(ResponseDTO{RecoveryCodes: recoveryCodes})
+}
@@ -0,0 +1,44 @@
+package confirm_totp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type nullService struct{}
+
+func (nullService) confirmTOTP(authedUser locals.AuthedUser, code string) ([]string, error) {
+	return nil, nil
+}
+
+func TestHandler_ErrBadRequest(t *testing.T) {
+	h := &handler{svc: nullService{}}
+
+	app := fiberx.NewApp()
+	app.Add(Method, Path, h.confirmTOTP)
+
+	for name, reqDTO := range map[string]RequestDTO{
+		"no code":    {},
+		"short code": {Code: "12345"},
+		"alpha code": {Code: "abcdef"},
+	} {
+		b, _ := json.Marshal(reqDTO)
+		req := httptest.NewRequest(Method, Path, bytes.NewReader(b))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode, name)
+	}
+}
@@ -0,0 +1,122 @@
+package confirm_totp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/aes_utils"
+	"vdm/core/dependencies/database"
+	"vdm/core/env"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/models"
+	"vdm/core/totp"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	user    *models.User
+	secret  string
+	session uuid.UUID
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.user = &models.User{Email: "user@test.com", Tag: "user0123", Password: "x"}
+	if err = connector.GormDB().Create(data.user).Error; err != nil {
+		return
+	}
+
+	if data.secret, err = totp.GenerateSecret(); err != nil {
+		return
+	}
+	var encrypted string
+	if encrypted, err = aes_utils.Encrypt(data.secret, testKey); err != nil {
+		return
+	}
+	if err = connector.GormDB().Create(&models.UserTOTP{UserID: data.user.ID, Secret: encrypted}).Error; err != nil {
+		return
+	}
+
+	data.session = uuid.New()
+	other := uuid.New()
+	expiry := time.Now().Add(time.Hour)
+
+	err = connector.GormDB().Create([]*models.UserToken{
+		{UserID: data.user.ID, Hash: "session", Category: models.UserTokenCategoryRefresh, Expiry: expiry, FamilyID: &data.session},
+		{UserID: data.user.ID, Hash: "other", Category: models.UserTokenCategoryRefresh, Expiry: expiry, FamilyID: &other},
+	}).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(local_keys.AuthedUser, locals.AuthedUser{ID: data.user.ID, SessionID: data.session})
+		return c.Next()
+	})
+	Route(connector.GormDB(), env.SecurityConfig{MfaEncryptionKey: testKey}).Register(app)
+
+	code, err := totp.Code(data.secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := json.Marshal(RequestDTO{Code: code})
+	req := httptest.NewRequest(Method, Path, bytes.NewReader(b))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	var dto ResponseDTO
+	if err = json.NewDecoder(res.Body).Decode(&dto); err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, dto.RecoveryCodes, totp.RecoveryCodesCount)
+
+	var userTOTP models.UserTOTP
+	if err = connector.GormDB().First(&userTOTP, "user_id = ?", data.user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, userTOTP.Confirmed())
+
+	var count int64
+	if err = connector.GormDB().Model(&models.UserRecoveryCode{}).Where("user_id = ?", data.user.ID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(totp.RecoveryCodesCount), count)
+
+	// the other sessions are signed out
+	var hashes []string
+	if err = connector.GormDB().Model(&models.UserToken{}).Pluck("hash", &hashes).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"session"}, hashes)
+}
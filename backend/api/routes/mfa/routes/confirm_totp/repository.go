@@ -0,0 +1,73 @@
+package confirm_totp
+
+import (
+	"time"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	findTOTP(userID uuid.UUID) (*models.UserTOTP, error)
+	confirmTOTP(userID uuid.UUID, step int64, recoveryCodeHashes []string, keptSessionID uuid.UUID) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) findTOTP(userID uuid.UUID) (*models.UserTOTP, error) {
+	var totps []models.UserTOTP
+	if err := r.db.Where("user_id = ?", userID).
+		Limit(1).
+		Find(&totps).Error; err != nil {
+		return nil, err
+	}
+	if len(totps) == 0 {
+		return nil, nil
+	}
+	return &totps[0], nil
+}
+
+// confirmTOTP confirms the pending TOTP, replaces the recovery codes of the user
+// and deletes the refresh tokens of every session but keptSessionID.
+// It returns false when a concurrent request confirmed the TOTP first.
+func (r *repository) confirmTOTP(userID uuid.UUID, step int64, recoveryCodeHashes []string, keptSessionID uuid.UUID) (bool, error) {
+	var confirmed bool
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.UserTOTP{}).
+			Where("user_id = ? AND confirmed_at IS NULL", userID).
+			Updates(map[string]any{"confirmed_at": time.Now(), "last_used_step": step})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Where("user_id = ?", userID).
+			Delete(&models.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		recoveryCodes := make([]models.UserRecoveryCode, len(recoveryCodeHashes))
+		for i, hash := range recoveryCodeHashes {
+			recoveryCodes[i] = models.UserRecoveryCode{UserID: userID, Hash: hash}
+		}
+		if err := tx.Create(&recoveryCodes).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ? AND category = ? AND family_id IS DISTINCT FROM ?", userID, models.UserTokenCategoryRefresh, keptSessionID).
+			Delete(&models.UserToken{}).Error; err != nil {
+			return err
+		}
+
+		confirmed = true
+		return nil
+	})
+
+	return confirmed, err
+}
@@ -0,0 +1,21 @@
+package confirm_totp
+
+import (
+	"vdm/core/env"
+	"vdm/core/fiberx"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	Path   = "/totp/confirm"
+	Method = fiber.MethodPost
+)
+
+func Route(db *gorm.DB, cfg env.SecurityConfig) *fiberx.Route {
+	repo := &repository{db}
+	svc := &service{repo: repo, encryptionKey: cfg.MfaEncryptionKey}
+	handler := &handler{svc}
+	return fiberx.NewRoute(Method, Path, handler.confirmTOTP)
+}
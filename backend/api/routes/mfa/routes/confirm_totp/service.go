@@ -0,0 +1,66 @@
+package confirm_totp
+
+import (
+	"fmt"
+	"time"
+	"vdm/core/aes_utils"
+	"vdm/core/hmac_utils"
+	"vdm/core/locals"
+	"vdm/core/totp"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Service interface {
+	confirmTOTP(authedUser locals.AuthedUser, code string) ([]string, error)
+}
+
+type service struct {
+	repo          Repository
+	encryptionKey []byte
+}
+
+// confirmTOTP enables the pending TOTP of the user once a first code is valid.
+// It issues new recovery codes and signs out the other sessions of the user.
+func (s *service) confirmTOTP(authedUser locals.AuthedUser, code string) ([]string, error) {
+	userTOTP, err := s.repo.findTOTP(authedUser.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find totp: %v", err)
+	}
+	if userTOTP == nil {
+		return nil, &fiber.Error{Code: fiber.StatusNotFound, Message: "totp not enrolled"}
+	}
+	if userTOTP.Confirmed() {
+		return nil, &fiber.Error{Code: fiber.StatusConflict, Message: "totp already enabled"}
+	}
+
+	secret, err := aes_utils.Decrypt(userTOTP.Secret, s.encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt totp secret: %v", err)
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return nil, &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid code"}
+	}
+
+	recoveryCodes, err := totp.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recovery codes: %v", err)
+	}
+
+	hashes := make([]string, len(recoveryCodes))
+	for i, recoveryCode := range recoveryCodes {
+		hashes[i] = hmac_utils.HashString(totp.NormalizeRecoveryCode(recoveryCode), s.encryptionKey)
+	}
+
+	confirmed, err := s.repo.confirmTOTP(authedUser.ID, step, hashes, authedUser.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm totp: %v", err)
+	}
+	if !confirmed {
+		return nil, &fiber.Error{Code: fiber.StatusConflict, Message: "totp already enabled"}
+	}
+
+	return recoveryCodes, nil
+}
@@ -0,0 +1,110 @@
+package confirm_totp
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+	"vdm/core/aes_utils"
+	"vdm/core/hmac_utils"
+	"vdm/core/locals"
+	"vdm/core/models"
+	"vdm/core/totp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var testKey = bytes.Repeat([]byte("k"), 32)
+
+type stubRepository struct {
+	userTOTP  *models.UserTOTP
+	confirmed bool
+
+	step          int64
+	hashes        []string
+	keptSessionID uuid.UUID
+}
+
+func (r *stubRepository) findTOTP(userID uuid.UUID) (*models.UserTOTP, error) {
+	return r.userTOTP, nil
+}
+
+func (r *stubRepository) confirmTOTP(userID uuid.UUID, step int64, recoveryCodeHashes []string, keptSessionID uuid.UUID) (bool, error) {
+	r.step, r.hashes, r.keptSessionID = step, recoveryCodeHashes, keptSessionID
+	return r.confirmed, nil
+}
+
+func newPendingTOTP(t *testing.T) (string, *models.UserTOTP) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := aes_utils.Encrypt(secret, testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return secret, &models.UserTOTP{Secret: encrypted}
+}
+
+func TestService_Success(t *testing.T) {
+	secret, userTOTP := newPendingTOTP(t)
+	repo := &stubRepository{userTOTP: userTOTP, confirmed: true}
+	svc := &service{repo: repo, encryptionKey: testKey}
+
+	step := totp.Step(time.Now())
+	code, err := totp.Code(secret, step)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authedUser := locals.AuthedUser{ID: uuid.New(), SessionID: uuid.New()}
+	recoveryCodes, err := svc.confirmTOTP(authedUser, code)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, recoveryCodes, totp.RecoveryCodesCount)
+	assert.GreaterOrEqual(t, repo.step, step)
+	assert.Equal(t, authedUser.SessionID, repo.keptSessionID)
+	if assert.Len(t, repo.hashes, totp.RecoveryCodesCount) {
+		// recovery codes are never stored in plain text
+		assert.Equal(t, hmac_utils.HashString(totp.NormalizeRecoveryCode(recoveryCodes[0]), testKey), repo.hashes[0])
+		assert.NotContains(t, repo.hashes, recoveryCodes[0])
+	}
+}
+
+func TestService_Err(t *testing.T) {
+	secret, pending := newPendingTOTP(t)
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrongCode := "000000"
+	if code == wrongCode {
+		wrongCode = "111111"
+	}
+
+	confirmedAt := time.Now()
+	_, confirmed := newPendingTOTP(t)
+	confirmed.ConfirmedAt = &confirmedAt
+
+	for name, tc := range map[string]struct {
+		repo *stubRepository
+		code string
+		want int
+	}{
+		"not enrolled":      {repo: &stubRepository{}, code: code, want: fiber.StatusNotFound},
+		"already enabled":   {repo: &stubRepository{userTOTP: confirmed}, code: code, want: fiber.StatusConflict},
+		"invalid code":      {repo: &stubRepository{userTOTP: pending}, code: wrongCode, want: fiber.StatusBadRequest},
+		"concurrent enable": {repo: &stubRepository{userTOTP: pending}, code: code, want: fiber.StatusConflict},
+	} {
+		_, err := (&service{repo: tc.repo, encryptionKey: testKey}).confirmTOTP(locals.AuthedUser{ID: uuid.New()}, tc.code)
+
+		var fiberErr *fiber.Error
+		if assert.True(t, errors.As(err, &fiberErr), name) {
+			assert.Equal(t, tc.want, fiberErr.Code, name)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+package disable_totp
+
+type RequestDTO struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
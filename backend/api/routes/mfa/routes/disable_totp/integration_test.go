@@ -0,0 +1,95 @@
+package disable_totp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/aes_utils"
+	"vdm/core/dependencies/database"
+	"vdm/core/env"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/models"
+	"vdm/core/totp"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, user *models.User, secret string) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	user = &models.User{Email: "user@test.com", Tag: "user0123", Password: "x"}
+	if err = connector.GormDB().Create(user).Error; err != nil {
+		return
+	}
+
+	if secret, err = totp.GenerateSecret(); err != nil {
+		return
+	}
+	var encrypted string
+	if encrypted, err = aes_utils.Encrypt(secret, testKey); err != nil {
+		return
+	}
+	confirmedAt := time.Now()
+	if err = connector.GormDB().Create(&models.UserTOTP{UserID: user.ID, Secret: encrypted, ConfirmedAt: &confirmedAt}).Error; err != nil {
+		return
+	}
+
+	err = connector.GormDB().Create(&models.UserRecoveryCode{UserID: user.ID, Hash: "hash"}).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, user, secret := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(local_keys.AuthedUser, locals.AuthedUser{ID: user.ID, Roles: []models.RoleName{models.RoleRedactor}, MFAEnabled: true})
+		return c.Next()
+	})
+	Route(connector.GormDB(), env.SecurityConfig{MfaEncryptionKey: testKey}).Register(app)
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := json.Marshal(RequestDTO{Code: code})
+	req := httptest.NewRequest(Method, Path, bytes.NewReader(b))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNoContent, res.StatusCode)
+
+	var count int64
+	if err = connector.GormDB().Model(&models.UserTOTP{}).Where("user_id = ?", user.ID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Zero(t, count)
+
+	if err = connector.GormDB().Model(&models.UserRecoveryCode{}).Where("user_id = ?", user.ID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Zero(t, count)
+}
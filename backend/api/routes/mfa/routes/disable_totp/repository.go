@@ -0,0 +1,41 @@
+package disable_totp
+
+import (
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	findTOTP(userID uuid.UUID) (*models.UserTOTP, error)
+	deleteTOTP(userID uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) findTOTP(userID uuid.UUID) (*models.UserTOTP, error) {
+	var totps []models.UserTOTP
+	if err := r.db.Where("user_id = ?", userID).
+		Limit(1).
+		Find(&totps).Error; err != nil {
+		return nil, err
+	}
+	if len(totps) == 0 {
+		return nil, nil
+	}
+	return &totps[0], nil
+}
+
+func (r *repository) deleteTOTP(userID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).
+			Delete(&models.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).
+			Delete(&models.UserTOTP{}).Error
+	})
+}
@@ -0,0 +1,52 @@
+package disable_totp
+
+import (
+	"fmt"
+	"time"
+	"vdm/core/aes_utils"
+	"vdm/core/locals"
+	"vdm/core/totp"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Service interface {
+	disableTOTP(authedUser locals.AuthedUser, code string) error
+}
+
+type service struct {
+	repo          Repository
+	encryptionKey []byte
+}
+
+// disableTOTP removes the TOTP and the recovery codes of the user, a current code is required.
+// Users holding a role which requires two-factor authentication can't disable it.
+func (s *service) disableTOTP(authedUser locals.AuthedUser, code string) error {
+	if authedUser.RequiresMFA() {
+		return &fiber.Error{Code: fiber.StatusForbidden, Message: "two-factor authentication is required for your roles"}
+	}
+
+	userTOTP, err := s.repo.findTOTP(authedUser.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find totp: %v", err)
+	}
+	if userTOTP == nil || !userTOTP.Confirmed() {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: "totp not enabled"}
+	}
+
+	secret, err := aes_utils.Decrypt(userTOTP.Secret, s.encryptionKey)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt totp secret: %v", err)
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok || step <= userTOTP.LastUsedStep {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid code"}
+	}
+
+	if err = s.repo.deleteTOTP(authedUser.ID); err != nil {
+		return fmt.Errorf("failed to delete totp: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package disable_totp
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+	"vdm/core/aes_utils"
+	"vdm/core/locals"
+	"vdm/core/models"
+	"vdm/core/totp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var testKey = bytes.Repeat([]byte("k"), 32)
+
+type stubRepository struct {
+	userTOTP *models.UserTOTP
+	deleted  bool
+}
+
+func (r *stubRepository) findTOTP(userID uuid.UUID) (*models.UserTOTP, error) {
+	return r.userTOTP, nil
+}
+
+func (r *stubRepository) deleteTOTP(userID uuid.UUID) error {
+	r.deleted = true
+	return nil
+}
+
+func newConfirmedTOTP(t *testing.T) (string, *models.UserTOTP) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := aes_utils.Encrypt(secret, testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	confirmedAt := time.Now()
+	return secret, &models.UserTOTP{Secret: encrypted, ConfirmedAt: &confirmedAt}
+}
+
+func TestService_Success(t *testing.T) {
+	secret, userTOTP := newConfirmedTOTP(t)
+	repo := &stubRepository{userTOTP: userTOTP}
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authedUser := locals.AuthedUser{ID: uuid.New(), Roles: []models.RoleName{models.RoleRedactor}}
+	if err = (&service{repo: repo, encryptionKey: testKey}).disableTOTP(authedUser, code); err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, repo.deleted)
+}
+
+func TestService_Err(t *testing.T) {
+	secret, confirmed := newConfirmedTOTP(t)
+	step := totp.Step(time.Now())
+	code, err := totp.Code(secret, step)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, used := newConfirmedTOTP(t)
+	used.Secret = confirmed.Secret
+	used.LastUsedStep = step + 1
+
+	_, pending := newConfirmedTOTP(t)
+	pending.ConfirmedAt = nil
+
+	redactor := locals.AuthedUser{ID: uuid.New(), Roles: []models.RoleName{models.RoleRedactor}}
+	moderator := locals.AuthedUser{ID: uuid.New(), Roles: []models.RoleName{models.RoleRedactor, models.RoleModerator}}
+
+	for name, tc := range map[string]struct {
+		authedUser locals.AuthedUser
+		repo       *stubRepository
+		want       int
+	}{
+		"required by role": {authedUser: moderator, repo: &stubRepository{userTOTP: confirmed}, want: fiber.StatusForbidden},
+		"not enrolled":     {authedUser: redactor, repo: &stubRepository{}, want: fiber.StatusNotFound},
+		"not confirmed":    {authedUser: redactor, repo: &stubRepository{userTOTP: pending}, want: fiber.StatusNotFound},
+		"replayed code":    {authedUser: redactor, repo: &stubRepository{userTOTP: used}, want: fiber.StatusBadRequest},
+	} {
+		err := (&service{repo: tc.repo, encryptionKey: testKey}).disableTOTP(tc.authedUser, code)
+
+		var fiberErr *fiber.Error
+		if assert.True(t, errors.As(err, &fiberErr), name) {
+			assert.Equal(t, tc.want, fiberErr.Code, name)
+		}
+		assert.False(t, tc.repo.deleted, name)
+	}
+}
@@ -0,0 +1,7 @@
+package enroll_totp
+
+type ResponseDTO struct {
+	// Secret is shown for the users who can't scan the QR code of ProvisioningURI
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
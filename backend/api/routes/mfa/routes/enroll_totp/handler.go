@@ -0,0 +1,30 @@
+package enroll_totp
+
+import (
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	enrollTOTP(c *fiber.Ctx) error
+}
+
+type handler struct {
+	svc Service
+}
+
+func (h *handler) enrollTOTP(c *fiber.Ctx) error {
+	authedUser, ok := c.Locals(local_keys.AuthedUser).(locals.AuthedUser)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "can't locals authed user"}
+	}
+
+	res, err := h.svc.enrollTOTP(authedUser.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(res)
+}
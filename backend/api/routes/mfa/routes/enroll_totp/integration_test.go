@@ -0,0 +1,106 @@
+package enroll_totp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/aes_utils"
+	"vdm/core/dependencies/database"
+	"vdm/core/env"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+var testCfg = env.SecurityConfig{MfaEncryptionKey: bytes.Repeat([]byte("k"), 32), MfaIssuer: "Vigie du mensonge"}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, user *models.User) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	user = &models.User{Email: "user@test.com", Tag: "user0123", Password: "x"}
+	if err := connector.GormDB().Create(user).Error; err != nil {
+		test_utils.CleanUpTestData(c, t, container, connector)
+		t.Fatal(err)
+	}
+
+	return
+}
+
+func newApp(connector database.Connector, user *models.User) *fiber.App {
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(local_keys.AuthedUser, locals.AuthedUser{ID: user.ID})
+		return c.Next()
+	})
+	Route(connector.GormDB(), testCfg).Register(app)
+	return app
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, user := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := newApp(connector, user)
+
+	// enrolling twice replaces the pending secret
+	var dto ResponseDTO
+	for range 2 {
+		res, err := app.Test(httptest.NewRequest(Method, Path, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+
+		err = json.NewDecoder(res.Body).Decode(&dto)
+		_ = res.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Contains(t, dto.ProvisioningURI, "otpauth://totp/Vigie%20du%20mensonge:user@test.com?")
+	assert.Contains(t, dto.ProvisioningURI, "secret="+dto.Secret)
+
+	var userTOTP models.UserTOTP
+	if err := connector.GormDB().First(&userTOTP, "user_id = ?", user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, userTOTP.Confirmed())
+
+	// the secret is stored encrypted
+	assert.NotEqual(t, dto.Secret, userTOTP.Secret)
+	secret, err := aes_utils.Decrypt(userTOTP.Secret, testCfg.MfaEncryptionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, dto.Secret, secret)
+}
+
+func TestIntegration_ErrConflict(t *testing.T) {
+	c := context.Background()
+	container, connector, user := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	confirmedAt := time.Now()
+	if err := connector.GormDB().Create(&models.UserTOTP{UserID: user.ID, Secret: "encrypted", ConfirmedAt: &confirmedAt}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := newApp(connector, user).Test(httptest.NewRequest(Method, Path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusConflict, res.StatusCode)
+}
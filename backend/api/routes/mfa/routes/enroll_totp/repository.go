@@ -0,0 +1,54 @@
+package enroll_totp
+
+import (
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	findUserWithTOTP(userID uuid.UUID) (userWithTOTP, error)
+	savePendingTOTP(userTOTP *models.UserTOTP) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+type userWithTOTP struct {
+	email string
+	totp  *models.UserTOTP
+}
+
+func (r *repository) findUserWithTOTP(userID uuid.UUID) (userWithTOTP, error) {
+	var user models.User
+	if err := r.db.Select("id", "email").
+		First(&user, "id = ?", userID).Error; err != nil {
+		return userWithTOTP{}, err
+	}
+
+	var totps []models.UserTOTP
+	if err := r.db.Where("user_id = ?", userID).
+		Limit(1).
+		Find(&totps).Error; err != nil {
+		return userWithTOTP{}, err
+	}
+
+	res := userWithTOTP{email: user.Email}
+	if len(totps) > 0 {
+		res.totp = &totps[0]
+	}
+	return res, nil
+}
+
+// savePendingTOTP replaces the pending TOTP of the user, a confirmed one is left untouched
+func (r *repository) savePendingTOTP(userTOTP *models.UserTOTP) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND confirmed_at IS NULL", userTOTP.UserID).
+			Delete(&models.UserTOTP{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(userTOTP).Error
+	})
+}
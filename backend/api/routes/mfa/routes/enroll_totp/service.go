@@ -0,0 +1,51 @@
+package enroll_totp
+
+import (
+	"fmt"
+	"vdm/core/aes_utils"
+	"vdm/core/models"
+	"vdm/core/totp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	enrollTOTP(userID uuid.UUID) (ResponseDTO, error)
+}
+
+type service struct {
+	repo          Repository
+	encryptionKey []byte
+	issuer        string
+}
+
+// enrollTOTP generates a secret, pending until confirm_totp checks a first code. A pending secret is replaced.
+func (s *service) enrollTOTP(userID uuid.UUID) (ResponseDTO, error) {
+	user, err := s.repo.findUserWithTOTP(userID)
+	if err != nil {
+		return ResponseDTO{}, fmt.Errorf("failed to find user: %v", err)
+	}
+	if user.totp != nil && user.totp.Confirmed() {
+		return ResponseDTO{}, &fiber.Error{Code: fiber.StatusConflict, Message: "totp already enabled"}
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return ResponseDTO{}, fmt.Errorf("failed to generate totp secret: %v", err)
+	}
+
+	encrypted, err := aes_utils.Encrypt(secret, s.encryptionKey)
+	if err != nil {
+		return ResponseDTO{}, fmt.Errorf("failed to encrypt totp secret: %v", err)
+	}
+
+	if err = s.repo.savePendingTOTP(&models.UserTOTP{UserID: userID, Secret: encrypted}); err != nil {
+		return ResponseDTO{}, fmt.Errorf("failed to save totp: %v", err)
+	}
+
+	return ResponseDTO{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.issuer, user.email, secret),
+	}, nil
+}
@@ -29,6 +29,11 @@ func Group(deps *dependencies.Dependencies) *fiberx.Group {
 					Message: fmt.Sprintf("user %s does not have role %s", authedUser.ID, models.RoleModerator)}
 			}
 
+			if !authedUser.MFAEnabled {
+				return &fiber.Error{Code: fiber.StatusForbidden,
+					Message: fmt.Sprintf("role %s requires two-factor authentication", models.RoleModerator)}
+			}
+
 			return c.Next()
 		}),
 
@@ -2,10 +2,16 @@ package moderator_articles
 
 import (
 	"vdm/api/routes/moderator/moderator_articles/moderator_claim_article"
+	"vdm/api/routes/moderator/moderator_articles/moderator_diff_articles"
 	"vdm/api/routes/moderator/moderator_articles/moderator_find_article"
+	"vdm/api/routes/moderator/moderator_articles/moderator_get_article_history"
+	"vdm/api/routes/moderator/moderator_articles/moderator_get_article_sources"
 	"vdm/api/routes/moderator/moderator_articles/moderator_get_claimed_articles"
 	"vdm/api/routes/moderator/moderator_articles/moderator_get_pending_articles"
+	"vdm/api/routes/moderator/moderator_articles/moderator_get_review_comments"
+	"vdm/api/routes/moderator/moderator_articles/moderator_release_article"
 	"vdm/api/routes/moderator/moderator_articles/moderator_save_review"
+	"vdm/api/routes/moderator/moderator_articles/moderator_save_review_comment"
 	"vdm/core/dependencies"
 	"vdm/core/fiberx"
 )
@@ -19,8 +25,14 @@ func Group(deps *dependencies.Dependencies) *fiberx.Group {
 		moderator_get_claimed_articles.Route(deps.GormDB()),
 		moderator_get_pending_articles.Route(deps.GormDB()),
 		moderator_find_article.Route(deps.GormDB()),
+		moderator_diff_articles.Route(deps.GormDB()),
+		moderator_get_article_history.Route(deps.GormDB()),
+		moderator_get_article_sources.Route(deps.GormDB()),
 		moderator_claim_article.Route(deps.GormDB()),
-		moderator_save_review.Route(deps.GormDB()),
+		moderator_release_article.Route(deps.GormDB()),
+		moderator_save_review.Route(deps.GormDB(), deps.Config.Moderation.PublicationQuorum, deps.Cache),
+		moderator_get_review_comments.Route(deps.GormDB()),
+		moderator_save_review_comment.Route(deps.GormDB()),
 	)
 
 	return group
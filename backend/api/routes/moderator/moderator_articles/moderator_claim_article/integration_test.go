@@ -89,7 +89,7 @@ func TestIntegration_Success(t *testing.T) {
 
 	var cnt int64
 	if err := connector.GormDB().Model(&models.Article{}).
-		Where("id = ? AND moderator_id = ?", data.article.ID, data.moderator.ID).
+		Where("id = ? AND moderator_id = ? AND claimed_at IS NOT NULL", data.article.ID, data.moderator.ID).
 		Count(&cnt).Error; err != nil {
 		t.Fatal(err)
 	}
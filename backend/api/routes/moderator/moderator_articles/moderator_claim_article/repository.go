@@ -1,6 +1,7 @@
 package moderator_claim_article
 
 import (
+	"time"
 	"vdm/core/models"
 
 	"github.com/google/uuid"
@@ -18,5 +19,7 @@ type repository struct {
 func (r *repository) updateArticleModerator(moderatorID, articleID uuid.UUID) error {
 	return r.db.Model(&models.Article{}).
 		Where("id = ? AND redactor_id <> ? AND moderator_id IS NULL", articleID, moderatorID).
-		Update("moderator_id", moderatorID).Error
+		// a moderator reviews each version at most once
+		Where("NOT EXISTS (SELECT 1 FROM article_reviews WHERE article_id = articles.id AND moderator_id = ?)", moderatorID).
+		Updates(map[string]any{"moderator_id": moderatorID, "claimed_at": time.Now()}).Error
 }
@@ -0,0 +1,73 @@
+package moderator_diff_articles
+
+import (
+	"time"
+	"vdm/core/diff_utils"
+	"vdm/core/dto/response_dto"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	fromParam = "from"
+	toParam   = "to"
+)
+
+type VersionDTO struct {
+	ID        uuid.UUID            `json:"id"`
+	Major     int16                `json:"major"`
+	Minor     int16                `json:"minor"`
+	Status    models.ArticleStatus `json:"status"`
+	UpdatedAt time.Time            `json:"updatedAt"`
+}
+
+type ChangeDTO[T any] struct {
+	From T `json:"from"`
+	To   T `json:"to"`
+}
+
+type SetDiffDTO[T any] struct {
+	Added   []T `json:"added"`
+	Removed []T `json:"removed"`
+}
+
+// SourceChangeDTO lists the fields which changed in a source found by its URL in both versions
+type SourceChangeDTO struct {
+	URL              string                               `json:"url"`
+	Type             *ChangeDTO[models.ArticleSourceType] `json:"type,omitempty"`
+	Publisher        *ChangeDTO[string]                   `json:"publisher,omitempty"`
+	PublishedAt      *ChangeDTO[*time.Time]               `json:"publishedAt,omitempty"`
+	TimestampSeconds *ChangeDTO[*int]                     `json:"timestampSeconds,omitempty"`
+	Quote            *ChangeDTO[string]                   `json:"quote,omitempty"`
+}
+
+type SourcesDiffDTO struct {
+	SetDiffDTO[string]
+	Changed []SourceChangeDTO `json:"changed"`
+}
+
+type ResponseDTO struct {
+	From VersionDTO `json:"from"`
+	To   VersionDTO `json:"to"`
+
+	Title []diff_utils.Edit `json:"title"`
+	Body  []diff_utils.Edit `json:"body"`
+
+	Category  *ChangeDTO[models.ArticleCategory] `json:"category,omitempty"`
+	EventDate *ChangeDTO[time.Time]              `json:"eventDate,omitempty"`
+
+	Tags        SetDiffDTO[string]                  `json:"tags"`
+	Sources     SourcesDiffDTO                      `json:"sources"`
+	Politicians SetDiffDTO[response_dto.Politician] `json:"politicians"`
+}
+
+func newVersionDTO(article models.Article) VersionDTO {
+	return VersionDTO{
+		ID:        article.ID,
+		Major:     article.Major,
+		Minor:     article.Minor,
+		Status:    article.Status,
+		UpdatedAt: article.UpdatedAt,
+	}
+}
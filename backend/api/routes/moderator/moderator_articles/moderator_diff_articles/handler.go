@@ -0,0 +1,41 @@
+package moderator_diff_articles
+
+import (
+	"fmt"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	diffArticlesForModerator(c *fiber.Ctx) error
+}
+
+type handler struct {
+	svc Service
+}
+
+func (h *handler) diffArticlesForModerator(c *fiber.Ctx) error {
+	articleRef, err := uuid.Parse(c.Params(local_keys.ArticleReference))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid article reference"}
+	}
+
+	fromID, err := uuid.Parse(c.Query(fromParam))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: fmt.Sprintf("query param <%s> must be an article id", fromParam)}
+	}
+
+	toID, err := uuid.Parse(c.Query(toParam))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: fmt.Sprintf("query param <%s> must be an article id", toParam)}
+	}
+
+	respDTO, err := h.svc.diffArticles(articleRef, fromID, toID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(respDTO)
+}
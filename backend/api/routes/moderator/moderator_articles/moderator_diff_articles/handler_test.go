@@ -0,0 +1,40 @@
+package moderator_diff_articles
+
+import (
+	"net/http/httptest"
+	"testing"
+	"vdm/core/fiberx"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type nullService struct{}
+
+func (*nullService) diffArticles(reference, fromID, toID uuid.UUID) (ResponseDTO, error) {
+	return ResponseDTO{}, nil
+}
+
+func TestHandler_ErrBadRequest(t *testing.T) {
+	app := fiberx.NewApp()
+	h := &handler{svc: &nullService{}}
+	app.Add(Method, Path, h.diffArticlesForModerator)
+
+	ref, id := uuid.New().String(), uuid.New().String()
+
+	for _, target := range []string{
+		"/not-a-uuid/diff?from=" + id + "&to=" + id,
+		"/" + ref + "/diff?to=" + id,
+		"/" + ref + "/diff?from=" + id + "&to=not-a-uuid",
+	} {
+		req := httptest.NewRequest(Method, target, nil)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode, target)
+	}
+}
@@ -0,0 +1,134 @@
+package moderator_diff_articles
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"vdm/core/dependencies/database"
+	"vdm/core/diff_utils"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	ref      uuid.UUID
+	articles []*models.Article
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	data.ref = uuid.New()
+
+	data.articles = []*models.Article{
+		{
+			RedactorID: redactor.ID,
+			Title:      "Article v1",
+			Body:       "Premier jet de l'article.",
+			Tags:       []*models.ArticleTag{{Tag: "Macron"}},
+			Sources:    []*models.ArticleSource{{URL: "https://example.com/a"}},
+			Status:     models.ArticleStatusArchived,
+			Reference:  data.ref,
+			Minor:      1,
+		},
+		{
+			RedactorID: redactor.ID,
+			Title:      "Article v2",
+			Body:       "Second jet de l'article.",
+			Tags:       []*models.ArticleTag{{Tag: "Macron"}, {Tag: "Retraites"}},
+			Sources:    []*models.ArticleSource{{URL: "https://example.com/a"}},
+			Status:     models.ArticleStatusUnderReview,
+			Reference:  data.ref,
+			Minor:      2,
+		},
+	}
+
+	err = connector.GormDB().Create(&data.articles).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	target := fmt.Sprintf("/%s/diff?from=%s&to=%s", data.ref, data.articles[0].ID, data.articles[1].ID)
+	req := httptest.NewRequest(Method, target, nil)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code 200, got %d", res.StatusCode)
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resDTO ResponseDTO
+	if err = json.Unmarshal(resBody, &resDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, int16(1), resDTO.From.Minor)
+	assert.Equal(t, int16(2), resDTO.To.Minor)
+	assert.Equal(t, []diff_utils.Edit{
+		{Operation: diff_utils.OperationDelete, Text: "Premier"},
+		{Operation: diff_utils.OperationInsert, Text: "Second"},
+		{Operation: diff_utils.OperationEqual, Text: " jet de l'article."},
+	}, resDTO.Body)
+	assert.Equal(t, []string{"Retraites"}, resDTO.Tags.Added)
+	assert.Empty(t, resDTO.Sources.Added)
+	assert.Empty(t, resDTO.Sources.Removed)
+}
+
+func TestIntegration_ErrNotFound(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	// both versions must share the requested reference
+	target := fmt.Sprintf("/%s/diff?from=%s&to=%s", uuid.New(), data.articles[0].ID, data.articles[1].ID)
+	req := httptest.NewRequest(Method, target, nil)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
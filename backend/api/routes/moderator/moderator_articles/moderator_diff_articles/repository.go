@@ -0,0 +1,32 @@
+package moderator_diff_articles
+
+import (
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	findArticleVersions(reference uuid.UUID, articleIDs ...uuid.UUID) ([]models.Article, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) findArticleVersions(reference uuid.UUID, articleIDs ...uuid.UUID) ([]models.Article, error) {
+	var articles []models.Article
+
+	if err := r.db.Where("reference = ? AND id IN ?", reference, articleIDs).
+		Preload("Sources").
+		Preload("Tags").
+		Preload("Politicians", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "first_name", "last_name")
+		}).
+		Find(&articles).Error; err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
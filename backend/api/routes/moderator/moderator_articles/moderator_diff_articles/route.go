@@ -0,0 +1,21 @@
+package moderator_diff_articles
+
+import (
+	"vdm/core/fiberx"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	Path   = "/:" + local_keys.ArticleReference + "/diff"
+	Method = fiber.MethodGet
+)
+
+func Route(db *gorm.DB) *fiberx.Route {
+	repo := &repository{db}
+	svc := &service{repo}
+	handler := &handler{svc}
+	return fiberx.NewRoute(Method, Path, handler.diffArticlesForModerator)
+}
@@ -0,0 +1,142 @@
+package moderator_diff_articles
+
+import (
+	"fmt"
+	"time"
+	"vdm/core/diff_utils"
+	"vdm/core/dto/response_dto"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	diffArticles(reference, fromID, toID uuid.UUID) (ResponseDTO, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func (s *service) diffArticles(reference, fromID, toID uuid.UUID) (ResponseDTO, error) {
+	articles, err := s.repo.findArticleVersions(reference, fromID, toID)
+	if err != nil {
+		return ResponseDTO{}, fmt.Errorf("failed to find article versions: %v", err)
+	}
+
+	var from, to *models.Article
+	for i := range articles {
+		if articles[i].ID == fromID {
+			from = &articles[i]
+		}
+		if articles[i].ID == toID {
+			to = &articles[i]
+		}
+	}
+
+	if from == nil || to == nil {
+		return ResponseDTO{}, &fiber.Error{Code: fiber.StatusNotFound,
+			Message: fmt.Sprintf("articles %s and %s not found with reference %s", fromID, toID, reference)}
+	}
+
+	return diff(*from, *to), nil
+}
+
+func diff(from, to models.Article) ResponseDTO {
+	respDTO := ResponseDTO{
+		From:  newVersionDTO(from),
+		To:    newVersionDTO(to),
+		Title: diff_utils.Words(from.Title, to.Title),
+		Body:  diff_utils.Words(from.Body, to.Body),
+	}
+
+	if from.Category != to.Category {
+		respDTO.Category = &ChangeDTO[models.ArticleCategory]{From: from.Category, To: to.Category}
+	}
+
+	if !from.EventDate.Equal(to.EventDate) {
+		respDTO.EventDate = &ChangeDTO[time.Time]{From: from.EventDate, To: to.EventDate}
+	}
+
+	respDTO.Tags.Added, respDTO.Tags.Removed = diff_utils.Sets(tags(from), tags(to))
+	respDTO.Sources.Added, respDTO.Sources.Removed = diff_utils.Sets(sources(from), sources(to))
+	respDTO.Sources.Changed = changedSources(from, to)
+	respDTO.Politicians.Added, respDTO.Politicians.Removed = diff_utils.Sets(politicians(from), politicians(to))
+
+	return respDTO
+}
+
+func tags(article models.Article) []string {
+	values := make([]string, len(article.Tags))
+	for i := range article.Tags {
+		values[i] = article.Tags[i].Tag
+	}
+	return values
+}
+
+func sources(article models.Article) []string {
+	values := make([]string, len(article.Sources))
+	for i := range article.Sources {
+		values[i] = article.Sources[i].URL
+	}
+	return values
+}
+
+// changedSources compares the sources kept from one version to the other, by URL, field by field
+func changedSources(from, to models.Article) []SourceChangeDTO {
+	kept := make(map[string]*models.ArticleSource, len(from.Sources))
+	for _, source := range from.Sources {
+		kept[source.URL] = source
+	}
+
+	changes := make([]SourceChangeDTO, 0)
+	for _, toSource := range to.Sources {
+		fromSource, ok := kept[toSource.URL]
+		if !ok {
+			continue
+		}
+
+		c := SourceChangeDTO{
+			URL:       toSource.URL,
+			Type:      change(fromSource.Type, toSource.Type),
+			Publisher: change(fromSource.Publisher, toSource.Publisher),
+			Quote:     change(fromSource.Quote, toSource.Quote),
+		}
+		if !equalPtr(fromSource.PublishedAt, toSource.PublishedAt, time.Time.Equal) {
+			c.PublishedAt = &ChangeDTO[*time.Time]{From: fromSource.PublishedAt, To: toSource.PublishedAt}
+		}
+		if !equalPtr(fromSource.TimestampSeconds, toSource.TimestampSeconds, func(a, b int) bool { return a == b }) {
+			c.TimestampSeconds = &ChangeDTO[*int]{From: fromSource.TimestampSeconds, To: toSource.TimestampSeconds}
+		}
+
+		if c.Type != nil || c.Publisher != nil || c.PublishedAt != nil || c.TimestampSeconds != nil || c.Quote != nil {
+			changes = append(changes, c)
+		}
+	}
+
+	return changes
+}
+
+// change is nil when the value did not change
+func change[T comparable](from, to T) *ChangeDTO[T] {
+	if from == to {
+		return nil
+	}
+	return &ChangeDTO[T]{From: from, To: to}
+}
+
+func equalPtr[T any](a, b *T, equal func(T, T) bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equal(*a, *b)
+}
+
+func politicians(article models.Article) []response_dto.Politician {
+	values := make([]response_dto.Politician, len(article.Politicians))
+	for i := range article.Politicians {
+		values[i] = response_dto.NewPolitician(*article.Politicians[i])
+	}
+	return values
+}
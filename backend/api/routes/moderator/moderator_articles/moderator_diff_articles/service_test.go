@@ -0,0 +1,85 @@
+package moderator_diff_articles
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+	"vdm/core/diff_utils"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	macron := &models.Politician{ID: uuid.New(), FirstName: "Emmanuel", LastName: "Macron"}
+	attal := &models.Politician{ID: uuid.New(), FirstName: "Gabriel", LastName: "Attal"}
+	eventDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	publishedAt := time.Date(2024, 3, 2, 8, 0, 0, 0, time.UTC)
+	samePublishedAt := publishedAt.In(time.FixedZone("CET", 3600))
+	timestamp, otherTimestamp := 42, 45
+
+	from := models.Article{
+		Title:     "Le déficit est maîtrisé",
+		Body:      "Le ministre affirme que le déficit est maîtrisé.",
+		Category:  models.ArticleCategoryFalsehood,
+		EventDate: eventDate,
+		Tags:      []*models.ArticleTag{{Tag: "Budget"}, {Tag: "Déficit"}},
+		Sources: []*models.ArticleSource{
+			{URL: "https://example.com/a", Type: models.ArticleSourceTypeVideo, Publisher: "France 2", TimestampSeconds: &timestamp, Quote: "Le déficit est maîtrisé"},
+			{URL: "https://example.com/c", Type: models.ArticleSourceTypePressArticle, PublishedAt: &publishedAt},
+		},
+		Politicians: []*models.Politician{macron},
+	}
+	to := models.Article{
+		Title:     "Le déficit est maîtrisé",
+		Body:      "Le ministre affirme que le déficit public est maîtrisé.",
+		Category:  models.ArticleCategoryLie,
+		EventDate: eventDate,
+		Tags:      []*models.ArticleTag{{Tag: "Déficit"}},
+		Sources: []*models.ArticleSource{
+			{URL: "https://example.com/a", Type: models.ArticleSourceTypeVideo, Publisher: "France 2", TimestampSeconds: &otherTimestamp, Quote: "Le déficit public est maîtrisé"},
+			{URL: "https://example.com/b", Type: models.ArticleSourceTypeSocialPost},
+			// the same date in another time zone is no change
+			{URL: "https://example.com/c", Type: models.ArticleSourceTypePressArticle, PublishedAt: &samePublishedAt},
+		},
+		Politicians: []*models.Politician{macron, attal},
+	}
+
+	respDTO := diff(from, to)
+
+	assert.Equal(t, []diff_utils.Edit{{Operation: diff_utils.OperationEqual, Text: from.Title}}, respDTO.Title)
+	assert.Contains(t, respDTO.Body, diff_utils.Edit{Operation: diff_utils.OperationInsert, Text: "public "})
+
+	if assert.NotNil(t, respDTO.Category) {
+		assert.Equal(t, models.ArticleCategoryFalsehood, respDTO.Category.From)
+		assert.Equal(t, models.ArticleCategoryLie, respDTO.Category.To)
+	}
+	assert.Nil(t, respDTO.EventDate)
+
+	assert.Empty(t, respDTO.Tags.Added)
+	assert.Equal(t, []string{"Budget"}, respDTO.Tags.Removed)
+	assert.Equal(t, []string{"https://example.com/b"}, respDTO.Sources.Added)
+	assert.Empty(t, respDTO.Sources.Removed)
+	assert.Equal(t, []SourceChangeDTO{{
+		URL:              "https://example.com/a",
+		TimestampSeconds: &ChangeDTO[*int]{From: &timestamp, To: &otherTimestamp},
+		Quote:            &ChangeDTO[string]{From: "Le déficit est maîtrisé", To: "Le déficit public est maîtrisé"},
+	}}, respDTO.Sources.Changed)
+	if assert.Equal(t, 1, len(respDTO.Politicians.Added)) {
+		assert.Equal(t, attal.ID, respDTO.Politicians.Added[0].ID)
+	}
+}
+
+func TestDiff_UnchangedSetsEncodeToEmptyArrays(t *testing.T) {
+	article := models.Article{Title: "Titre", Body: "Corps"}
+
+	raw, err := json.Marshal(diff(article, article))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, string(raw), `"tags":{"added":[],"removed":[]}`)
+	assert.Contains(t, string(raw), `"sources":{"added":[],"removed":[],"changed":[]}`)
+	assert.Contains(t, string(raw), `"politicians":{"added":[],"removed":[]}`)
+}
@@ -22,10 +22,10 @@ func (r *repository) findArticlesByReference(reference uuid.UUID) ([]models.Arti
 		Order("created_at DESC").
 		Preload("Sources").
 		Preload("Tags").
-		Preload("Review", func(db *gorm.DB) *gorm.DB {
+		Preload("Reviews", func(db *gorm.DB) *gorm.DB {
 			return db.Preload("Moderator", func(db *gorm.DB) *gorm.DB {
 				return db.Select("id", "tag")
-			}).Select("moderator_id", "article_id", "notes", "decision")
+			}).Select("moderator_id", "article_id", "notes", "decision", "created_at")
 		}).
 		Preload("Moderator", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "tag")
@@ -33,6 +33,7 @@ func (r *repository) findArticlesByReference(reference uuid.UUID) ([]models.Arti
 		Preload("Politicians", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "first_name", "last_name")
 		}).
+		Preload("ArticlePoliticians").
 		Find(&articles).Error; err != nil {
 		return nil, err
 	}
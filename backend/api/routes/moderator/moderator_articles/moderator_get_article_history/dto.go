@@ -0,0 +1,32 @@
+package moderator_get_article_history
+
+import (
+	"time"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+)
+
+type EventDTO struct {
+	ArticleID *uuid.UUID           `json:"articleId,omitempty"`
+	ActorTag  string               `json:"actorTag"`
+	From      models.ArticleStatus `json:"from,omitempty"`
+	To        models.ArticleStatus `json:"to"`
+	CreatedAt time.Time            `json:"createdAt"`
+}
+
+func newEventDTO(event models.ArticleStatusEvent) EventDTO {
+	dto := EventDTO{
+		ArticleID: event.ArticleID,
+		To:        event.ToStatus,
+		CreatedAt: event.CreatedAt,
+	}
+	if event.Actor != nil {
+		dto.ActorTag = event.Actor.Tag
+	}
+	if event.FromStatus != nil {
+		dto.From = *event.FromStatus
+	}
+
+	return dto
+}
@@ -0,0 +1,39 @@
+package moderator_get_article_history
+
+import (
+	"fmt"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	getArticleHistoryForModerator(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) getArticleHistoryForModerator(c *fiber.Ctx) error {
+	articleID, err := uuid.Parse(c.Params(local_keys.ArticleID))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid article id"}
+	}
+
+	events, err := h.repo.getStatusEvents(articleID)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("article with id %s not found", articleID)}
+	}
+
+	resDTO := make([]EventDTO, len(events))
+	for i, event := range events {
+		resDTO[i] = newEventDTO(event)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resDTO)
+}
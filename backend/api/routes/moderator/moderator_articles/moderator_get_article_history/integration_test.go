@@ -0,0 +1,150 @@
+package moderator_get_article_history
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/article_lifecycle"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	redactor  *models.User
+	moderator *models.User
+	archived  *models.Article
+	article   *models.Article
+	draft     *models.Article
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.redactor = &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(data.redactor).Error; err != nil {
+		return
+	}
+
+	data.moderator = &models.User{Email: "moderator@test.com", Tag: "moderator0123", Password: "x"}
+	if err = connector.GormDB().Create(data.moderator).Error; err != nil {
+		return
+	}
+
+	reference := uuid.New()
+	data.archived = &models.Article{
+		RedactorID: data.redactor.ID,
+		Title:      "Change requested",
+		Status:     models.ArticleStatusArchived,
+		Reference:  reference,
+		Minor:      1,
+	}
+	data.article = &models.Article{
+		RedactorID: data.redactor.ID,
+		Title:      "Resubmitted",
+		Status:     models.ArticleStatusUnderReview,
+		Reference:  reference,
+		Minor:      2,
+	}
+	data.draft = &models.Article{
+		RedactorID: data.redactor.ID,
+		Title:      "Draft",
+		Status:     models.ArticleStatusDraft,
+		Reference:  uuid.New(),
+	}
+	if err = connector.GormDB().Create([]*models.Article{data.archived, data.article, data.draft}).Error; err != nil {
+		return
+	}
+
+	start := time.Now().Add(-time.Hour)
+	events := []*models.ArticleStatusEvent{
+		article_lifecycle.NewEvent(*data.archived, data.redactor.ID, article_lifecycle.Created, models.ArticleStatusUnderReview),
+		article_lifecycle.NewEvent(*data.archived, data.moderator.ID, models.ArticleStatusUnderReview, models.ArticleStatusChangeRequested),
+		article_lifecycle.NewEvent(*data.archived, data.redactor.ID, models.ArticleStatusChangeRequested, models.ArticleStatusArchived),
+		article_lifecycle.NewEvent(*data.article, data.redactor.ID, models.ArticleStatusChangeRequested, models.ArticleStatusUnderReview),
+		article_lifecycle.NewEvent(*data.draft, data.redactor.ID, article_lifecycle.Created, models.ArticleStatusDraft),
+	}
+	for i, event := range events {
+		event.CreatedAt = start.Add(time.Duration(i) * time.Minute)
+	}
+
+	err = connector.GormDB().Create(events).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	// the history of the whole reference is returned from any of its versions
+	req := httptest.NewRequest(Method, "/"+data.archived.ID.String()+"/history", nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resDTO []EventDTO
+	if err = json.Unmarshal(resBody, &resDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, resDTO, 4) {
+		assert.Equal(t, models.ArticleStatus(""), resDTO[0].From)
+		assert.Equal(t, models.ArticleStatusUnderReview, resDTO[0].To)
+		assert.Equal(t, data.redactor.Tag, resDTO[0].ActorTag)
+
+		assert.Equal(t, models.ArticleStatusChangeRequested, resDTO[1].To)
+		assert.Equal(t, data.moderator.Tag, resDTO[1].ActorTag)
+
+		assert.Equal(t, data.article.ID, *resDTO[3].ArticleID)
+		assert.Equal(t, models.ArticleStatusChangeRequested, resDTO[3].From)
+	}
+}
+
+func TestIntegration_ErrNotFound(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	// drafts are hidden from moderators
+	for _, articleID := range []uuid.UUID{data.draft.ID, uuid.New()} {
+		req := httptest.NewRequest(Method, "/"+articleID.String()+"/history", nil)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	}
+}
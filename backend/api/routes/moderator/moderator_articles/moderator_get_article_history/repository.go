@@ -0,0 +1,38 @@
+package moderator_get_article_history
+
+import (
+	"fmt"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	getStatusEvents(articleID uuid.UUID) ([]models.ArticleStatusEvent, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// getStatusEvents returns the transitions of every version sharing the reference of the given article, oldest first.
+// Drafts are ignored, which moderators can't see.
+func (r *repository) getStatusEvents(articleID uuid.UUID) ([]models.ArticleStatusEvent, error) {
+	reference := r.db.Model(&models.Article{}).
+		Select("reference").
+		Where("id = ? AND status <> ?", articleID, models.ArticleStatusDraft)
+
+	var events []models.ArticleStatusEvent
+
+	if err := r.db.Where("reference = (?)", reference).
+		Order("created_at").
+		Preload("Actor", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "tag")
+		}).
+		Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to find status events of Article{ID=%s}: %v", articleID, err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,62 @@
+package moderator_get_article_sources
+
+import (
+	"time"
+	"vdm/core/models"
+)
+
+type SourceStatus string
+
+const (
+	SourceStatusPending SourceStatus = "PENDING" // not checked yet
+	SourceStatusOK      SourceStatus = "OK"
+	SourceStatusBroken  SourceStatus = "BROKEN"
+)
+
+type SourceDTO struct {
+	URL             string       `json:"url"`
+	Status          SourceStatus `json:"status"`
+	CheckStatusCode int          `json:"checkStatusCode,omitempty"`
+	CheckError      string       `json:"checkError,omitempty"`
+	CheckedAt       *time.Time   `json:"checkedAt,omitempty"`
+	Capture         *CaptureDTO  `json:"capture,omitempty"`
+}
+
+type CaptureDTO struct {
+	StatusCode  int       `json:"statusCode"`
+	FinalURL    string    `json:"finalUrl"`
+	Title       string    `json:"title"`
+	ContentHash string    `json:"contentHash"`
+	Text        string    `json:"text"`
+	CapturedAt  time.Time `json:"capturedAt"`
+}
+
+func newSourceDTO(snapshot models.SourceSnapshot) SourceDTO {
+	dto := SourceDTO{
+		URL:             snapshot.URL,
+		Status:          SourceStatusOK,
+		CheckStatusCode: snapshot.CheckStatusCode,
+		CheckError:      snapshot.CheckError,
+		CheckedAt:       snapshot.CheckedAt,
+	}
+
+	switch {
+	case snapshot.CheckedAt == nil:
+		dto.Status = SourceStatusPending
+	case snapshot.Broken:
+		dto.Status = SourceStatusBroken
+	}
+
+	if snapshot.CapturedAt != nil {
+		dto.Capture = &CaptureDTO{
+			StatusCode:  snapshot.StatusCode,
+			FinalURL:    snapshot.FinalURL,
+			Title:       snapshot.Title,
+			ContentHash: snapshot.ContentHash,
+			Text:        snapshot.Text,
+			CapturedAt:  *snapshot.CapturedAt,
+		}
+	}
+
+	return dto
+}
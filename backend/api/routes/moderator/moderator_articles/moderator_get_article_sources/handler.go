@@ -0,0 +1,44 @@
+package moderator_get_article_sources
+
+import (
+	"fmt"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	getArticleSourcesForModerator(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) getArticleSourcesForModerator(c *fiber.Ctx) error {
+	articleID, err := uuid.Parse(c.Params(local_keys.ArticleID))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid article id"}
+	}
+
+	exists, err := h.repo.submittedArticleExists(articleID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("article with id %s not found", articleID)}
+	}
+
+	snapshots, err := h.repo.getSourceSnapshots(articleID)
+	if err != nil {
+		return err
+	}
+
+	resDTO := make([]SourceDTO, len(snapshots))
+	for i := range snapshots {
+		resDTO[i] = newSourceDTO(snapshots[i])
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resDTO)
+}
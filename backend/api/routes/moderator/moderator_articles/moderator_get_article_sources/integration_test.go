@@ -0,0 +1,132 @@
+package moderator_get_article_sources
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	article *models.Article
+	draft   *models.Article
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	data.article = &models.Article{
+		RedactorID: redactor.ID,
+		Title:      "Under review",
+		Status:     models.ArticleStatusUnderReview,
+		Reference:  uuid.New(),
+		Minor:      1,
+		Sources: []*models.ArticleSource{
+			{URL: "https://a.example/broken"},
+			{URL: "https://b.example/live"},
+			{URL: "https://c.example/new"},
+		},
+	}
+	if err = connector.GormDB().Create(data.article).Error; err != nil {
+		return
+	}
+
+	data.draft = &models.Article{
+		RedactorID: redactor.ID,
+		Title:      "Draft",
+		Status:     models.ArticleStatusDraft,
+		Reference:  uuid.New(),
+	}
+	if err = connector.GormDB().Create(data.draft).Error; err != nil {
+		return
+	}
+
+	now := time.Now()
+	err = connector.GormDB().Create([]*models.SourceSnapshot{
+		{URL: "https://a.example/broken", CheckStatusCode: 404, Broken: true, CheckedAt: &now},
+		{URL: "https://b.example/live", StatusCode: 200, FinalURL: "https://b.example/live", Title: "Live",
+			ContentHash: "hash", Text: "content", CapturedAt: &now, CheckStatusCode: 200, CheckedAt: &now},
+	}).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/"+data.article.ID.String()+"/sources", nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resDTO []SourceDTO
+	if err = json.Unmarshal(resBody, &resDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, resDTO, 3) {
+		assert.Equal(t, SourceStatusBroken, resDTO[0].Status)
+		assert.Equal(t, 404, resDTO[0].CheckStatusCode)
+		assert.Nil(t, resDTO[0].Capture)
+
+		assert.Equal(t, SourceStatusOK, resDTO[1].Status)
+		if assert.NotNil(t, resDTO[1].Capture) {
+			assert.Equal(t, "Live", resDTO[1].Capture.Title)
+		}
+
+		assert.Equal(t, SourceStatusPending, resDTO[2].Status)
+	}
+}
+
+func TestIntegration_ErrNotFound(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/"+data.draft.ID.String()+"/sources", nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
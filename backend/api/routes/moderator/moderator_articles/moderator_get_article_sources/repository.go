@@ -0,0 +1,58 @@
+package moderator_get_article_sources
+
+import (
+	"fmt"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	submittedArticleExists(articleID uuid.UUID) (bool, error)
+	getSourceSnapshots(articleID uuid.UUID) ([]models.SourceSnapshot, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// submittedArticleExists ignores drafts, which moderators can't see.
+func (r *repository) submittedArticleExists(articleID uuid.UUID) (bool, error) {
+	var count int64
+
+	if err := r.db.Model(&models.Article{}).
+		Where("id = ? AND status <> ?", articleID, models.ArticleStatusDraft).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to count Article{ID=%s}: %v", articleID, err)
+	}
+
+	return count > 0, nil
+}
+
+// getSourceSnapshots returns a snapshot for each source of the article.
+// A source that has not been archived yet comes back with an empty snapshot.
+func (r *repository) getSourceSnapshots(articleID uuid.UUID) ([]models.SourceSnapshot, error) {
+	var snapshots []models.SourceSnapshot
+
+	if err := r.db.Table("article_sources").
+		Select(`article_sources.url,
+			COALESCE(source_snapshots.status_code, 0) AS status_code,
+			COALESCE(source_snapshots.final_url, '') AS final_url,
+			COALESCE(source_snapshots.title, '') AS title,
+			COALESCE(source_snapshots.content_hash, '') AS content_hash,
+			COALESCE(source_snapshots.text, '') AS text,
+			source_snapshots.captured_at,
+			COALESCE(source_snapshots.check_status_code, 0) AS check_status_code,
+			COALESCE(source_snapshots.check_error, '') AS check_error,
+			COALESCE(source_snapshots.broken, FALSE) AS broken,
+			source_snapshots.checked_at`).
+		Joins("LEFT JOIN source_snapshots ON source_snapshots.url = article_sources.url").
+		Where("article_sources.article_id = ?", articleID).
+		Order("article_sources.url").
+		Scan(&snapshots).Error; err != nil {
+		return nil, fmt.Errorf("failed to find source snapshots of Article{ID=%s}: %v", articleID, err)
+	}
+
+	return snapshots, nil
+}
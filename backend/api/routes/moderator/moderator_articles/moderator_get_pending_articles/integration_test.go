@@ -78,6 +78,15 @@ func loadTestData(c context.Context, t *testing.T) (container testcontainers.Con
 			Status:      models.ArticleStatusUnderReview,
 			Reference:   ref,
 		},
+		{ // excluded: already approved by the moderator, waiting for another approval
+			RedactorID:  data.redactor.ID,
+			Title:       "Approved",
+			Politicians: []*models.Politician{data.politicians[0]},
+			Tags:        []*models.ArticleTag{{Tag: "Macron"}},
+			Reviews:     []*models.ArticleReview{{ModeratorID: data.moderator.ID, Decision: models.ArticleStatusPublished}},
+			Status:      models.ArticleStatusUnderReview,
+			Reference:   ref,
+		},
 		{ // excluded: wrong status
 			RedactorID:  data.redactor.ID,
 			Title:       "Draft",
@@ -19,6 +19,8 @@ func (r *repository) getPendingArticles(moderatorID uuid.UUID) ([]models.Article
 	var articles []models.Article
 
 	if err := r.db.Where("moderator_id IS NULL AND redactor_id <> ? AND status = ?", moderatorID, models.ArticleStatusUnderReview).
+		// a moderator reviews each version at most once
+		Where("NOT EXISTS (SELECT 1 FROM article_reviews WHERE article_id = articles.id AND moderator_id = ?)", moderatorID).
 		Order("created_at DESC").
 		Select("id", "redactor_id", "reference", "title", "event_date", "updated_at", "category").
 		Preload("Redactor", func(db *gorm.DB) *gorm.DB {
@@ -0,0 +1,45 @@
+package moderator_get_review_comments
+
+import (
+	"fmt"
+	"vdm/core/dto/response_dto"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	getReviewCommentsForModerator(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) getReviewCommentsForModerator(c *fiber.Ctx) error {
+	articleID, err := uuid.Parse(c.Params(local_keys.ArticleID))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid article id"}
+	}
+
+	exists, err := h.repo.submittedArticleExists(articleID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("article with id %s not found", articleID)}
+	}
+
+	threads, err := h.repo.getReviewThreads(articleID)
+	if err != nil {
+		return err
+	}
+
+	resDTO := make([]response_dto.ReviewComment, len(threads))
+	for i := range threads {
+		resDTO[i] = response_dto.NewReviewComment(threads[i])
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resDTO)
+}
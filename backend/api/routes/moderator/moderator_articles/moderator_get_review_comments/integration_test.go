@@ -0,0 +1,155 @@
+package moderator_get_review_comments
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"vdm/core/dependencies/database"
+	"vdm/core/dto/response_dto"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	redactor  *models.User
+	moderator *models.User
+	article   *models.Article
+	draft     *models.Article
+	root      *models.ReviewComment
+	reply     *models.ReviewComment
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.redactor = &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(data.redactor).Error; err != nil {
+		return
+	}
+
+	data.moderator = &models.User{Email: "moderator@test.com", Tag: "moderator0123", Password: "x"}
+	if err = connector.GormDB().Create(data.moderator).Error; err != nil {
+		return
+	}
+
+	data.article = &models.Article{
+		RedactorID:  data.redactor.ID,
+		ModeratorID: &data.moderator.ID,
+		Title:       "Under review",
+		Body:        "Le chômage a baissé de 10% en un an.",
+		Status:      models.ArticleStatusUnderReview,
+		Reference:   uuid.New(),
+		Minor:       1,
+	}
+	if err = connector.GormDB().Create(data.article).Error; err != nil {
+		return
+	}
+
+	data.draft = &models.Article{
+		RedactorID: data.redactor.ID,
+		Title:      "Draft",
+		Status:     models.ArticleStatusDraft,
+		Reference:  uuid.New(),
+	}
+	if err = connector.GormDB().Create(data.draft).Error; err != nil {
+		return
+	}
+
+	anchorStart, anchorEnd := 23, 26
+	data.root = &models.ReviewComment{
+		ArticleID:   data.article.ID,
+		AuthorID:    data.moderator.ID,
+		AnchorStart: &anchorStart,
+		AnchorEnd:   &anchorEnd,
+		Quote:       "10%",
+		Text:        "Quelle est la source de ce chiffre ?",
+	}
+	if err = connector.GormDB().Create(data.root).Error; err != nil {
+		return
+	}
+
+	data.reply = &models.ReviewComment{
+		ArticleID: data.article.ID,
+		ParentID:  &data.root.ID,
+		AuthorID:  data.redactor.ID,
+		Text:      "Le rapport de l'INSEE, ajouté aux sources.",
+	}
+	err = connector.GormDB().Create(data.reply).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/"+data.article.ID.String()+"/comments", nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code 200, got %d", res.StatusCode)
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resDTO []response_dto.ReviewComment
+	if err = json.Unmarshal(resBody, &resDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Equal(t, 1, len(resDTO)) {
+		assert.Equal(t, data.root.ID, resDTO[0].ID)
+		assert.Equal(t, data.moderator.Tag, resDTO[0].AuthorTag)
+		assert.Equal(t, &response_dto.ReviewCommentAnchor{Start: 23, End: 26}, resDTO[0].Anchor)
+		assert.Equal(t, "10%", resDTO[0].Quote)
+
+		if assert.Equal(t, 1, len(resDTO[0].Replies)) {
+			assert.Equal(t, data.reply.ID, resDTO[0].Replies[0].ID)
+			assert.Equal(t, data.redactor.Tag, resDTO[0].Replies[0].AuthorTag)
+		}
+	}
+}
+
+func TestIntegration_ErrNotFound(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/"+data.draft.ID.String()+"/comments", nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
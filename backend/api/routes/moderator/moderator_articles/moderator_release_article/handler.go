@@ -0,0 +1,40 @@
+package moderator_release_article
+
+import (
+	"fmt"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	releaseArticleForModerator(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) releaseArticleForModerator(c *fiber.Ctx) error {
+	authedUser, ok := c.Locals("authedUser").(locals.AuthedUser)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "can't locals authed user"}
+	}
+
+	articleID, err := uuid.Parse(c.Params(local_keys.ArticleID))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid article id"}
+	}
+
+	released, err := h.repo.releaseArticle(authedUser.ID, articleID)
+	if err != nil {
+		return fmt.Errorf("failed to release article: %v", err)
+	}
+	if !released {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("no article with id %s under review claimed by user %s", articleID, authedUser.ID)}
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,112 @@
+package moderator_release_article
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	redactor  *models.User
+	moderator *models.User
+	article   *models.Article
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.redactor = &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(data.redactor).Error; err != nil {
+		return
+	}
+
+	data.moderator = &models.User{Email: "moderator@test.com", Tag: "moderator0123", Password: "x"}
+	if err = connector.GormDB().Create(data.moderator).Error; err != nil {
+		return
+	}
+
+	claimedAt := time.Now()
+	data.article = &models.Article{
+		RedactorID:  data.redactor.ID,
+		ModeratorID: &data.moderator.ID,
+		ClaimedAt:   &claimedAt,
+		Title:       "Claimed",
+		Status:      models.ArticleStatusUnderReview,
+		Reference:   uuid.New(),
+		Minor:       1,
+	}
+	err = connector.GormDB().Create(data.article).Error
+	return
+}
+
+func newAppWithAuthedModerator(moderatorID uuid.UUID) *fiber.App {
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("authedUser", locals.AuthedUser{ID: moderatorID})
+		return c.Next()
+	})
+	return app
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := newAppWithAuthedModerator(data.moderator.ID)
+	Route(connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/"+data.article.ID.String()+"/claim", nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNoContent, res.StatusCode)
+
+	var released models.Article
+	if err := connector.GormDB().First(&released, "id = ?", data.article.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, released.ModeratorID)
+	assert.Nil(t, released.ClaimedAt)
+}
+
+func TestIntegration_ErrNotFound(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	// only the moderator holding the claim can release it
+	app := newAppWithAuthedModerator(uuid.New())
+	Route(connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/"+data.article.ID.String()+"/claim", nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
@@ -0,0 +1,25 @@
+package moderator_release_article
+
+import (
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	releaseArticle(moderatorID, articleID uuid.UUID) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// releaseArticle puts an article claimed by the moderator back in the pending queue.
+func (r *repository) releaseArticle(moderatorID, articleID uuid.UUID) (bool, error) {
+	res := r.db.Model(&models.Article{}).
+		Where("id = ? AND moderator_id = ? AND status = ?", articleID, moderatorID, models.ArticleStatusUnderReview).
+		Updates(map[string]any{"moderator_id": nil, "claimed_at": nil})
+
+	return res.RowsAffected > 0, res.Error
+}
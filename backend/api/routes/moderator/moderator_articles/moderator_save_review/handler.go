@@ -1,8 +1,11 @@
 package moderator_save_review
 
 import (
+	"vdm/core/article_lifecycle"
+	"vdm/core/http_cache"
 	"vdm/core/locals"
 	"vdm/core/locals/local_keys"
+	"vdm/core/logger"
 	"vdm/core/models"
 	"vdm/core/validation"
 
@@ -15,7 +18,9 @@ type Handler interface {
 }
 
 type handler struct {
-	repo Repository
+	repo   Repository
+	quorum int
+	cache  http_cache.Invalidator
 }
 
 func (h *handler) saveArticleReviewForModerator(c *fiber.Ctx) error {
@@ -37,7 +42,7 @@ func (h *handler) saveArticleReviewForModerator(c *fiber.Ctx) error {
 		return err
 	}
 
-	if !reqDTO.Decision.Valid() {
+	if !article_lifecycle.Allowed(models.RoleModerator, models.ArticleStatusUnderReview, reqDTO.Decision) {
 		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid decision"}
 	}
 
@@ -56,9 +61,17 @@ func (h *handler) saveArticleReviewForModerator(c *fiber.Ctx) error {
 		review.Notes = reqDTO.Notes
 	}
 
-	if err := h.repo.createReviewAndUpdateArticle(review); err != nil {
+	published, err := h.repo.createReviewAndUpdateArticle(review, h.quorum)
+	if err != nil {
 		return err
 	}
 
+	if published {
+		// the publication is committed: a stale cache is caught up by the cache watcher
+		if err := h.cache.Invalidate(c.UserContext(), http_cache.ScopeArticles); err != nil {
+			logger.Error("failed to invalidate cached articles", logger.Err(err))
+		}
+	}
+
 	return c.SendStatus(fiber.StatusNoContent)
 }
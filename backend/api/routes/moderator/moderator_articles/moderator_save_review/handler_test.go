@@ -2,21 +2,25 @@ package moderator_save_review
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"vdm/core/fiberx"
+	"vdm/core/http_cache"
 	"vdm/core/locals"
 	"vdm/core/models"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 )
 
 type nullRepo struct{}
 
-func (*nullRepo) createReviewAndUpdateArticle(review *models.ArticleReview) error {
-	return nil
+func (*nullRepo) createReviewAndUpdateArticle(review *models.ArticleReview, quorum int) (bool, error) {
+	return false, nil
 }
 
 func newAppWithAuthedModeratorAndNullRepo() *fiber.App {
@@ -94,3 +98,70 @@ func TestHandler_ErrBadRequest_InvalidNotes(t *testing.T) {
 		t.Fatalf("expected 400, got %d", res.StatusCode)
 	}
 }
+
+func TestHandler_ErrBadRequest_DisallowedDecision(t *testing.T) {
+	app := newAppWithAuthedModeratorAndNullRepo()
+	for _, decision := range []models.ArticleStatus{models.ArticleStatusDraft, models.ArticleStatusArchived, models.ArticleStatusUnderReview} {
+		payload := map[string]any{"decision": string(decision), "notes": strings.Repeat("n", 40)}
+		b, _ := json.Marshal(payload)
+		req := httptest.NewRequest(Method, "/"+uuid.New().String()+"/review", bytes.NewReader(b))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != fiber.StatusBadRequest {
+			t.Fatalf("expected 400 for decision %s, got %d", decision, res.StatusCode)
+		}
+	}
+}
+
+type publishingRepo struct {
+	published bool
+}
+
+func (r *publishingRepo) createReviewAndUpdateArticle(review *models.ArticleReview, quorum int) (bool, error) {
+	return r.published, nil
+}
+
+type recordingInvalidator struct {
+	scopes []http_cache.Scope
+}
+
+func (i *recordingInvalidator) Invalidate(ctx context.Context, scopes ...http_cache.Scope) error {
+	i.scopes = append(i.scopes, scopes...)
+	return nil
+}
+
+func TestHandler_InvalidatesCacheOnPublication(t *testing.T) {
+	for _, published := range []bool{false, true} {
+		cache := &recordingInvalidator{}
+
+		app := fiberx.NewApp()
+		app.Use(func(c *fiber.Ctx) error {
+			c.Locals("authedUser", locals.AuthedUser{ID: uuid.New()})
+			return c.Next()
+		})
+		h := &handler{repo: &publishingRepo{published}, quorum: 1, cache: cache}
+		app.Add(Method, Path, h.saveArticleReviewForModerator)
+
+		b, _ := json.Marshal(map[string]any{"decision": string(models.ArticleStatusPublished)})
+		req := httptest.NewRequest(Method, "/"+uuid.New().String()+"/review", bytes.NewReader(b))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != fiber.StatusNoContent {
+			t.Fatalf("expected 204, got %d", res.StatusCode)
+		}
+
+		if published {
+			assert.Equal(t, []http_cache.Scope{http_cache.ScopeArticles}, cache.scopes)
+		} else {
+			assert.Empty(t, cache.scopes)
+		}
+	}
+}
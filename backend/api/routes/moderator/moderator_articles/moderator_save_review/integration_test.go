@@ -7,8 +7,10 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 	"vdm/core/dependencies/database"
 	"vdm/core/fiberx"
+	"vdm/core/http_cache"
 	"vdm/core/locals"
 	"vdm/core/models"
 	"vdm/test_utils"
@@ -73,7 +75,7 @@ func TestIntegration_Success_DecisionPublish(t *testing.T) {
 	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
 
 	app := newAppWithAuthedModerator(data.moderator.ID)
-	Route(connector.GormDB()).Register(app)
+	Route(connector.GormDB(), 1, http_cache.New(http_cache.NewMemoryStore(100), time.Hour, 0)).Register(app)
 
 	payload := map[string]any{"decision": string(models.ArticleStatusPublished)}
 	b, _ := json.Marshal(payload)
@@ -92,6 +94,7 @@ func TestIntegration_Success_DecisionPublish(t *testing.T) {
 	}
 	assert.Equal(t, models.ArticleStatusPublished, updated.Status)
 	assert.Equal(t, int16(1), updated.Major)
+	assert.NotNil(t, updated.PublishedAt)
 	assert.Equal(t, int16(0), updated.Minor)
 
 	var reviewsCount int64
@@ -99,6 +102,14 @@ func TestIntegration_Success_DecisionPublish(t *testing.T) {
 		t.Fatal(err)
 	}
 	assert.Equal(t, int64(1), reviewsCount)
+
+	var event models.ArticleStatusEvent
+	if err := connector.GormDB().First(&event, "article_id = ?", data.article.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, data.moderator.ID, event.ActorID)
+	assert.Equal(t, models.ArticleStatusUnderReview, *event.FromStatus)
+	assert.Equal(t, models.ArticleStatusPublished, event.ToStatus)
 }
 
 func TestIntegration_Success_OtherDecision(t *testing.T) {
@@ -107,7 +118,7 @@ func TestIntegration_Success_OtherDecision(t *testing.T) {
 	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
 
 	app := newAppWithAuthedModerator(data.moderator.ID)
-	Route(connector.GormDB()).Register(app)
+	Route(connector.GormDB(), 1, http_cache.New(http_cache.NewMemoryStore(100), time.Hour, 0)).Register(app)
 
 	notes := strings.Repeat("n", 40)
 	payload := map[string]any{"decision": string(models.ArticleStatusChangeRequested), "notes": notes}
@@ -134,3 +145,127 @@ func TestIntegration_Success_OtherDecision(t *testing.T) {
 	}
 	assert.Equal(t, int64(1), reviewsCount)
 }
+
+func TestIntegration_Success_DecisionPublish_ArchivesPublishedVersion(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	published := &models.Article{
+		RedactorID: data.redactor.ID,
+		Title:      "Published",
+		Status:     models.ArticleStatusPublished,
+		Reference:  data.article.Reference,
+		Major:      1,
+		Minor:      0,
+	}
+	if err := connector.GormDB().Create(published).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := connector.GormDB().Model(data.article).Update("major", 1).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app := newAppWithAuthedModerator(data.moderator.ID)
+	Route(connector.GormDB(), 1, http_cache.New(http_cache.NewMemoryStore(100), time.Hour, 0)).Register(app)
+
+	payload := map[string]any{"decision": string(models.ArticleStatusPublished)}
+	b, _ := json.Marshal(payload)
+	req := httptest.NewRequest(Method, "/"+data.article.ID.String()+"/review", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	assert.Equal(t, fiber.StatusNoContent, res.StatusCode)
+
+	var updated models.Article
+	if err := connector.GormDB().First(&updated, "id = ?", data.article.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, models.ArticleStatusPublished, updated.Status)
+	assert.Equal(t, int16(2), updated.Major)
+	assert.Equal(t, int16(0), updated.Minor)
+
+	var archived models.Article
+	if err := connector.GormDB().First(&archived, "id = ?", published.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, models.ArticleStatusArchived, archived.Status)
+	assert.Equal(t, int16(1), archived.Major)
+
+	var archivedEvents int64
+	if err := connector.GormDB().Model(&models.ArticleStatusEvent{}).
+		Where("article_id = ? AND from_status = ? AND to_status = ?", published.ID, models.ArticleStatusPublished, models.ArticleStatusArchived).
+		Count(&archivedEvents).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(1), archivedEvents)
+}
+
+func TestIntegration_Success_DecisionPublish_Quorum(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	secondModerator := &models.User{Email: "moderator2@test.com", Tag: "moderator4567", Password: "x"}
+	if err := connector.GormDB().Create(secondModerator).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	payload := map[string]any{"decision": string(models.ArticleStatusPublished)}
+	b, _ := json.Marshal(payload)
+
+	// first approval: the quorum is not met, the article goes back to the pending queue
+	app := newAppWithAuthedModerator(data.moderator.ID)
+	Route(connector.GormDB(), 2, http_cache.New(http_cache.NewMemoryStore(100), time.Hour, 0)).Register(app)
+
+	req := httptest.NewRequest(Method, "/"+data.article.ID.String()+"/review", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	assert.Equal(t, fiber.StatusNoContent, res.StatusCode)
+
+	var updated models.Article
+	if err := connector.GormDB().First(&updated, "id = ?", data.article.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, models.ArticleStatusUnderReview, updated.Status)
+	assert.Nil(t, updated.ModeratorID)
+	assert.Equal(t, int16(0), updated.Major)
+
+	// second approval from another moderator who claimed the article: the article is published
+	if err := connector.GormDB().Model(&models.Article{}).
+		Where("id = ?", data.article.ID).
+		Update("moderator_id", secondModerator.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app = newAppWithAuthedModerator(secondModerator.ID)
+	Route(connector.GormDB(), 2, http_cache.New(http_cache.NewMemoryStore(100), time.Hour, 0)).Register(app)
+
+	req = httptest.NewRequest(Method, "/"+data.article.ID.String()+"/review", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	res, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	assert.Equal(t, fiber.StatusNoContent, res.StatusCode)
+
+	if err := connector.GormDB().First(&updated, "id = ?", data.article.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, models.ArticleStatusPublished, updated.Status)
+	assert.Equal(t, int16(1), updated.Major)
+
+	var reviewsCount int64
+	if err := connector.GormDB().Model(&models.ArticleReview{}).Where("article_id = ? AND decision = ?", data.article.ID, models.ArticleStatusPublished).Count(&reviewsCount).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(2), reviewsCount)
+}
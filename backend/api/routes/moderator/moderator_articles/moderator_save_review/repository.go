@@ -2,25 +2,32 @@ package moderator_save_review
 
 import (
 	"fmt"
+	"vdm/core/article_lifecycle"
 	"vdm/core/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
-	createReviewAndUpdateArticle(review *models.ArticleReview) error
+	createReviewAndUpdateArticle(review *models.ArticleReview, quorum int) (published bool, err error)
 }
 
 type repository struct {
 	db *gorm.DB
 }
 
-func (r *repository) createReviewAndUpdateArticle(review *models.ArticleReview) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+// createReviewAndUpdateArticle stores the moderator's decision.
+// An approval publishes the article only once quorum distinct moderators have approved it:
+// until then the claim is released so that another moderator can review the article.
+// Any other decision applies immediately. It reports whether the article was published.
+func (r *repository) createReviewAndUpdateArticle(review *models.ArticleReview, quorum int) (published bool, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
 		var article models.Article
 
 		if err := tx.Where("id = ? AND moderator_id = ? AND status = ?", review.ArticleID, review.ModeratorID, models.ArticleStatusUnderReview).
-			Select("id", "major").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "reference", "major").
 			First(&article).Error; err != nil {
 			return fmt.Errorf("failed to find Article{ID=%s ModeratorID=%s Status=%s}: %v",
 				review.ArticleID, review.ModeratorID, models.ArticleStatusUnderReview, err)
@@ -31,10 +38,44 @@ func (r *repository) createReviewAndUpdateArticle(review *models.ArticleReview)
 		}
 
 		updates := map[string]any{"status": review.Decision}
+		publish := review.Decision == models.ArticleStatusPublished
+
+		if publish {
+			var approvals int64
+			if err := tx.Model(&models.ArticleReview{}).
+				Where("article_id = ? AND decision = ?", article.ID, models.ArticleStatusPublished).
+				Count(&approvals).Error; err != nil {
+				return fmt.Errorf("failed to count approvals of Article{ID=%s}: %v", article.ID, err)
+			}
+
+			if approvals < int64(quorum) { // back to the pending queue, waiting for another approval
+				publish = false
+				updates = map[string]any{"moderator_id": nil, "claimed_at": nil}
+			}
+		}
+
+		var events []*models.ArticleStatusEvent
+		if _, ok := updates["status"]; ok {
+			events = append(events, article_lifecycle.NewEvent(article, review.ModeratorID, models.ArticleStatusUnderReview, review.Decision))
+		}
+
+		if publish {
+			// the previously published version stays live until now: archive it in the same transaction
+			var superseded []models.Article
+			if err := tx.Model(&superseded).
+				Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "reference"}}}).
+				Where("reference = ? AND status = ?", article.Reference, models.ArticleStatusPublished).
+				Update("status", models.ArticleStatusArchived).Error; err != nil {
+				return fmt.Errorf("failed to archive published version of Article{Reference=%s}: %v", article.Reference, err)
+			}
+			for _, version := range superseded {
+				events = append(events, article_lifecycle.NewEvent(version, review.ModeratorID,
+					models.ArticleStatusPublished, models.ArticleStatusArchived))
+			}
 
-		if review.Decision == models.ArticleStatusPublished {
 			updates["major"] = article.Major + 1 // increment major version each time an article is published
 			updates["minor"] = 0
+			updates["published_at"] = review.CreatedAt
 		}
 
 		if err := tx.Model(&models.Article{}).
@@ -44,6 +85,16 @@ func (r *repository) createReviewAndUpdateArticle(review *models.ArticleReview)
 				review.ArticleID, review.ModeratorID, err)
 		}
 
+		if len(events) > 0 {
+			if err := tx.Create(events).Error; err != nil {
+				return fmt.Errorf("failed to record status events of Article{ID=%s}: %v", article.ID, err)
+			}
+		}
+
+		published = publish
+
 		return nil
 	})
+
+	return published && err == nil, err
 }
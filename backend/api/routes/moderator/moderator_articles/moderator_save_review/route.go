@@ -2,6 +2,7 @@ package moderator_save_review
 
 import (
 	"vdm/core/fiberx"
+	"vdm/core/http_cache"
 	"vdm/core/locals/local_keys"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,8 +14,8 @@ const (
 	Method = fiber.MethodPost
 )
 
-func Route(db *gorm.DB) *fiberx.Route {
+func Route(db *gorm.DB, quorum int, cache http_cache.Invalidator) *fiberx.Route {
 	repo := &repository{db}
-	handler := &handler{repo}
+	handler := &handler{repo, quorum, cache}
 	return fiberx.NewRoute(Method, Path, handler.saveArticleReviewForModerator)
 }
@@ -0,0 +1,21 @@
+package moderator_save_review_comment
+
+import "github.com/google/uuid"
+
+type AnchorDTO struct {
+	Start int `json:"start" validate:"min=0"`
+	End   int `json:"end" validate:"gtfield=Start"`
+}
+
+// RequestDTO describes either a new thread, anchored to a range of the article body or to one of its sources,
+// or a reply to an existing thread.
+type RequestDTO struct {
+	ParentID  *uuid.UUID `json:"parentId,omitempty"`
+	Anchor    *AnchorDTO `json:"anchor,omitempty"`
+	SourceURL string     `json:"sourceUrl,omitempty"`
+	Text      string     `json:"text" validate:"required,max=2000"`
+}
+
+type ResponseDTO struct {
+	ID uuid.UUID `json:"id"`
+}
@@ -0,0 +1,139 @@
+package moderator_save_review_comment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	moderator *models.User
+	article   *models.Article
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	data.moderator = &models.User{Email: "moderator@test.com", Tag: "moderator0123", Password: "x"}
+	if err = connector.GormDB().Create(data.moderator).Error; err != nil {
+		return
+	}
+
+	data.article = &models.Article{
+		RedactorID:  redactor.ID,
+		ModeratorID: &data.moderator.ID,
+		Title:       "Under review",
+		Body:        "Le chômage a baissé de 10% en un an.",
+		Sources:     []*models.ArticleSource{{URL: "https://www.insee.fr"}},
+		Status:      models.ArticleStatusUnderReview,
+		Reference:   uuid.New(),
+		Minor:       1,
+	}
+	err = connector.GormDB().Create(data.article).Error
+	return
+}
+
+func newAppWithAuthedModerator(moderatorID uuid.UUID) *fiber.App {
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("authedUser", locals.AuthedUser{ID: moderatorID})
+		return c.Next()
+	})
+	return app
+}
+
+func saveComment(t *testing.T, app *fiber.App, articleID uuid.UUID, reqDTO RequestDTO) (int, ResponseDTO) {
+	b, _ := json.Marshal(reqDTO)
+	req := httptest.NewRequest(Method, "/"+articleID.String()+"/comments", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var resDTO ResponseDTO
+	if res.StatusCode == fiber.StatusCreated {
+		resBody, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = json.Unmarshal(resBody, &resDTO); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return res.StatusCode, resDTO
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := newAppWithAuthedModerator(data.moderator.ID)
+	Route(connector.GormDB()).Register(app)
+
+	status, root := saveComment(t, app, data.article.ID, RequestDTO{Anchor: &AnchorDTO{Start: 23, End: 26}, Text: "Quelle source ?"})
+	assert.Equal(t, fiber.StatusCreated, status)
+
+	status, _ = saveComment(t, app, data.article.ID, RequestDTO{SourceURL: "https://www.insee.fr", Text: "Lien mort ?"})
+	assert.Equal(t, fiber.StatusCreated, status)
+
+	status, reply := saveComment(t, app, data.article.ID, RequestDTO{ParentID: &root.ID, Text: "Précision"})
+	assert.Equal(t, fiber.StatusCreated, status)
+
+	var saved models.ReviewComment
+	if err := connector.GormDB().First(&saved, "id = ?", root.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "10%", saved.Quote)
+	assert.Equal(t, data.moderator.ID, saved.AuthorID)
+
+	if err := connector.GormDB().First(&saved, "id = ?", reply.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if assert.NotNil(t, saved.ParentID) {
+		assert.Equal(t, root.ID, *saved.ParentID)
+	}
+}
+
+func TestIntegration_ErrNotFound(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	// only the moderator who claimed the article can comment on it
+	app := newAppWithAuthedModerator(uuid.New())
+	Route(connector.GormDB()).Register(app)
+
+	status, _ := saveComment(t, app, data.article.ID, RequestDTO{Anchor: &AnchorDTO{Start: 23, End: 26}, Text: "Quelle source ?"})
+	assert.Equal(t, fiber.StatusNotFound, status)
+}
@@ -0,0 +1,62 @@
+package moderator_save_review_comment
+
+import (
+	"errors"
+	"fmt"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	findClaimedArticle(articleID, moderatorID uuid.UUID) (*models.Article, error)
+	findComment(commentID, articleID uuid.UUID) (*models.ReviewComment, error)
+	createComment(comment *models.ReviewComment) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) findClaimedArticle(articleID, moderatorID uuid.UUID) (*models.Article, error) {
+	var article models.Article
+
+	if err := r.db.Where("id = ? AND moderator_id = ? AND status = ?", articleID, moderatorID, models.ArticleStatusUnderReview).
+		Select("id", "body").
+		Preload("Sources").
+		First(&article).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to find Article{ID=%s ModeratorID=%s}: %v", articleID, moderatorID, err)
+	}
+
+	return &article, nil
+}
+
+func (r *repository) findComment(commentID, articleID uuid.UUID) (*models.ReviewComment, error) {
+	var comment models.ReviewComment
+
+	if err := r.db.Where("id = ? AND article_id = ?", commentID, articleID).
+		Select("id", "parent_id").
+		First(&comment).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to find ReviewComment{ID=%s}: %v", commentID, err)
+	}
+
+	return &comment, nil
+}
+
+func (r *repository) createComment(comment *models.ReviewComment) error {
+	if err := r.db.Create(comment).Error; err != nil {
+		return fmt.Errorf("failed to create review comment: %v", err)
+	}
+	return nil
+}
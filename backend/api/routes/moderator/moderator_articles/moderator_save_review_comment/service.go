@@ -0,0 +1,95 @@
+package moderator_save_review_comment
+
+import (
+	"fmt"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	saveReviewComment(moderatorID, articleID uuid.UUID, reqDTO RequestDTO) (uuid.UUID, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func (s *service) saveReviewComment(moderatorID, articleID uuid.UUID, reqDTO RequestDTO) (uuid.UUID, error) {
+	article, err := s.repo.findClaimedArticle(articleID, moderatorID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if article == nil {
+		return uuid.Nil, &fiber.Error{Code: fiber.StatusNotFound,
+			Message: fmt.Sprintf("no article with id %s under review claimed by user %s", articleID, moderatorID)}
+	}
+
+	comment := &models.ReviewComment{
+		ArticleID: article.ID,
+		AuthorID:  moderatorID,
+		Text:      reqDTO.Text,
+	}
+
+	if reqDTO.ParentID != nil {
+		if reqDTO.Anchor != nil || reqDTO.SourceURL != "" {
+			return uuid.Nil, &fiber.Error{Code: fiber.StatusBadRequest, Message: "a reply can't be anchored"}
+		}
+
+		parent, err := s.repo.findComment(*reqDTO.ParentID, article.ID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if parent == nil {
+			return uuid.Nil, &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("comment with id %s not found", *reqDTO.ParentID)}
+		}
+
+		comment.ParentID = rootID(*parent)
+	} else if err = anchor(comment, *article, reqDTO); err != nil {
+		return uuid.Nil, err
+	}
+
+	if err = s.repo.createComment(comment); err != nil {
+		return uuid.Nil, err
+	}
+
+	return comment.ID, nil
+}
+
+// rootID keeps threads flat: replying to a reply adds to the thread of its root comment.
+func rootID(parent models.ReviewComment) *uuid.UUID {
+	if parent.ParentID != nil {
+		return parent.ParentID
+	}
+	return &parent.ID
+}
+
+// anchor attaches a new thread to exactly one of a range of the article body or one of its sources.
+func anchor(comment *models.ReviewComment, article models.Article, reqDTO RequestDTO) error {
+	if (reqDTO.Anchor == nil) == (reqDTO.SourceURL == "") {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "a new comment must be anchored to either a range of the body or a source"}
+	}
+
+	if reqDTO.Anchor != nil {
+		body := []rune(article.Body)
+		if reqDTO.Anchor.End > len(body) {
+			return &fiber.Error{Code: fiber.StatusBadRequest,
+				Message: fmt.Sprintf("anchor [%d, %d) is out of the body bounds [0, %d)", reqDTO.Anchor.Start, reqDTO.Anchor.End, len(body))}
+		}
+
+		comment.AnchorStart = &reqDTO.Anchor.Start
+		comment.AnchorEnd = &reqDTO.Anchor.End
+		comment.Quote = string(body[reqDTO.Anchor.Start:reqDTO.Anchor.End]) // snapshot used to re-anchor the comment in later versions
+		return nil
+	}
+
+	for _, source := range article.Sources {
+		if source.URL == reqDTO.SourceURL {
+			comment.SourceURL = &source.URL
+			return nil
+		}
+	}
+
+	return &fiber.Error{Code: fiber.StatusBadRequest, Message: fmt.Sprintf("%s is not a source of the article", reqDTO.SourceURL)}
+}
@@ -0,0 +1,105 @@
+package moderator_save_review_comment
+
+import (
+	"testing"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubRepository struct {
+	article *models.Article
+	parent  *models.ReviewComment
+	created *models.ReviewComment
+}
+
+func (r *stubRepository) findClaimedArticle(articleID, moderatorID uuid.UUID) (*models.Article, error) {
+	return r.article, nil
+}
+
+func (r *stubRepository) findComment(commentID, articleID uuid.UUID) (*models.ReviewComment, error) {
+	return r.parent, nil
+}
+
+func (r *stubRepository) createComment(comment *models.ReviewComment) error {
+	comment.ID = uuid.New()
+	r.created = comment
+	return nil
+}
+
+func newStubRepository() *stubRepository {
+	return &stubRepository{article: &models.Article{
+		ID:      uuid.New(),
+		Body:    "Le chômage a baissé de 10% en un an.",
+		Sources: []*models.ArticleSource{{URL: "https://www.insee.fr"}},
+	}}
+}
+
+func assertFiberErrCode(t *testing.T, code int, err error) {
+	var fiberErr *fiber.Error
+	if assert.ErrorAs(t, err, &fiberErr) {
+		assert.Equal(t, code, fiberErr.Code)
+	}
+}
+
+func TestService_AnchorToBody(t *testing.T) {
+	repo := newStubRepository()
+	svc := &service{repo}
+
+	// offsets count characters, not bytes
+	_, err := svc.saveReviewComment(uuid.New(), repo.article.ID, RequestDTO{Anchor: &AnchorDTO{Start: 3, End: 10}, Text: "?"})
+	assert.NoError(t, err)
+	if assert.NotNil(t, repo.created) {
+		assert.Equal(t, "chômage", repo.created.Quote)
+		assert.Nil(t, repo.created.SourceURL)
+	}
+
+	_, err = svc.saveReviewComment(uuid.New(), repo.article.ID, RequestDTO{Anchor: &AnchorDTO{Start: 3, End: 100}, Text: "?"})
+	assertFiberErrCode(t, fiber.StatusBadRequest, err)
+}
+
+func TestService_AnchorToSource(t *testing.T) {
+	repo := newStubRepository()
+	svc := &service{repo}
+
+	_, err := svc.saveReviewComment(uuid.New(), repo.article.ID, RequestDTO{SourceURL: "https://www.insee.fr", Text: "?"})
+	assert.NoError(t, err)
+	if assert.NotNil(t, repo.created) && assert.NotNil(t, repo.created.SourceURL) {
+		assert.Equal(t, "https://www.insee.fr", *repo.created.SourceURL)
+	}
+
+	_, err = svc.saveReviewComment(uuid.New(), repo.article.ID, RequestDTO{SourceURL: "https://example.com", Text: "?"})
+	assertFiberErrCode(t, fiber.StatusBadRequest, err)
+
+	// new threads are anchored to exactly one target
+	_, err = svc.saveReviewComment(uuid.New(), repo.article.ID, RequestDTO{Text: "?"})
+	assertFiberErrCode(t, fiber.StatusBadRequest, err)
+
+	_, err = svc.saveReviewComment(uuid.New(), repo.article.ID, RequestDTO{
+		Anchor: &AnchorDTO{Start: 0, End: 2}, SourceURL: "https://www.insee.fr", Text: "?"})
+	assertFiberErrCode(t, fiber.StatusBadRequest, err)
+}
+
+func TestService_Reply(t *testing.T) {
+	repo := newStubRepository()
+	rootID := uuid.New()
+	repo.parent = &models.ReviewComment{ID: uuid.New(), ParentID: &rootID}
+	svc := &service{repo}
+
+	// replying to a reply adds to the root thread
+	_, err := svc.saveReviewComment(uuid.New(), repo.article.ID, RequestDTO{ParentID: &repo.parent.ID, Text: "!"})
+	assert.NoError(t, err)
+	if assert.NotNil(t, repo.created) && assert.NotNil(t, repo.created.ParentID) {
+		assert.Equal(t, rootID, *repo.created.ParentID)
+	}
+
+	_, err = svc.saveReviewComment(uuid.New(), repo.article.ID, RequestDTO{
+		ParentID: &repo.parent.ID, Anchor: &AnchorDTO{Start: 0, End: 2}, Text: "!"})
+	assertFiberErrCode(t, fiber.StatusBadRequest, err)
+
+	repo.parent = nil
+	_, err = svc.saveReviewComment(uuid.New(), repo.article.ID, RequestDTO{ParentID: &rootID, Text: "!"})
+	assertFiberErrCode(t, fiber.StatusNotFound, err)
+}
@@ -0,0 +1,47 @@
+package download_open_data
+
+import (
+	"archive/zip"
+	"bufio"
+	"fmt"
+	"time"
+	"vdm/core/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	downloadOpenData(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+// downloadOpenData streams the dataset in every format, and its manifest, as a zip archive
+func (h *handler) downloadOpenData(c *fiber.Ctx) error {
+	fileName := fmt.Sprintf("vigie-du-mensonge-%s.zip", time.Now().UTC().Format(time.DateOnly))
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		zw := zip.NewWriter(w)
+
+		if _, err := h.repo.export(zw.Create); err != nil {
+			// the status is already sent: leaving the archive without its central directory makes the failure detectable
+			logger.Error("failed to stream open data export", logger.Err(err))
+			_ = w.Flush()
+			return
+		}
+
+		if err := zw.Close(); err != nil {
+			logger.Error("failed to close open data archive", logger.Err(err))
+		}
+
+		_ = w.Flush()
+	})
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package download_open_data
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"vdm/core/fiberx"
+	"vdm/core/open_data"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubRepository struct {
+	err error
+}
+
+func (r *stubRepository) export(create func(name string) (io.Writer, error)) (*open_data.Manifest, error) {
+	w, err := create("articles.ndjson")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write([]byte("{}\n")); err != nil {
+		return nil, err
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	w, err = create(open_data.ManifestFileName)
+	if err != nil {
+		return nil, err
+	}
+	_, err = w.Write([]byte("{}"))
+
+	return &open_data.Manifest{}, err
+}
+
+func download(t *testing.T, repo Repository) (*bytes.Reader, string) {
+	app := fiberx.NewApp()
+	h := &handler{repo}
+	app.Add(Method, Path, h.downloadOpenData)
+
+	res, err := app.Test(httptest.NewRequest(Method, Path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/zip", res.Header.Get(fiber.HeaderContentType))
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return bytes.NewReader(body), res.Header.Get(fiber.HeaderContentDisposition)
+}
+
+func TestHandler_Success(t *testing.T) {
+	body, disposition := download(t, &stubRepository{})
+
+	assert.Contains(t, disposition, `attachment; filename="vigie-du-mensonge-`)
+
+	archive, err := zip.NewReader(body, body.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, archive.File, 2) {
+		assert.Equal(t, "articles.ndjson", archive.File[0].Name)
+		assert.Equal(t, open_data.ManifestFileName, archive.File[1].Name)
+	}
+}
+
+func TestHandler_ErrExport(t *testing.T) {
+	body, _ := download(t, &stubRepository{err: errors.New("connection lost")})
+
+	_, err := zip.NewReader(body, body.Size())
+	assert.Error(t, err)
+}
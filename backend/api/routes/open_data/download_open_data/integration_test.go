@@ -0,0 +1,101 @@
+package download_open_data
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/core/open_data"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	err = connector.GormDB().Create(&models.Article{
+		RedactorID: redactor.ID,
+		Title:      "Published",
+		Status:     models.ArticleStatusPublished,
+		Category:   models.ArticleCategoryLie,
+		Reference:  uuid.New(),
+		Major:      1,
+		EventDate:  time.Date(2024, 6, 9, 20, 0, 0, 0, time.UTC),
+	}).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	res, err := app.Test(httptest.NewRequest(Method, Path, nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code 200, got %d", res.StatusCode)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !assert.Len(t, archive.File, 4) {
+		return
+	}
+
+	f, err := archive.File[3].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var manifest open_data.Manifest
+	if err = json.NewDecoder(f).Decode(&manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, open_data.ManifestVersion, manifest.Version)
+	assert.Equal(t, 1, manifest.Articles)
+	for i, file := range manifest.Files {
+		assert.Equal(t, archive.File[i].Name, file.Name)
+		assert.Equal(t, uint64(file.Size), archive.File[i].UncompressedSize64)
+	}
+}
@@ -0,0 +1,20 @@
+package download_open_data
+
+import (
+	"io"
+	"vdm/core/open_data"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	export(create func(name string) (io.Writer, error)) (*open_data.Manifest, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) export(create func(name string) (io.Writer, error)) (*open_data.Manifest, error) {
+	return open_data.Export(r.db, create)
+}
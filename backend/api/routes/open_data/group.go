@@ -0,0 +1,19 @@
+package open_data
+
+import (
+	"vdm/api/routes/open_data/download_open_data"
+	"vdm/core/dependencies"
+	"vdm/core/fiberx"
+)
+
+const Prefix = "/open-data"
+
+func Group(deps *dependencies.Dependencies) *fiberx.Group {
+	group := fiberx.NewGroup(Prefix)
+
+	group.Add(
+		download_open_data.Route(deps.GormDB()),
+	)
+
+	return group
+}
@@ -0,0 +1,25 @@
+package passkeys
+
+import (
+	"vdm/api/routes/passkeys/routes/delete_passkey"
+	"vdm/api/routes/passkeys/routes/get_passkeys"
+	"vdm/api/routes/passkeys/routes/register_passkey"
+	"vdm/api/routes/passkeys/routes/registration_options"
+	"vdm/core/dependencies"
+	"vdm/core/fiberx"
+)
+
+const Prefix = "/passkeys"
+
+func Group(deps *dependencies.Dependencies) *fiberx.Group {
+	group := fiberx.NewGroup(Prefix)
+
+	group.Add(
+		get_passkeys.Route(deps.GormDB()),
+		registration_options.Route(deps.GormDB(), deps.WebAuthn, deps.Config.Security),
+		register_passkey.Route(deps.GormDB(), deps.WebAuthn, deps.Mailer, deps.Config.ClientURL),
+		delete_passkey.Route(deps.GormDB()),
+	)
+
+	return group
+}
@@ -0,0 +1,41 @@
+package delete_passkey
+
+import (
+	"fmt"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	deletePasskey(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) deletePasskey(c *fiber.Ctx) error {
+	authedUser, ok := c.Locals(local_keys.AuthedUser).(locals.AuthedUser)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "can't locals authed user"}
+	}
+
+	passkeyID, err := uuid.Parse(c.Params(local_keys.PasskeyID))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid passkey id"}
+	}
+
+	// passkeys of other users are reported as not found
+	found, err := h.repo.deletePasskey(authedUser.ID, passkeyID)
+	if err != nil {
+		return fmt.Errorf("failed to delete passkey: %v", err)
+	}
+	if !found {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("passkey %s not found", passkeyID)}
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
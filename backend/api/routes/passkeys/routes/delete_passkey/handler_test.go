@@ -0,0 +1,42 @@
+package delete_passkey
+
+import (
+	"net/http/httptest"
+	"testing"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type nullRepository struct{}
+
+func (nullRepository) deletePasskey(userID, passkeyID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func TestHandler_Err(t *testing.T) {
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(local_keys.AuthedUser, locals.AuthedUser{ID: uuid.New()})
+		return c.Next()
+	})
+	h := &handler{repo: nullRepository{}}
+	app.Add(Method, Path, h.deletePasskey)
+
+	for target, want := range map[string]int{
+		"/not-a-uuid":          fiber.StatusBadRequest,
+		"/" + uuid.NewString(): fiber.StatusNotFound,
+	} {
+		res, err := app.Test(httptest.NewRequest(Method, target, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, want, res.StatusCode, target)
+	}
+}
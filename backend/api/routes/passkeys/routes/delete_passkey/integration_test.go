@@ -0,0 +1,63 @@
+package delete_passkey
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegration(t *testing.T) {
+	c := context.Background()
+	container, connector := test_utils.NewTestContainerConnector(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	user := &models.User{Email: "user@test.com", Tag: "user0123", Password: "x"}
+	otherUser := &models.User{Email: "other@test.com", Tag: "other0123", Password: "x"}
+	if err := connector.GormDB().Create([]*models.User{user, otherUser}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	passkey := &models.UserPasskey{UserID: user.ID, CredentialID: []byte("laptop"), PublicKey: []byte("key")}
+	foreign := &models.UserPasskey{UserID: otherUser.ID, CredentialID: []byte("foreign"), PublicKey: []byte("key")}
+	if err := connector.GormDB().Create([]*models.UserPasskey{passkey, foreign}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(local_keys.AuthedUser, locals.AuthedUser{ID: user.ID})
+		return c.Next()
+	})
+	Route(connector.GormDB()).Register(app)
+
+	for _, tc := range []struct {
+		target string
+		want   int
+	}{
+		{"/" + foreign.ID.String(), fiber.StatusNotFound},
+		{"/" + passkey.ID.String(), fiber.StatusNoContent},
+		{"/" + passkey.ID.String(), fiber.StatusNotFound},
+	} {
+		res, err := app.Test(httptest.NewRequest(Method, tc.target, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, tc.want, res.StatusCode, tc.target)
+	}
+
+	var ids []string
+	if err := connector.GormDB().Model(&models.UserPasskey{}).Pluck("id", &ids).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{foreign.ID.String()}, ids)
+}
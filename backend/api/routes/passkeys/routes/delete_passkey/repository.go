@@ -0,0 +1,22 @@
+package delete_passkey
+
+import (
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	deletePasskey(userID, passkeyID uuid.UUID) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) deletePasskey(userID, passkeyID uuid.UUID) (bool, error) {
+	res := r.db.Where("id = ? AND user_id = ?", passkeyID, userID).
+		Delete(&models.UserPasskey{})
+	return res.RowsAffected > 0, res.Error
+}
@@ -0,0 +1,14 @@
+package get_passkeys
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PasskeyDTO struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+}
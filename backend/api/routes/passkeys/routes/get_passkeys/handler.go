@@ -0,0 +1,41 @@
+package get_passkeys
+
+import (
+	"fmt"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	getPasskeys(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) getPasskeys(c *fiber.Ctx) error {
+	authedUser, ok := c.Locals(local_keys.AuthedUser).(locals.AuthedUser)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "can't locals authed user"}
+	}
+
+	passkeys, err := h.repo.getPasskeys(authedUser.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get passkeys: %v", err)
+	}
+
+	dtos := make([]PasskeyDTO, len(passkeys))
+	for i, passkey := range passkeys {
+		dtos[i] = PasskeyDTO{
+			ID:         passkey.ID,
+			Name:       passkey.Name,
+			CreatedAt:  passkey.CreatedAt,
+			LastUsedAt: passkey.LastUsedAt,
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dtos)
+}
@@ -0,0 +1,64 @@
+package get_passkeys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector := test_utils.NewTestContainerConnector(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	user := &models.User{Email: "user@test.com", Tag: "user0123", Password: "x"}
+	otherUser := &models.User{Email: "other@test.com", Tag: "other0123", Password: "x"}
+	if err := connector.GormDB().Create([]*models.User{user, otherUser}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	if err := connector.GormDB().Create([]*models.UserPasskey{
+		{UserID: user.ID, Name: "laptop", CredentialID: []byte("laptop"), PublicKey: []byte("key"), CreatedAt: now.Add(-time.Hour)},
+		{UserID: user.ID, Name: "phone", CredentialID: []byte("phone"), PublicKey: []byte("key"), CreatedAt: now, LastUsedAt: &now},
+		{UserID: otherUser.ID, Name: "foreign", CredentialID: []byte("foreign"), PublicKey: []byte("key")},
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(local_keys.AuthedUser, locals.AuthedUser{ID: user.ID})
+		return c.Next()
+	})
+	Route(connector.GormDB()).Register(app)
+
+	res, err := app.Test(httptest.NewRequest(Method, Path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	var passkeys []PasskeyDTO
+	if err = json.NewDecoder(res.Body).Decode(&passkeys); err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, passkeys, 2) {
+		assert.Equal(t, "laptop", passkeys[0].Name)
+		assert.Nil(t, passkeys[0].LastUsedAt)
+		assert.Equal(t, "phone", passkeys[1].Name)
+		assert.NotNil(t, passkeys[1].LastUsedAt)
+	}
+}
@@ -0,0 +1,25 @@
+package get_passkeys
+
+import (
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	getPasskeys(userID uuid.UUID) ([]models.UserPasskey, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) getPasskeys(userID uuid.UUID) ([]models.UserPasskey, error) {
+	var passkeys []models.UserPasskey
+	err := r.db.Select("id", "name", "created_at", "last_used_at").
+		Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&passkeys).Error
+	return passkeys, err
+}
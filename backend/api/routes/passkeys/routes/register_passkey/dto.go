@@ -0,0 +1,20 @@
+package register_passkey
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RequestDTO carries the credential returned by navigator.credentials.create, as JSON
+type RequestDTO struct {
+	Name       string          `json:"name" validate:"required,max=64"`
+	Credential json.RawMessage `json:"credential" validate:"required"`
+}
+
+type ResponseDTO struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
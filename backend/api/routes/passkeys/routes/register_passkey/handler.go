@@ -0,0 +1,43 @@
+package register_passkey
+
+import (
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	registerPasskey(c *fiber.Ctx) error
+}
+
+type handler struct {
+	svc Service
+}
+
+func (h *handler) registerPasskey(c *fiber.Ctx) error {
+	var req RequestDTO
+	if err := c.BodyParser(&req); err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid request body"}
+	}
+	if err := validation.Validate(req); err != nil {
+		return err
+	}
+
+	authedUser, ok := c.Locals(local_keys.AuthedUser).(locals.AuthedUser)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "can't locals authed user"}
+	}
+
+	passkey, err := h.svc.registerPasskey(authedUser.ID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(ResponseDTO{
+		ID:        passkey.ID,
+		Name:      passkey.Name,
+		CreatedAt: passkey.CreatedAt,
+	})
+}
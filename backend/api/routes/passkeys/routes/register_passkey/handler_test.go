@@ -0,0 +1,48 @@
+package register_passkey
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type nullService struct{}
+
+func (nullService) registerPasskey(userID uuid.UUID, req RequestDTO) (models.UserPasskey, error) {
+	return models.UserPasskey{}, nil
+}
+
+func TestHandler_ErrBadRequest(t *testing.T) {
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(local_keys.AuthedUser, locals.AuthedUser{ID: uuid.New()})
+		return c.Next()
+	})
+	h := &handler{svc: nullService{}}
+	app.Add(Method, Path, h.registerPasskey)
+
+	for name, body := range map[string]string{
+		"no name":       `{"credential": {}}`,
+		"long name":     `{"name": "` + strings.Repeat("a", 65) + `", "credential": {}}`,
+		"no credential": `{"name": "laptop"}`,
+	} {
+		req := httptest.NewRequest(Method, Path, strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode, name)
+	}
+}
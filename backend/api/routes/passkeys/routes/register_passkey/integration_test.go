@@ -0,0 +1,100 @@
+package register_passkey
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/env"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/models"
+	"vdm/core/passkey"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector := test_utils.NewTestContainerConnector(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	user := &models.User{Email: "user@test.com", Tag: "user0123", Password: "x"}
+	if err := connector.GormDB().Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	webAuthn, err := passkey.New(env.SecurityConfig{
+		WebAuthnRPID:         "localhost",
+		WebAuthnRPName:       "Vigie du mensonge",
+		WebAuthnRPOrigins:    []string{testOrigin},
+		WebAuthnChallengeTTL: time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the first step, as done by registration_options
+	creation, session, err := webAuthn.BeginRegistration(passkey.User{ID: user.ID, Email: user.Email})
+	if err != nil {
+		t.Fatal(err)
+	}
+	challenge, err := passkey.NewChallenge(models.WebAuthnCeremonyRegistration, &user.ID, session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = connector.GormDB().Create(&challenge).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	authenticator, err := test_utils.NewPasskeyAuthenticator(testOrigin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	credential, err := authenticator.Create(*creation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(local_keys.AuthedUser, locals.AuthedUser{ID: user.ID})
+		return c.Next()
+	})
+	mailer := test_utils.NewMockMailer(gomock.NewController(t))
+	mailer.EXPECT().Send(user.Email, "Nouvelle passkey sur votre compte Vigie du mensonge", gomock.Any()).Return(nil).Times(1)
+
+	Route(connector.GormDB(), webAuthn, mailer, testOrigin).Register(app)
+
+	b, _ := json.Marshal(RequestDTO{Name: "laptop", Credential: credential})
+	req := httptest.NewRequest(Method, Path, bytes.NewReader(b))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+
+	var userPasskey models.UserPasskey
+	if err = connector.GormDB().First(&userPasskey, "user_id = ?", user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "laptop", userPasskey.Name)
+	assert.Equal(t, authenticator.CredentialID, userPasskey.CredentialID)
+	assert.NotEmpty(t, userPasskey.PublicKey)
+
+	var count int64
+	if err = connector.GormDB().Model(&models.WebAuthnChallenge{}).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Zero(t, count, "the challenge is consumed")
+}
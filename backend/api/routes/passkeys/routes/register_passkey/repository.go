@@ -0,0 +1,63 @@
+package register_passkey
+
+import (
+	"time"
+	"vdm/core/models"
+	"vdm/core/passkey"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	consumeChallenge(challenge string, userID uuid.UUID) (*models.WebAuthnChallenge, error)
+	findUser(userID uuid.UUID) (passkey.User, error)
+	passkeyExists(credentialID []byte) (bool, error)
+	createPasskey(userPasskey *models.UserPasskey) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// consumeChallenge deletes the unexpired registration challenge of the user and returns it, nil when there is none
+func (r *repository) consumeChallenge(challenge string, userID uuid.UUID) (*models.WebAuthnChallenge, error) {
+	var challenges []models.WebAuthnChallenge
+	if err := r.db.Clauses(clause.Returning{}).
+		Where("challenge = ? AND ceremony = ? AND user_id = ? AND expiry > ?", challenge, models.WebAuthnCeremonyRegistration, userID, time.Now()).
+		Delete(&challenges).Error; err != nil {
+		return nil, err
+	}
+	if len(challenges) == 0 {
+		return nil, nil
+	}
+	return &challenges[0], nil
+}
+
+func (r *repository) findUser(userID uuid.UUID) (passkey.User, error) {
+	var user models.User
+	if err := r.db.Select("id", "email").
+		First(&user, "id = ?", userID).Error; err != nil {
+		return passkey.User{}, err
+	}
+
+	var passkeys []models.UserPasskey
+	if err := r.db.Where("user_id = ?", userID).
+		Find(&passkeys).Error; err != nil {
+		return passkey.User{}, err
+	}
+
+	return passkey.User{ID: user.ID, Email: user.Email, Passkeys: passkeys}, nil
+}
+
+func (r *repository) passkeyExists(credentialID []byte) (bool, error) {
+	var exists bool
+	err := r.db.Raw("SELECT EXISTS (SELECT 1 FROM user_passkeys WHERE credential_id = ?)", credentialID).
+		Scan(&exists).Error
+	return exists, err
+}
+
+func (r *repository) createPasskey(userPasskey *models.UserPasskey) error {
+	return r.db.Create(userPasskey).Error
+}
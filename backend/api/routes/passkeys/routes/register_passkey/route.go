@@ -0,0 +1,22 @@
+package register_passkey
+
+import (
+	"vdm/core/dependencies/mailer"
+	"vdm/core/fiberx"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	Path   = "/"
+	Method = fiber.MethodPost
+)
+
+func Route(db *gorm.DB, webAuthn *webauthn.WebAuthn, mailer mailer.Mailer, clientURL string) *fiberx.Route {
+	repo := &repository{db}
+	svc := &service{repo: repo, webAuthn: webAuthn, mailer: mailer, clientURL: clientURL}
+	handler := &handler{svc}
+	return fiberx.NewRoute(Method, Path, handler.registerPasskey)
+}
@@ -0,0 +1,92 @@
+package register_passkey
+
+import (
+	"fmt"
+	"vdm/core/dependencies/mailer"
+	"vdm/core/logger"
+	"vdm/core/models"
+	"vdm/core/passkey"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	registerPasskey(userID uuid.UUID, req RequestDTO) (models.UserPasskey, error)
+}
+
+type service struct {
+	repo     Repository
+	webAuthn *webauthn.WebAuthn
+
+	mailer    mailer.Mailer
+	clientURL string
+}
+
+// registerPasskey verifies the credential created from the options of registration_options and stores it
+func (s *service) registerPasskey(userID uuid.UUID, req RequestDTO) (models.UserPasskey, error) {
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(req.Credential)
+	if err != nil {
+		return models.UserPasskey{}, &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid credential"}
+	}
+
+	// the challenge is single use, whether the credential is valid or not
+	challenge, err := s.repo.consumeChallenge(parsed.Response.CollectedClientData.Challenge, userID)
+	if err != nil {
+		return models.UserPasskey{}, fmt.Errorf("failed to consume webauthn challenge: %v", err)
+	}
+	if challenge == nil {
+		return models.UserPasskey{}, &fiber.Error{Code: fiber.StatusBadRequest, Message: "unknown or expired challenge"}
+	}
+
+	session, err := passkey.Session(*challenge)
+	if err != nil {
+		return models.UserPasskey{}, err
+	}
+
+	user, err := s.repo.findUser(userID)
+	if err != nil {
+		return models.UserPasskey{}, fmt.Errorf("failed to find user: %v", err)
+	}
+
+	credential, err := s.webAuthn.CreateCredential(user, session, parsed)
+	if err != nil {
+		return models.UserPasskey{}, &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid credential"}
+	}
+
+	exists, err := s.repo.passkeyExists(credential.ID)
+	if err != nil {
+		return models.UserPasskey{}, fmt.Errorf("failed to check passkey: %v", err)
+	}
+	if exists {
+		return models.UserPasskey{}, &fiber.Error{Code: fiber.StatusConflict, Message: "passkey already registered"}
+	}
+
+	userPasskey := passkey.NewUserPasskey(userID, req.Name, credential)
+	if err = s.repo.createPasskey(&userPasskey); err != nil {
+		return models.UserPasskey{}, fmt.Errorf("failed to create passkey: %v", err)
+	}
+
+	s.notifyPasskey(user, userPasskey)
+
+	return userPasskey, nil
+}
+
+// notifyPasskey tells the user a passkey was added to their account, the passkey being kept whether the email was sent or not
+func (s *service) notifyPasskey(user passkey.User, userPasskey models.UserPasskey) {
+	logger.Info("passkey registered",
+		logger.Any("event", "passkey_registered"),
+		logger.Any("userId", user.ID))
+
+	if err := s.mailer.Send(
+		user.Email,
+		"Nouvelle passkey sur votre compte Vigie du mensonge",
+		fmt.Sprintf("Une passkey nommée « %s » vient d'être ajoutée à votre compte Vigie du mensonge, elle permet désormais de vous connecter sans mot de passe.\n", userPasskey.Name)+
+			"Si cet ajout ne vient pas de vous, supprimez cette passkey depuis votre compte et modifiez votre mot de passe à partir de la page de connexion (lien ci-dessous)."+
+			"\n\n"+s.clientURL+"/sign-in",
+	); err != nil {
+		logger.Error("failed to send passkey email", logger.Err(err), logger.Any("userId", user.ID))
+	}
+}
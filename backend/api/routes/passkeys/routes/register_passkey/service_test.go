@@ -0,0 +1,153 @@
+package register_passkey
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"vdm/core/env"
+	"vdm/core/models"
+	"vdm/core/passkey"
+	"vdm/test_utils"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const testOrigin = "http://localhost:5173"
+
+type stubRepository struct {
+	user      passkey.User
+	challenge *models.WebAuthnChallenge
+	exists    bool
+	created   *models.UserPasskey
+}
+
+func (r *stubRepository) consumeChallenge(challenge string, userID uuid.UUID) (*models.WebAuthnChallenge, error) {
+	if r.challenge == nil || r.challenge.Challenge != challenge || *r.challenge.UserID != userID {
+		return nil, nil
+	}
+	consumed := r.challenge
+	r.challenge = nil
+	return consumed, nil
+}
+
+func (r *stubRepository) findUser(userID uuid.UUID) (passkey.User, error) {
+	return r.user, nil
+}
+
+func (r *stubRepository) passkeyExists(credentialID []byte) (bool, error) {
+	return r.exists, nil
+}
+
+func (r *stubRepository) createPasskey(userPasskey *models.UserPasskey) error {
+	r.created = userPasskey
+	return nil
+}
+
+// begin starts a registration the way registration_options does
+func begin(t *testing.T) (*webauthn.WebAuthn, *stubRepository, protocol.CredentialCreation) {
+	webAuthn, err := passkey.New(env.SecurityConfig{
+		WebAuthnRPID:         "localhost",
+		WebAuthnRPName:       "Vigie du mensonge",
+		WebAuthnRPOrigins:    []string{testOrigin},
+		WebAuthnChallengeTTL: time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := passkey.User{ID: uuid.New(), Email: "user@test.com"}
+	creation, session, err := webAuthn.BeginRegistration(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	challenge, err := passkey.NewChallenge(models.WebAuthnCeremonyRegistration, &user.ID, session)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return webAuthn, &stubRepository{user: user, challenge: &challenge}, *creation
+}
+
+func TestService_Success(t *testing.T) {
+	webAuthn, repo, creation := begin(t)
+
+	authenticator, err := test_utils.NewPasskeyAuthenticator(testOrigin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	credential, err := authenticator.Create(creation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mockCtrl := gomock.NewController(t)
+	mailer := test_utils.NewMockMailer(mockCtrl)
+	// the user is told once, when the passkey is added
+	mailer.EXPECT().Send("user@test.com", "Nouvelle passkey sur votre compte Vigie du mensonge", gomock.Any()).Return(nil).Times(1)
+
+	svc := &service{repo: repo, webAuthn: webAuthn, mailer: mailer, clientURL: testOrigin}
+	userPasskey, err := svc.registerPasskey(repo.user.ID, RequestDTO{Name: "laptop", Credential: credential})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "laptop", userPasskey.Name)
+	assert.Equal(t, repo.user.ID, userPasskey.UserID)
+	assert.Equal(t, authenticator.CredentialID, userPasskey.CredentialID)
+	assert.NotNil(t, repo.created)
+	assert.Nil(t, repo.challenge, "the challenge is consumed")
+
+	// the challenge can't be replayed
+	_, err = svc.registerPasskey(repo.user.ID, RequestDTO{Name: "laptop", Credential: credential})
+	var fiberErr *fiber.Error
+	if assert.True(t, errors.As(err, &fiberErr)) {
+		assert.Equal(t, fiber.StatusBadRequest, fiberErr.Code)
+	}
+}
+
+func TestService_Err(t *testing.T) {
+	for name, tc := range map[string]struct {
+		origin string
+		exists bool
+		userID func(repo *stubRepository) uuid.UUID
+		want   int
+	}{
+		"wrong origin":     {origin: "https://phishing.example.com", want: fiber.StatusBadRequest},
+		"other user":       {origin: testOrigin, userID: func(*stubRepository) uuid.UUID { return uuid.New() }, want: fiber.StatusBadRequest},
+		"already existing": {origin: testOrigin, exists: true, want: fiber.StatusConflict},
+	} {
+		webAuthn, repo, creation := begin(t)
+		repo.exists = tc.exists
+
+		authenticator, err := test_utils.NewPasskeyAuthenticator(tc.origin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		credential, err := authenticator.Create(creation)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		userID := repo.user.ID
+		if tc.userID != nil {
+			userID = tc.userID(repo)
+		}
+
+		// no email is sent for a refused passkey
+		mailer := test_utils.NewMockMailer(gomock.NewController(t))
+
+		_, err = (&service{repo: repo, webAuthn: webAuthn, mailer: mailer}).registerPasskey(userID, RequestDTO{Name: "laptop", Credential: credential})
+
+		var fiberErr *fiber.Error
+		if assert.True(t, errors.As(err, &fiberErr), name) {
+			assert.Equal(t, tc.want, fiberErr.Code, name)
+		}
+		assert.Nil(t, repo.created, name)
+	}
+}
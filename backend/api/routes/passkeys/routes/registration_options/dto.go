@@ -0,0 +1,6 @@
+package registration_options
+
+type RequestDTO struct {
+	// Code is a current TOTP code, required once the user has confirmed a TOTP
+	Code string `json:"code" validate:"omitempty,len=6,numeric"`
+}
@@ -0,0 +1,43 @@
+package registration_options
+
+import (
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	registrationOptions(c *fiber.Ctx) error
+}
+
+type handler struct {
+	svc Service
+}
+
+// registrationOptions answers the options to pass to navigator.credentials.create
+func (h *handler) registrationOptions(c *fiber.Ctx) error {
+	var req RequestDTO
+	// the body is optional, users without TOTP have no code to send
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid request body"}
+		}
+		if err := validation.Validate(req); err != nil {
+			return err
+		}
+	}
+
+	authedUser, ok := c.Locals(local_keys.AuthedUser).(locals.AuthedUser)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "can't locals authed user"}
+	}
+
+	creation, err := h.svc.registrationOptions(authedUser.ID, req.Code)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(creation)
+}
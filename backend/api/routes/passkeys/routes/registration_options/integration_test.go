@@ -0,0 +1,85 @@
+package registration_options
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/env"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/models"
+	"vdm/core/passkey"
+	"vdm/test_utils"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector := test_utils.NewTestContainerConnector(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	user := &models.User{Email: "user@test.com", Tag: "user0123", Password: "x"}
+	if err := connector.GormDB().Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	registered := &models.UserPasskey{UserID: user.ID, CredentialID: []byte("registered"), PublicKey: []byte("key")}
+	expired := &models.WebAuthnChallenge{Challenge: "expired", Ceremony: models.WebAuthnCeremonyLogin, Session: "{}", Expiry: time.Now().Add(-time.Minute)}
+	if err := connector.GormDB().Create(registered).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := connector.GormDB().Create(expired).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	webAuthn, err := passkey.New(env.SecurityConfig{
+		WebAuthnRPID:         "localhost",
+		WebAuthnRPName:       "Vigie du mensonge",
+		WebAuthnRPOrigins:    []string{"http://localhost:5173"},
+		WebAuthnChallengeTTL: time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(local_keys.AuthedUser, locals.AuthedUser{ID: user.ID})
+		return c.Next()
+	})
+	Route(connector.GormDB(), webAuthn, env.SecurityConfig{}).Register(app)
+
+	res, err := app.Test(httptest.NewRequest(Method, Path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	var creation protocol.CredentialCreation
+	if err = json.NewDecoder(res.Body).Decode(&creation); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "localhost", creation.Response.RelyingParty.ID)
+	assert.Equal(t, "user@test.com", creation.Response.User.Name)
+	if assert.Len(t, creation.Response.CredentialExcludeList, 1) {
+		assert.Equal(t, []byte("registered"), []byte(creation.Response.CredentialExcludeList[0].CredentialID))
+	}
+
+	var challenges []models.WebAuthnChallenge
+	if err = connector.GormDB().Find(&challenges).Error; err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, challenges, 1, "the expired challenge is cleaned up") {
+		assert.Equal(t, creation.Response.Challenge.String(), challenges[0].Challenge)
+		assert.Equal(t, models.WebAuthnCeremonyRegistration, challenges[0].Ceremony)
+		assert.Equal(t, user.ID, *challenges[0].UserID)
+	}
+}
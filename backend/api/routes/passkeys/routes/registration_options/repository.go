@@ -0,0 +1,83 @@
+package registration_options
+
+import (
+	"time"
+	"vdm/core/models"
+	"vdm/core/passkey"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	findTOTP(userID uuid.UUID) (*models.UserTOTP, error)
+	useTOTPStep(userID uuid.UUID, step int64) (bool, error)
+	recordFailedAttempt(userID uuid.UUID, lockoutDuration time.Duration) error
+	findUser(userID uuid.UUID) (passkey.User, error)
+	createChallenge(challenge *models.WebAuthnChallenge) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) findTOTP(userID uuid.UUID) (*models.UserTOTP, error) {
+	var totps []models.UserTOTP
+	if err := r.db.Where("user_id = ?", userID).
+		Limit(1).
+		Find(&totps).Error; err != nil {
+		return nil, err
+	}
+	if len(totps) == 0 {
+		return nil, nil
+	}
+	return &totps[0], nil
+}
+
+// useTOTPStep records the step of an accepted code and resets the failed attempts.
+// It returns false when a concurrent request used the same step, or a later one, first.
+func (r *repository) useTOTPStep(userID uuid.UUID, step int64) (bool, error) {
+	res := r.db.Model(&models.UserTOTP{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Updates(map[string]any{"last_used_step": step, "failed_attempts": 0, "last_failed_at": nil})
+	return res.RowsAffected > 0, res.Error
+}
+
+// recordFailedAttempt counts consecutive failures, starting over once the previous one is older than lockoutDuration
+func (r *repository) recordFailedAttempt(userID uuid.UUID, lockoutDuration time.Duration) error {
+	now := time.Now()
+	return r.db.Model(&models.UserTOTP{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]any{
+			"failed_attempts": gorm.Expr("CASE WHEN last_failed_at IS NULL OR last_failed_at < ? THEN 1 ELSE failed_attempts + 1 END",
+				now.Add(-lockoutDuration)),
+			"last_failed_at": now,
+		}).Error
+}
+
+func (r *repository) findUser(userID uuid.UUID) (passkey.User, error) {
+	var user models.User
+	if err := r.db.Select("id", "email").
+		First(&user, "id = ?", userID).Error; err != nil {
+		return passkey.User{}, err
+	}
+
+	var passkeys []models.UserPasskey
+	if err := r.db.Where("user_id = ?", userID).
+		Find(&passkeys).Error; err != nil {
+		return passkey.User{}, err
+	}
+
+	return passkey.User{ID: user.ID, Email: user.Email, Passkeys: passkeys}, nil
+}
+
+func (r *repository) createChallenge(challenge *models.WebAuthnChallenge) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// abandoned ceremonies are cleaned up
+		if err := tx.Where("expiry <= ?", time.Now()).
+			Delete(&models.WebAuthnChallenge{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(challenge).Error
+	})
+}
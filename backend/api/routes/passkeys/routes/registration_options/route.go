@@ -0,0 +1,22 @@
+package registration_options
+
+import (
+	"vdm/core/env"
+	"vdm/core/fiberx"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	Path   = "/registration-options"
+	Method = fiber.MethodPost
+)
+
+func Route(db *gorm.DB, webAuthn *webauthn.WebAuthn, cfg env.SecurityConfig) *fiberx.Route {
+	repo := &repository{db}
+	svc := &service{repo: repo, webAuthn: webAuthn, encryptionKey: cfg.MfaEncryptionKey}
+	handler := &handler{svc}
+	return fiberx.NewRoute(Method, Path, handler.registrationOptions)
+}
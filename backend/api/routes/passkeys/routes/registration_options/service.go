@@ -0,0 +1,97 @@
+package registration_options
+
+import (
+	"fmt"
+	"time"
+	"vdm/core/aes_utils"
+	"vdm/core/models"
+	"vdm/core/passkey"
+	"vdm/core/totp"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	registrationOptions(userID uuid.UUID, code string) (*protocol.CredentialCreation, error)
+}
+
+type service struct {
+	repo          Repository
+	webAuthn      *webauthn.WebAuthn
+	encryptionKey []byte
+}
+
+func (s *service) registrationOptions(userID uuid.UUID, code string) (*protocol.CredentialCreation, error) {
+	if err := s.stepUp(userID, code); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.findUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %v", err)
+	}
+
+	// the authenticators already holding a passkey of the user refuse to create another one
+	exclusions := webauthn.Credentials(user.WebAuthnCredentials()).CredentialDescriptors()
+
+	creation, session, err := s.webAuthn.BeginRegistration(user, webauthn.WithExclusions(exclusions))
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin passkey registration: %v", err)
+	}
+
+	challenge, err := passkey.NewChallenge(models.WebAuthnCeremonyRegistration, &userID, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.createChallenge(&challenge); err != nil {
+		return nil, fmt.Errorf("failed to create webauthn challenge: %v", err)
+	}
+
+	return creation, nil
+}
+
+// stepUp consumes a current TOTP code when the user has confirmed a TOTP, so that a stolen session
+// can't add a passkey skipping the second factor on the next sign-ins.
+// Wrong codes count towards the lockout of the second step of signing in.
+func (s *service) stepUp(userID uuid.UUID, code string) error {
+	userTOTP, err := s.repo.findTOTP(userID)
+	if err != nil {
+		return fmt.Errorf("failed to find totp: %v", err)
+	}
+	if userTOTP == nil || !userTOTP.Confirmed() {
+		return nil
+	}
+
+	if code == "" {
+		return &fiber.Error{Code: fiber.StatusForbidden, Message: "totp code required"}
+	}
+
+	if userTOTP.FailedAttempts >= totp.MaxFailedAttempts && userTOTP.LastFailedAt != nil && time.Since(*userTOTP.LastFailedAt) < totp.LockoutDuration {
+		return &fiber.Error{Code: fiber.StatusTooManyRequests, Message: "too many failed attempts"}
+	}
+
+	secret, err := aes_utils.Decrypt(userTOTP.Secret, s.encryptionKey)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt totp secret: %v", err)
+	}
+
+	used := false
+	if step, ok := totp.Validate(secret, code, time.Now()); ok && step > userTOTP.LastUsedStep {
+		if used, err = s.repo.useTOTPStep(userID, step); err != nil {
+			return fmt.Errorf("failed to use totp step: %v", err)
+		}
+	}
+
+	if !used {
+		if err = s.repo.recordFailedAttempt(userID, totp.LockoutDuration); err != nil {
+			return fmt.Errorf("failed to record failed attempt: %v", err)
+		}
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid code"}
+	}
+
+	return nil
+}
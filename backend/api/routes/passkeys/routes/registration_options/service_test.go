@@ -0,0 +1,148 @@
+package registration_options
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+	"vdm/core/aes_utils"
+	"vdm/core/env"
+	"vdm/core/models"
+	"vdm/core/passkey"
+	"vdm/core/totp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var encryptionKey = bytes.Repeat([]byte("k"), 32)
+
+type stubRepository struct {
+	totp       *models.UserTOTP
+	failures   int
+	challenges int
+}
+
+func (r *stubRepository) findTOTP(userID uuid.UUID) (*models.UserTOTP, error) {
+	return r.totp, nil
+}
+
+func (r *stubRepository) useTOTPStep(userID uuid.UUID, step int64) (bool, error) {
+	if step <= r.totp.LastUsedStep {
+		return false, nil
+	}
+	r.totp.LastUsedStep = step
+	return true, nil
+}
+
+func (r *stubRepository) recordFailedAttempt(userID uuid.UUID, lockoutDuration time.Duration) error {
+	r.failures++
+	return nil
+}
+
+func (r *stubRepository) findUser(userID uuid.UUID) (passkey.User, error) {
+	return passkey.User{ID: userID, Email: "user@test.com"}, nil
+}
+
+func (r *stubRepository) createChallenge(challenge *models.WebAuthnChallenge) error {
+	r.challenges++
+	return nil
+}
+
+func newTestService(t *testing.T, repo *stubRepository) *service {
+	webAuthn, err := passkey.New(env.SecurityConfig{
+		WebAuthnRPID:         "localhost",
+		WebAuthnRPName:       "Vigie du mensonge",
+		WebAuthnRPOrigins:    []string{"http://localhost:5173"},
+		WebAuthnChallengeTTL: time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &service{repo: repo, webAuthn: webAuthn, encryptionKey: encryptionKey}
+}
+
+// withTOTP gives the user a confirmed TOTP and returns its secret
+func withTOTP(t *testing.T, repo *stubRepository, userID uuid.UUID) string {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := aes_utils.Encrypt(secret, encryptionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	confirmedAt := time.Now()
+	repo.totp = &models.UserTOTP{UserID: userID, Secret: encrypted, ConfirmedAt: &confirmedAt}
+
+	return secret
+}
+
+func assertStatus(t *testing.T, err error, status int) {
+	var fiberErr *fiber.Error
+	if assert.True(t, errors.As(err, &fiberErr), err) {
+		assert.Equal(t, status, fiberErr.Code)
+	}
+}
+
+func TestService_WithoutTOTP(t *testing.T) {
+	repo := &stubRepository{}
+	svc := newTestService(t, repo)
+
+	creation, err := svc.registrationOptions(uuid.New(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "user@test.com", creation.Response.User.Name)
+	assert.Equal(t, 1, repo.challenges)
+}
+
+func TestService_WithTOTP(t *testing.T) {
+	repo := &stubRepository{}
+	svc := newTestService(t, repo)
+	userID := uuid.New()
+	secret := withTOTP(t, repo, userID)
+
+	_, err := svc.registrationOptions(userID, "")
+	assertStatus(t, err, fiber.StatusForbidden)
+	assert.Equal(t, 0, repo.failures)
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = svc.registrationOptions(userID, code); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, repo.challenges)
+
+	// the code can't be replayed
+	_, err = svc.registrationOptions(userID, code)
+	assertStatus(t, err, fiber.StatusBadRequest)
+	assert.Equal(t, 1, repo.failures)
+	assert.Equal(t, 1, repo.challenges)
+}
+
+func TestService_ErrTooManyRequests(t *testing.T) {
+	repo := &stubRepository{}
+	svc := newTestService(t, repo)
+	userID := uuid.New()
+	secret := withTOTP(t, repo, userID)
+
+	lastFailedAt := time.Now()
+	repo.totp.FailedAttempts = totp.MaxFailedAttempts
+	repo.totp.LastFailedAt = &lastFailedAt
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// even a valid code is refused during the lockout
+	_, err = svc.registrationOptions(userID, code)
+	assertStatus(t, err, fiber.StatusTooManyRequests)
+	assert.Equal(t, 0, repo.challenges)
+}
@@ -1,9 +1,11 @@
 package politicians
 
 import (
+	"vdm/api/routes/politicians/routes/find_politician"
 	"vdm/api/routes/politicians/routes/get_politicians"
 	"vdm/core/dependencies"
 	"vdm/core/fiberx"
+	"vdm/core/http_cache"
 )
 
 const Prefix = "/politicians"
@@ -12,6 +14,11 @@ func Group(deps *dependencies.Dependencies) *fiberx.Group {
 	group := fiberx.NewGroup(Prefix)
 
 	group.Add(
+		// politicians embed counts of published articles, whose changes must be seen too.
+		// No route reads query params, so none tells responses apart.
+		fiberx.NewMiddleware(deps.Cache.Middleware(nil, http_cache.ScopePoliticians, http_cache.ScopeArticles)),
+
+		find_politician.Route(deps.GormDB()),
 		get_politicians.Group(deps.GormDB()),
 	)
 
@@ -0,0 +1,14 @@
+package find_politician
+
+import (
+	"vdm/core/dto/response_dto"
+	"vdm/core/models"
+)
+
+type ResponseDTO struct {
+	response_dto.Politician
+	ImageURL      string                           `json:"imageUrl,omitempty"`
+	Occupations   []response_dto.Occupation        `json:"occupations"`
+	Affiliations  []response_dto.Affiliation       `json:"affiliations"`
+	ArticleCounts map[models.ArticleCategory]int64 `json:"articleCounts"`
+}
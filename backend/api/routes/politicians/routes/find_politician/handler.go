@@ -0,0 +1,34 @@
+package find_politician
+
+import (
+	"fmt"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	findPolitician(c *fiber.Ctx) error
+}
+
+type handler struct {
+	svc Service
+}
+
+func (h *handler) findPolitician(c *fiber.Ctx) error {
+	politicianID, err := uuid.Parse(c.Params(local_keys.PoliticianID))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid politician id"}
+	}
+
+	respDTO, err := h.svc.findAndMapPolitician(politicianID)
+	if err != nil {
+		return err
+	}
+	if respDTO == nil {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("politician with id %s not found", politicianID)}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(respDTO)
+}
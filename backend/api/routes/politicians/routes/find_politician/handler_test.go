@@ -0,0 +1,50 @@
+package find_politician
+
+import (
+	"net/http/httptest"
+	"testing"
+	"vdm/core/fiberx"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type nullService struct{}
+
+func (*nullService) findAndMapPolitician(politicianID uuid.UUID) (*ResponseDTO, error) {
+	return nil, nil
+}
+
+func newAppWithNullSvc() *fiber.App {
+	app := fiberx.NewApp()
+	h := &handler{svc: &nullService{}}
+	app.Add(Method, Path, h.findPolitician)
+	return app
+}
+
+func TestHandler_ErrBadRequest(t *testing.T) {
+	app := newAppWithNullSvc()
+
+	req := httptest.NewRequest(Method, "/not-a-uuid", nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestHandler_ErrNotFound(t *testing.T) {
+	app := newAppWithNullSvc()
+
+	req := httptest.NewRequest(Method, "/"+uuid.New().String(), nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
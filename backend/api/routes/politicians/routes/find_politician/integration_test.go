@@ -0,0 +1,204 @@
+package find_politician
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	politicians []*models.Politician
+	governments []*models.Government
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.politicians = []*models.Politician{
+		{FirstName: "Gabriel", LastName: "Attal"},
+		{FirstName: "Élisabeth", LastName: "Borne"},
+	}
+	if err = connector.GormDB().Create(&data.politicians).Error; err != nil {
+		return
+	}
+
+	data.governments = []*models.Government{
+		{
+			PrimeMinisterID: data.politicians[1].ID,
+			Reference:       43,
+			StartDate:       time.Date(2022, 5, 16, 0, 0, 0, 0, time.UTC),
+			EndDate:         sql.NullTime{Time: time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+		{
+			PrimeMinisterID: data.politicians[0].ID,
+			Reference:       44,
+			StartDate:       time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+			EndDate:         sql.NullTime{Time: time.Date(2024, 9, 21, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+	}
+	if err = connector.GormDB().Create(&data.governments).Error; err != nil {
+		return
+	}
+
+	// inserted out of chronological order on purpose
+	occupations := []*models.Occupation{
+		{
+			PoliticianID: data.politicians[0].ID,
+			GovernmentID: &data.governments[1].ID,
+			Code:         "PM",
+			Title:        "Premier ministre",
+			StartDate:    data.governments[1].StartDate,
+			EndDate:      data.governments[1].EndDate,
+		},
+		{
+			PoliticianID: data.politicians[0].ID,
+			GovernmentID: &data.governments[0].ID,
+			Code:         "MIN_EDUC",
+			Title:        "Ministre de l'Éducation nationale et de la Jeunesse",
+			StartDate:    time.Date(2023, 7, 20, 0, 0, 0, 0, time.UTC),
+			EndDate:      data.governments[0].EndDate,
+		},
+	}
+	if err = connector.GormDB().Create(&occupations).Error; err != nil {
+		return
+	}
+
+	parties := []*models.Party{
+		{Code: "RE", Name: "Renaissance"},
+		{Code: "PS", Name: "Parti socialiste"},
+	}
+	if err = connector.GormDB().Create(&parties).Error; err != nil {
+		return
+	}
+
+	// inserted out of chronological order on purpose
+	affiliations := []*models.PoliticianAffiliation{
+		{
+			PoliticianID: data.politicians[0].ID,
+			PartyID:      parties[0].ID,
+			StartDate:    time.Date(2016, 4, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			PoliticianID: data.politicians[0].ID,
+			PartyID:      parties[1].ID,
+			StartDate:    time.Date(2006, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:      sql.NullTime{Time: time.Date(2016, 4, 6, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+	}
+	if err = connector.GormDB().Create(&affiliations).Error; err != nil {
+		return
+	}
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	articles := []*models.Article{
+		{
+			RedactorID:  redactor.ID,
+			Title:       "Published lie",
+			Politicians: []*models.Politician{data.politicians[0]},
+			Status:      models.ArticleStatusPublished,
+			Category:    models.ArticleCategoryLie,
+		},
+		{
+			RedactorID:  redactor.ID,
+			Title:       "Published falsehood",
+			Politicians: []*models.Politician{data.politicians[0], data.politicians[1]},
+			Status:      models.ArticleStatusPublished,
+			Category:    models.ArticleCategoryFalsehood,
+		},
+		{
+			RedactorID:  redactor.ID,
+			Title:       "Draft lie",
+			Politicians: []*models.Politician{data.politicians[0]},
+			Status:      models.ArticleStatusDraft,
+			Category:    models.ArticleCategoryLie,
+		},
+	}
+
+	err = connector.GormDB().Create(&articles).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/"+data.politicians[0].ID.String(), nil)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code 200, got %d", res.StatusCode)
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resDTO ResponseDTO
+	if err = json.Unmarshal(resBody, &resDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, data.politicians[0].ID, resDTO.ID)
+	assert.Equal(t, "Gabriel Attal", resDTO.FullName)
+
+	if assert.Equal(t, 2, len(resDTO.Occupations)) {
+		assert.Equal(t, "MIN_EDUC", resDTO.Occupations[0].Code)
+		assert.Equal(t, "PM", resDTO.Occupations[1].Code)
+
+		if assert.NotNil(t, resDTO.Occupations[0].Government) {
+			assert.Equal(t, int16(43), resDTO.Occupations[0].Government.Reference)
+			if assert.NotNil(t, resDTO.Occupations[0].Government.PrimeMinister) {
+				assert.Equal(t, "Élisabeth Borne", resDTO.Occupations[0].Government.PrimeMinister.FullName)
+			}
+		}
+	}
+
+	if assert.NotNil(t, resDTO.Party) {
+		assert.Equal(t, "RE", resDTO.Party.Code)
+	}
+
+	if assert.Equal(t, 2, len(resDTO.Affiliations)) {
+		assert.Equal(t, "PS", resDTO.Affiliations[0].Party.Code)
+		assert.NotNil(t, resDTO.Affiliations[0].EndDate)
+		assert.Equal(t, "RE", resDTO.Affiliations[1].Party.Code)
+		assert.Nil(t, resDTO.Affiliations[1].EndDate)
+	}
+
+	assert.Equal(t, int64(1), resDTO.ArticleCounts[models.ArticleCategoryLie])
+	assert.Equal(t, int64(1), resDTO.ArticleCounts[models.ArticleCategoryFalsehood])
+}
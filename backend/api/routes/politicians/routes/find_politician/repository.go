@@ -0,0 +1,65 @@
+package find_politician
+
+import (
+	"errors"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type categoryCount struct {
+	Category models.ArticleCategory
+	Count    int64
+}
+
+type Repository interface {
+	findPolitician(politicianID uuid.UUID) (*models.Politician, error)
+	countPublishedArticlesByCategory(politicianID uuid.UUID) ([]categoryCount, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) findPolitician(politicianID uuid.UUID) (*models.Politician, error) {
+	var politician models.Politician
+
+	if err := r.db.Where("id = ?", politicianID).
+		Preload("Occupations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_date ASC")
+		}).
+		Preload("Occupations.Government").
+		Preload("Affiliations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_date ASC")
+		}).
+		Preload("Affiliations.Party").
+		Preload("Occupations.Government.PrimeMinister", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "first_name", "last_name")
+		}).
+		First(&politician).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &politician, nil
+}
+
+func (r *repository) countPublishedArticlesByCategory(politicianID uuid.UUID) ([]categoryCount, error) {
+	var counts []categoryCount
+
+	if err := r.db.Model(&models.Article{}).
+		Select("articles.category AS category, COUNT(*) AS count").
+		Joins("JOIN article_politicians ap ON ap.article_id = articles.id").
+		Where("ap.politician_id = ? AND articles.status = ?", politicianID, models.ArticleStatusPublished).
+		Group("articles.category").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
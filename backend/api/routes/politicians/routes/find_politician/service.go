@@ -0,0 +1,60 @@
+package find_politician
+
+import (
+	"fmt"
+	"vdm/core/dto/response_dto"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	findAndMapPolitician(politicianID uuid.UUID) (*ResponseDTO, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func (s *service) findAndMapPolitician(politicianID uuid.UUID) (*ResponseDTO, error) {
+	politician, err := s.repo.findPolitician(politicianID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find politician: %v", err)
+	}
+	if politician == nil {
+		return nil, nil
+	}
+
+	counts, err := s.repo.countPublishedArticlesByCategory(politicianID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count published articles: %v", err)
+	}
+
+	respDTO := &ResponseDTO{
+		Politician:   response_dto.NewPolitician(*politician),
+		Occupations:  make([]response_dto.Occupation, len(politician.Occupations)),
+		Affiliations: make([]response_dto.Affiliation, len(politician.Affiliations)),
+		ArticleCounts: map[models.ArticleCategory]int64{
+			models.ArticleCategoryLie:       0,
+			models.ArticleCategoryFalsehood: 0,
+		},
+	}
+
+	if politician.ImageUrl.Valid {
+		respDTO.ImageURL = politician.ImageUrl.String
+	}
+
+	for i := range politician.Occupations {
+		respDTO.Occupations[i] = response_dto.NewOccupation(*politician.Occupations[i])
+	}
+
+	for i := range politician.Affiliations {
+		respDTO.Affiliations[i] = response_dto.NewAffiliation(*politician.Affiliations[i])
+	}
+
+	for _, count := range counts {
+		respDTO.ArticleCounts[count.Category] = count.Count
+	}
+
+	return respDTO, nil
+}
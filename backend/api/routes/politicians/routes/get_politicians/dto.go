@@ -1,10 +1,15 @@
 package get_politicians
 
-import "github.com/google/uuid"
+import (
+	"vdm/core/dto/response_dto"
+
+	"github.com/google/uuid"
+)
 
 type PoliticianDTO struct {
-	ID       uuid.UUID `json:"id"`
-	FullName string    `json:"fullName"`
+	ID       uuid.UUID           `json:"id"`
+	FullName string              `json:"fullName"`
+	Party    *response_dto.Party `json:"party,omitempty"`
 }
 
 type ResponseDTO []PoliticianDTO
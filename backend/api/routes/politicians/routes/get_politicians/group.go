@@ -1,11 +1,9 @@
 package get_politicians
 
 import (
-	"time"
 	"vdm/core/fiberx"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cache"
 	"gorm.io/gorm"
 )
 
@@ -22,11 +20,6 @@ func Group(db *gorm.DB) *fiberx.Group {
 	group := fiberx.NewGroup(Path)
 
 	group.Add(
-		fiberx.NewMiddleware(cache.New(cache.Config{
-			CacheControl: true,
-			Expiration:   24 * time.Hour,
-		})),
-
 		fiberx.NewRoute(Method, Path, handler.getPoliticians),
 	)
 
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"slices"
 	"testing"
+	"time"
 	"vdm/core/dependencies/database"
 	"vdm/core/fiberx"
 	"vdm/core/models"
@@ -39,7 +40,20 @@ func loadTestData(c context.Context, t *testing.T) (container testcontainers.Con
 		{FirstName: "Bruno", LastName: "Retailleau"},
 	}
 
-	err = connector.GormDB().Create(&data.politicians).Error
+	if err = connector.GormDB().Create(&data.politicians).Error; err != nil {
+		return
+	}
+
+	party := &models.Party{Code: "RE", Name: "Renaissance"}
+	if err = connector.GormDB().Create(party).Error; err != nil {
+		return
+	}
+
+	err = connector.GormDB().Create(&models.PoliticianAffiliation{
+		PoliticianID: data.politicians[0].ID,
+		PartyID:      party.ID,
+		StartDate:    time.Date(2016, 4, 6, 0, 0, 0, 0, time.UTC),
+	}).Error
 
 	return
 }
@@ -79,4 +93,14 @@ func TestIntegration_Success(t *testing.T) {
 			return dto.FullName == politician.FirstName+" "+politician.LastName
 		}))
 	}
+
+	for _, dto := range respDTO {
+		if dto.ID == data.politicians[0].ID {
+			if assert.NotNil(t, dto.Party) {
+				assert.Equal(t, "Renaissance", dto.Party.Name)
+			}
+		} else {
+			assert.Nil(t, dto.Party)
+		}
+	}
 }
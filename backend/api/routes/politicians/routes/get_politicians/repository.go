@@ -19,6 +19,8 @@ func (r *repository) getPoliticians() ([]models.Politician, error) {
 
 	if err := r.db.Model(&models.Politician{}).
 		Select("id, first_name, last_name").
+		Preload("Affiliations", "end_date IS NULL").
+		Preload("Affiliations.Party").
 		Find(&politicians).Error; err != nil {
 		return nil, err
 	}
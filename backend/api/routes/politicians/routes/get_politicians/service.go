@@ -1,6 +1,9 @@
 package get_politicians
 
-import "fmt"
+import (
+	"fmt"
+	"vdm/core/dto/response_dto"
+)
 
 type Service interface {
 	getAndMapPoliticians() (ResponseDTO, error)
@@ -19,9 +22,12 @@ func (s *service) getAndMapPoliticians() (ResponseDTO, error) {
 	respDTO := make(ResponseDTO, 0, len(politicians))
 
 	for _, politician := range politicians {
+		politicianDTO := response_dto.NewPolitician(politician)
+
 		respDTO = append(respDTO, PoliticianDTO{
-			ID:       politician.ID,
-			FullName: politician.FirstName + " " + politician.LastName,
+			ID:       politicianDTO.ID,
+			FullName: politicianDTO.FullName,
+			Party:    politicianDTO.Party,
 		})
 	}
 
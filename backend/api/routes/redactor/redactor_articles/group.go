@@ -3,7 +3,10 @@ package redactor_articles
 import (
 	"vdm/api/routes/redactor/redactor_articles/redactor_find_article"
 	"vdm/api/routes/redactor/redactor_articles/redactor_get_articles"
+	"vdm/api/routes/redactor/redactor_articles/redactor_get_review_comments"
+	"vdm/api/routes/redactor/redactor_articles/redactor_resolve_review_comment"
 	"vdm/api/routes/redactor/redactor_articles/redactor_save_article"
+	"vdm/api/routes/redactor/redactor_articles/redactor_save_review_comment"
 	"vdm/core/dependencies"
 	"vdm/core/fiberx"
 )
@@ -17,6 +20,9 @@ func Group(deps *dependencies.Dependencies) *fiberx.Group {
 		redactor_get_articles.Route(deps.GormDB()),
 		redactor_save_article.Route(deps.GormDB()),
 		redactor_find_article.Route(deps.GormDB()),
+		redactor_get_review_comments.Route(deps.GormDB()),
+		redactor_save_review_comment.Route(deps.GormDB()),
+		redactor_resolve_review_comment.Route(deps.GormDB()),
 	)
 
 	return group
@@ -22,12 +22,13 @@ func (r *repository) findRedactorArticlesByReference(redactorID, reference uuid.
 		Order("created_at DESC").
 		Preload("Sources").
 		Preload("Tags").
-		Preload("Review", func(db *gorm.DB) *gorm.DB {
-			return db.Select("article_id", "notes", "decision")
+		Preload("Reviews", func(db *gorm.DB) *gorm.DB {
+			return db.Select("article_id", "notes", "decision", "created_at")
 		}).
 		Preload("Politicians", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "first_name", "last_name")
 		}).
+		Preload("ArticlePoliticians").
 		Find(&articles).Error; err != nil {
 		return nil, err
 	}
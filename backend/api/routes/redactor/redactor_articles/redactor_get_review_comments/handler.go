@@ -0,0 +1,51 @@
+package redactor_get_review_comments
+
+import (
+	"fmt"
+	"vdm/core/dto/response_dto"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	getReviewCommentsForRedactor(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) getReviewCommentsForRedactor(c *fiber.Ctx) error {
+	authedUser, ok := c.Locals("authedUser").(locals.AuthedUser)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "can't locals authed user"}
+	}
+
+	articleID, err := uuid.Parse(c.Params(local_keys.ArticleID))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid article id"}
+	}
+
+	exists, err := h.repo.redactorArticleExists(articleID, authedUser.ID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("article with id %s not found", articleID)}
+	}
+
+	threads, err := h.repo.getReviewThreads(articleID)
+	if err != nil {
+		return err
+	}
+
+	resDTO := make([]response_dto.ReviewComment, len(threads))
+	for i := range threads {
+		resDTO[i] = response_dto.NewReviewComment(threads[i])
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resDTO)
+}
@@ -0,0 +1,135 @@
+package redactor_get_review_comments
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"vdm/core/dependencies/database"
+	"vdm/core/dto/response_dto"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	redactor *models.User
+	article  *models.Article
+	root     *models.ReviewComment
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.redactor = &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(data.redactor).Error; err != nil {
+		return
+	}
+
+	moderator := &models.User{Email: "moderator@test.com", Tag: "moderator0123", Password: "x"}
+	if err = connector.GormDB().Create(moderator).Error; err != nil {
+		return
+	}
+
+	data.article = &models.Article{
+		RedactorID:  data.redactor.ID,
+		ModeratorID: &moderator.ID,
+		Title:       "Change requested",
+		Sources:     []*models.ArticleSource{{URL: "https://www.insee.fr"}},
+		Status:      models.ArticleStatusChangeRequested,
+		Reference:   uuid.New(),
+		Minor:       1,
+	}
+	if err = connector.GormDB().Create(data.article).Error; err != nil {
+		return
+	}
+
+	sourceURL := "https://www.insee.fr"
+	data.root = &models.ReviewComment{
+		ArticleID: data.article.ID,
+		AuthorID:  moderator.ID,
+		SourceURL: &sourceURL,
+		Text:      "Ce lien ne fonctionne plus.",
+	}
+	err = connector.GormDB().Create(data.root).Error
+	return
+}
+
+func newAppWithAuthedUser(userID uuid.UUID) *fiber.App {
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("authedUser", locals.AuthedUser{ID: userID})
+		return c.Next()
+	})
+	return app
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := newAppWithAuthedUser(data.redactor.ID)
+	Route(connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/"+data.article.ID.String()+"/comments", nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status code 200, got %d", res.StatusCode)
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resDTO []response_dto.ReviewComment
+	if err = json.Unmarshal(resBody, &resDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Equal(t, 1, len(resDTO)) {
+		assert.Equal(t, data.root.ID, resDTO[0].ID)
+		assert.Equal(t, "https://www.insee.fr", resDTO[0].SourceURL)
+		assert.Nil(t, resDTO[0].Anchor)
+	}
+}
+
+func TestIntegration_ErrNotFound(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	// other redactors can't read the comments
+	app := newAppWithAuthedUser(uuid.New())
+	Route(connector.GormDB()).Register(app)
+
+	req := httptest.NewRequest(Method, "/"+data.article.ID.String()+"/comments", nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
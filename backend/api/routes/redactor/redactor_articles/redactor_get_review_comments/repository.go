@@ -0,0 +1,51 @@
+package redactor_get_review_comments
+
+import (
+	"fmt"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	redactorArticleExists(articleID, redactorID uuid.UUID) (bool, error)
+	getReviewThreads(articleID uuid.UUID) ([]models.ReviewComment, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) redactorArticleExists(articleID, redactorID uuid.UUID) (bool, error) {
+	var count int64
+
+	if err := r.db.Model(&models.Article{}).
+		Where("id = ? AND redactor_id = ?", articleID, redactorID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to count Article{ID=%s RedactorID=%s}: %v", articleID, redactorID, err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *repository) getReviewThreads(articleID uuid.UUID) ([]models.ReviewComment, error) {
+	var threads []models.ReviewComment
+
+	selectAuthor := func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "tag")
+	}
+
+	if err := r.db.Where("article_id = ? AND parent_id IS NULL", articleID).
+		Order("created_at").
+		Preload("Author", selectAuthor).
+		Preload("Replies", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at")
+		}).
+		Preload("Replies.Author", selectAuthor).
+		Find(&threads).Error; err != nil {
+		return nil, fmt.Errorf("failed to find review comments of Article{ID=%s}: %v", articleID, err)
+	}
+
+	return threads, nil
+}
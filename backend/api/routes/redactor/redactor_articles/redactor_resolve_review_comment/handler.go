@@ -0,0 +1,45 @@
+package redactor_resolve_review_comment
+
+import (
+	"fmt"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	resolveReviewCommentForRedactor(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) resolveReviewCommentForRedactor(c *fiber.Ctx) error {
+	authedUser, ok := c.Locals("authedUser").(locals.AuthedUser)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "can't locals authed user"}
+	}
+
+	articleID, err := uuid.Parse(c.Params(local_keys.ArticleID))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid article id"}
+	}
+
+	commentID, err := uuid.Parse(c.Params(local_keys.ReviewCommentID))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid comment id"}
+	}
+
+	resolved, err := h.repo.resolveThread(authedUser.ID, articleID, commentID)
+	if err != nil {
+		return err
+	}
+	if !resolved {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("thread with id %s not found", commentID)}
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
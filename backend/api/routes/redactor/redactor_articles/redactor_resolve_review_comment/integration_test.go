@@ -0,0 +1,135 @@
+package redactor_resolve_review_comment
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	redactor *models.User
+	article  *models.Article
+	root     *models.ReviewComment
+	reply    *models.ReviewComment
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.redactor = &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(data.redactor).Error; err != nil {
+		return
+	}
+
+	moderator := &models.User{Email: "moderator@test.com", Tag: "moderator0123", Password: "x"}
+	if err = connector.GormDB().Create(moderator).Error; err != nil {
+		return
+	}
+
+	data.article = &models.Article{
+		RedactorID:  data.redactor.ID,
+		ModeratorID: &moderator.ID,
+		Title:       "Change requested",
+		Sources:     []*models.ArticleSource{{URL: "https://www.insee.fr"}},
+		Status:      models.ArticleStatusChangeRequested,
+		Reference:   uuid.New(),
+		Minor:       1,
+	}
+	if err = connector.GormDB().Create(data.article).Error; err != nil {
+		return
+	}
+
+	sourceURL := "https://www.insee.fr"
+	data.root = &models.ReviewComment{
+		ArticleID: data.article.ID,
+		AuthorID:  moderator.ID,
+		SourceURL: &sourceURL,
+		Text:      "Ce lien ne fonctionne plus.",
+	}
+	if err = connector.GormDB().Create(data.root).Error; err != nil {
+		return
+	}
+
+	data.reply = &models.ReviewComment{
+		ArticleID: data.article.ID,
+		ParentID:  &data.root.ID,
+		AuthorID:  data.redactor.ID,
+		Text:      "Corrigé.",
+	}
+	err = connector.GormDB().Create(data.reply).Error
+	return
+}
+
+func newAppWithAuthedUser(userID uuid.UUID) *fiber.App {
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("authedUser", locals.AuthedUser{ID: userID})
+		return c.Next()
+	})
+	return app
+}
+
+func resolve(t *testing.T, app *fiber.App, articleID, commentID uuid.UUID) int {
+	req := httptest.NewRequest(Method, "/"+articleID.String()+"/comments/"+commentID.String()+"/resolved", nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	return res.StatusCode
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := newAppWithAuthedUser(data.redactor.ID)
+	Route(connector.GormDB()).Register(app)
+
+	assert.Equal(t, fiber.StatusNoContent, resolve(t, app, data.article.ID, data.root.ID))
+
+	var resolved models.ReviewComment
+	if err := connector.GormDB().First(&resolved, "id = ?", data.root.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.NotNil(t, resolved.ResolvedAt)
+
+	// resolving twice is a no-op
+	assert.Equal(t, fiber.StatusNoContent, resolve(t, app, data.article.ID, data.root.ID))
+}
+
+func TestIntegration_ErrNotFound(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	// replies can't be resolved on their own
+	app := newAppWithAuthedUser(data.redactor.ID)
+	Route(connector.GormDB()).Register(app)
+	assert.Equal(t, fiber.StatusNotFound, resolve(t, app, data.article.ID, data.reply.ID))
+
+	// only the redactor of the article can resolve its threads
+	app = newAppWithAuthedUser(uuid.New())
+	Route(connector.GormDB()).Register(app)
+	assert.Equal(t, fiber.StatusNotFound, resolve(t, app, data.article.ID, data.root.ID))
+}
@@ -0,0 +1,34 @@
+package redactor_resolve_review_comment
+
+import (
+	"fmt"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	resolveThread(redactorID, articleID, commentID uuid.UUID) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// resolveThread marks the root comment of a thread as resolved. Resolving a thread twice keeps the first date.
+func (r *repository) resolveThread(redactorID, articleID, commentID uuid.UUID) (bool, error) {
+	redactorArticle := r.db.Model(&models.Article{}).
+		Select("id").
+		Where("id = ? AND redactor_id = ? AND status <> ?", articleID, redactorID, models.ArticleStatusArchived)
+
+	res := r.db.Model(&models.ReviewComment{}).
+		Where("id = ? AND parent_id IS NULL AND article_id IN (?)", commentID, redactorArticle).
+		Update("resolved_at", gorm.Expr("COALESCE(resolved_at, NOW())"))
+
+	if res.Error != nil {
+		return false, fmt.Errorf("failed to resolve ReviewComment{ID=%s}: %v", commentID, res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
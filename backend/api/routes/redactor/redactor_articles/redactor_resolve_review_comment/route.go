@@ -0,0 +1,20 @@
+package redactor_resolve_review_comment
+
+import (
+	"vdm/core/fiberx"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	Path   = "/:" + local_keys.ArticleID + "/comments/:" + local_keys.ReviewCommentID + "/resolved"
+	Method = fiber.MethodPut
+)
+
+func Route(db *gorm.DB) *fiberx.Route {
+	repo := &repository{db}
+	handler := &handler{repo}
+	return fiberx.NewRoute(Method, Path, handler.resolveReviewCommentForRedactor)
+}
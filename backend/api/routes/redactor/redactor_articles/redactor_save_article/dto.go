@@ -2,6 +2,7 @@ package redactor_save_article
 
 import (
 	"fmt"
+	"strings"
 	"time"
 	"vdm/core/models"
 
@@ -13,10 +14,48 @@ type RequestDTO struct {
 	Title         string                 `json:"title" validate:"required,min=20,max=200"`
 	EventDate     time.Time              `json:"eventDate" validate:"required"`
 	Category      models.ArticleCategory `json:"category" validate:"required"`
-	Body          string                 `json:"body,omitempty"`
+	Body          string                 `json:"body,omitempty" validate:"max=2000"`
 	Tags          []string               `json:"tags,omitempty" validate:"max=5"`
 	PoliticianIDs []uuid.UUID            `json:"politicianIds,omitempty" validate:"max=5"`
-	Sources       []string               `json:"sources,omitempty" validate:"max=5"`
+	Sources       []SourceDTO            `json:"sources,omitempty" validate:"max=5,dive"`
+
+	// CorrectionNote is required when resubmitting an article that has already been published
+	CorrectionNote string `json:"correctionNote,omitempty" validate:"max=500"`
+}
+
+type SourceDTO struct {
+	URL         string                   `json:"url" validate:"required,http_url,max=2048"`
+	Type        models.ArticleSourceType `json:"type" validate:"required"`
+	Publisher   string                   `json:"publisher,omitempty" validate:"max=100"`
+	PublishedAt *time.Time               `json:"publishedAt,omitempty"`
+
+	// TimestampSeconds locates the quote in a video, e.g. 754 for 12:34
+	TimestampSeconds *int   `json:"timestampSeconds,omitempty" validate:"omitempty,min=0"`
+	Quote            string `json:"quote,omitempty" validate:"max=1000"`
+}
+
+func (dto SourceDTO) toArticleSource(articleID uuid.UUID) (*models.ArticleSource, error) {
+	if !dto.Type.Valid() {
+		return nil, fmt.Errorf("invalid type for source %s: %s", dto.URL, dto.Type)
+	}
+
+	if dto.TimestampSeconds != nil && dto.Type != models.ArticleSourceTypeVideo {
+		return nil, fmt.Errorf("a timestamp is only allowed for video sources: %s", dto.URL)
+	}
+
+	if dto.PublishedAt != nil && dto.PublishedAt.After(time.Now()) {
+		return nil, fmt.Errorf("publication date of source %s is in the future", dto.URL)
+	}
+
+	return &models.ArticleSource{
+		ArticleID:        articleID,
+		URL:              dto.URL,
+		Type:             dto.Type,
+		Publisher:        strings.TrimSpace(dto.Publisher),
+		PublishedAt:      dto.PublishedAt,
+		TimestampSeconds: dto.TimestampSeconds,
+		Quote:            strings.TrimSpace(dto.Quote),
+	}, nil
 }
 
 func (dto RequestDTO) toArticle(redactorID uuid.UUID) (models.Article, error) {
@@ -27,6 +66,8 @@ func (dto RequestDTO) toArticle(redactorID uuid.UUID) (models.Article, error) {
 		EventDate:  dto.EventDate,
 		Body:       dto.Body,
 		Category:   dto.Category,
+
+		CorrectionNote: strings.TrimSpace(dto.CorrectionNote),
 	}
 
 	if !article.Category.Valid() {
@@ -34,12 +75,17 @@ func (dto RequestDTO) toArticle(redactorID uuid.UUID) (models.Article, error) {
 	}
 
 	seenSources := make(map[string]bool)
-	for _, source := range dto.Sources {
-		if seenSources[source] {
-			return models.Article{}, fmt.Errorf("duplicate source: %s", source)
+	for _, sourceDTO := range dto.Sources {
+		if seenSources[sourceDTO.URL] {
+			return models.Article{}, fmt.Errorf("duplicate source: %s", sourceDTO.URL)
+		}
+		seenSources[sourceDTO.URL] = true
+
+		source, err := sourceDTO.toArticleSource(article.ID)
+		if err != nil {
+			return models.Article{}, err
 		}
-		seenSources[source] = true
-		article.Sources = append(article.Sources, &models.ArticleSource{ArticleID: article.ID, URL: source})
+		article.Sources = append(article.Sources, source)
 	}
 
 	seenTags := make(map[string]bool)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 	"vdm/core/fiberx"
@@ -53,6 +54,29 @@ func TestHandler_ErrBadRequest_ValidateErr(t *testing.T) {
 	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
 }
 
+func TestHandler_ErrBadRequest_BodyTooLong(t *testing.T) {
+	app := newAppWithAuthedUserAndNullSvc()
+
+	// the limit counts characters, not bytes
+	payload := map[string]any{
+		"title":     "This is a sufficiently long article title",
+		"eventDate": time.Now(),
+		"category":  string(models.ArticleCategoryLie),
+		"body":      strings.Repeat("é", 2001),
+	}
+	b, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(Method, Path, bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
 func TestHandler_ErrBadRequest_MappingErr(t *testing.T) {
 	app := newAppWithAuthedUserAndNullSvc()
 
@@ -103,3 +127,48 @@ func TestHandler_ErrBadRequest_ValidateForPublicationErr(t *testing.T) {
 
 	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
 }
+
+func TestHandler_Sources(t *testing.T) {
+	app := newAppWithAuthedUserAndNullSvc()
+
+	tomorrow := time.Now().Add(24 * time.Hour)
+	timestamp := 754
+
+	tests := []struct {
+		name   string
+		source map[string]any
+		status int
+	}{
+		{"video quote", map[string]any{"url": "https://www.youtube.com/watch?v=x", "type": models.ArticleSourceTypeVideo,
+			"publisher": "France 2", "timestampSeconds": timestamp, "quote": "Nous avons créé un million d'emplois."}, fiber.StatusOK},
+		{"missing type", map[string]any{"url": "https://www.lemonde.fr"}, fiber.StatusBadRequest},
+		{"unknown type", map[string]any{"url": "https://www.lemonde.fr", "type": "BLOG"}, fiber.StatusBadRequest},
+		{"not an http url", map[string]any{"url": "javascript:alert(1)", "type": models.ArticleSourceTypePressArticle}, fiber.StatusBadRequest},
+		{"timestamp outside a video", map[string]any{"url": "https://www.lemonde.fr", "type": models.ArticleSourceTypePressArticle,
+			"timestampSeconds": timestamp}, fiber.StatusBadRequest},
+		{"published in the future", map[string]any{"url": "https://www.lemonde.fr", "type": models.ArticleSourceTypePressArticle,
+			"publishedAt": tomorrow}, fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := map[string]any{
+				"title":     "This is a sufficiently long article title",
+				"eventDate": time.Now(),
+				"category":  string(models.ArticleCategoryLie),
+				"sources":   []map[string]any{tt.source},
+			}
+			b, _ := json.Marshal(payload)
+
+			req := httptest.NewRequest(Method, Path, bytes.NewReader(b))
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.status, res.StatusCode)
+		})
+	}
+}
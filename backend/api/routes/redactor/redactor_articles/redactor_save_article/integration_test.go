@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 	"vdm/core/dependencies/database"
@@ -76,7 +77,7 @@ func TestIntegration_NewArticle_PublishTrue_Success(t *testing.T) {
 		Body:          string(longBody),
 		Tags:          []string{"Macron"},
 		PoliticianIDs: []uuid.UUID{data.politicians[0].ID},
-		Sources:       []string{"https://example.com"},
+		Sources:       []SourceDTO{{URL: "https://example.com", Type: models.ArticleSourceTypePressArticle}},
 	}
 	b, _ := json.Marshal(payload)
 
@@ -106,7 +107,9 @@ func TestIntegration_NewArticle_PublishTrue_Success(t *testing.T) {
 	assert.Equal(t, int16(0), article.Major)
 	assert.Equal(t, data.redactor.ID, article.RedactorID)
 	assert.Equal(t, 1, len(article.Tags))
-	assert.Equal(t, 1, len(article.Sources))
+	if assert.Equal(t, 1, len(article.Sources)) {
+		assert.Equal(t, models.ArticleSourceTypePressArticle, article.Sources[0].Type)
+	}
 	assert.Equal(t, 1, len(article.Politicians))
 }
 
@@ -125,7 +128,7 @@ func TestIntegration_NewArticle_PublishFalse_Success(t *testing.T) {
 		Body:          "", // can be empty for draft
 		Tags:          []string{},
 		PoliticianIDs: []uuid.UUID{data.politicians[0].ID},
-		Sources:       []string{},
+		Sources:       []SourceDTO{},
 	}
 	b, _ := json.Marshal(payload)
 
@@ -187,7 +190,7 @@ func TestIntegration_ExistingArticle_PublishTrue_Success(t *testing.T) {
 		Body:          string(longBody),
 		Tags:          []string{"One"},
 		PoliticianIDs: []uuid.UUID{data.politicians[0].ID},
-		Sources:       []string{"https://source"},
+		Sources:       []SourceDTO{{URL: "https://source", Type: models.ArticleSourceTypePressArticle}},
 	}
 	b, _ := json.Marshal(payload)
 
@@ -210,6 +213,15 @@ func TestIntegration_ExistingArticle_PublishTrue_Success(t *testing.T) {
 	assert.Equal(t, models.ArticleStatusUnderReview, articles[0].Status)
 	assert.Equal(t, int16(1), articles[0].Minor)
 	assert.Equal(t, int16(0), articles[0].Major)
+
+	var event models.ArticleStatusEvent
+	if err := connector.GormDB().First(&event, "article_id = ?", articles[0].ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, data.redactor.ID, event.ActorID)
+	assert.Equal(t, ref, event.Reference)
+	assert.Equal(t, models.ArticleStatusDraft, *event.FromStatus)
+	assert.Equal(t, models.ArticleStatusUnderReview, event.ToStatus)
 }
 
 func TestIntegration_ExistingArticle_PublishFalse_Success(t *testing.T) {
@@ -244,7 +256,7 @@ func TestIntegration_ExistingArticle_PublishFalse_Success(t *testing.T) {
 		Category:  models.ArticleCategoryFalsehood,
 		Body:      "updated body but still draft",
 		Tags:      []string{"X"},
-		Sources:   []string{"https://s1"},
+		Sources:   []SourceDTO{{URL: "https://s1", Type: models.ArticleSourceTypePressArticle}},
 	}
 	b, _ := json.Marshal(payload)
 
@@ -310,3 +322,228 @@ func TestIntegration_ErrConflict(t *testing.T) {
 
 	assert.Equal(t, fiber.StatusConflict, res.StatusCode)
 }
+
+func TestIntegration_NewArticle_ResolvesOffice_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	primeMinister := &models.Politician{FirstName: "Gabriel", LastName: "Attal"}
+	minister := &models.Politician{FirstName: "Gérald", LastName: "Darmanin"}
+	if err := connector.GormDB().Create([]*models.Politician{primeMinister, minister}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	government := &models.Government{
+		PrimeMinisterID: primeMinister.ID,
+		Reference:       44,
+		StartDate:       time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+	}
+	if err := connector.GormDB().Create(government).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	occupation := &models.Occupation{
+		PoliticianID: minister.ID,
+		GovernmentID: &government.ID,
+		Code:         "MIN_INT",
+		Title:        "Ministre de l'Intérieur",
+		StartDate:    government.StartDate,
+	}
+	if err := connector.GormDB().Create(occupation).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app := newAppWithAuthedUser(data.redactor.ID)
+	Route(connector.GormDB()).Register(app)
+
+	payload := RequestDTO{
+		Title:         "This is a sufficiently long article title",
+		EventDate:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Category:      models.ArticleCategoryLie,
+		PoliticianIDs: []uuid.UUID{minister.ID, data.politicians[0].ID},
+	}
+	b, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(Method, Path, bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	var ministerAP models.ArticlePolitician
+	if err := connector.GormDB().Where("politician_id = ?", minister.ID).First(&ministerAP).Error; err != nil {
+		t.Fatal(err)
+	}
+	if assert.NotNil(t, ministerAP.OccupationID) {
+		assert.Equal(t, occupation.ID, *ministerAP.OccupationID)
+	}
+	assert.Equal(t, "Ministre de l'Intérieur, gouvernement Attal", ministerAP.Office)
+
+	// no occupation at the event date
+	var otherAP models.ArticlePolitician
+	if err := connector.GormDB().Where("politician_id = ?", data.politicians[0].ID).First(&otherAP).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, otherAP.OccupationID)
+	assert.Empty(t, otherAP.Office)
+}
+
+func TestIntegration_PublishedArticle_CreatesRevision_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	// Seed a published article
+	ref := uuid.New()
+	published := &models.Article{
+		RedactorID: data.redactor.ID,
+		Title:      "Published title",
+		Body:       "",
+		Category:   models.ArticleCategoryLie,
+		EventDate:  time.Now(),
+		Reference:  ref,
+		Status:     models.ArticleStatusPublished,
+		Major:      1,
+		Minor:      0,
+	}
+	if err := connector.GormDB().Create(published).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app := newAppWithAuthedUser(data.redactor.ID)
+	Route(connector.GormDB()).Register(app)
+
+	payload := RequestDTO{
+		ID:        published.ID,
+		Title:     "Revised title which is long enough indeed",
+		EventDate: time.Now(),
+		Category:  models.ArticleCategoryLie,
+		Tags:      []string{"X"},
+	}
+	b, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(Method, Path, bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	// The published version stays live next to the new draft
+	var articles []models.Article
+	if err := connector.GormDB().Where("reference = ?", ref).Order("minor").Preload("Tags").Find(&articles).Error; err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 2, len(articles)) {
+		assert.Equal(t, published.ID, articles[0].ID)
+		assert.Equal(t, models.ArticleStatusPublished, articles[0].Status)
+		assert.Equal(t, "Published title", articles[0].Title)
+
+		assert.Equal(t, models.ArticleStatusDraft, articles[1].Status)
+		assert.Equal(t, payload.Title, articles[1].Title)
+		assert.Equal(t, int16(1), articles[1].Major)
+		assert.Equal(t, int16(1), articles[1].Minor)
+		assert.Equal(t, 1, len(articles[1].Tags))
+	}
+
+	// Only one revision may be in progress at a time
+	req = httptest.NewRequest(Method, Path, bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	res, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusConflict, res.StatusCode)
+}
+
+func TestIntegration_ChangeRequested_PublishTrue_MovesReviewThreads(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	moderator := &models.User{Email: "moderator@test.com", Tag: "moderator0123", Password: "x"}
+	if err := connector.GormDB().Create(moderator).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Seed an article sent back by a moderator, with a comment anchored to "10%"
+	ref := uuid.New()
+	old := &models.Article{
+		RedactorID:  data.redactor.ID,
+		ModeratorID: &moderator.ID,
+		Title:       "Change requested title",
+		Body:        "Le chômage a baissé de 10% en un an.",
+		Category:    models.ArticleCategoryLie,
+		EventDate:   time.Now(),
+		Reference:   ref,
+		Status:      models.ArticleStatusChangeRequested,
+		Major:       0,
+		Minor:       1,
+	}
+	if err := connector.GormDB().Create(old).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	anchorStart, anchorEnd := 23, 26
+	comment := &models.ReviewComment{
+		ArticleID:   old.ID,
+		AuthorID:    moderator.ID,
+		AnchorStart: &anchorStart,
+		AnchorEnd:   &anchorEnd,
+		Quote:       "10%",
+		Text:        "Quelle est la source de ce chiffre ?",
+	}
+	if err := connector.GormDB().Create(comment).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	app := newAppWithAuthedUser(data.redactor.ID)
+	Route(connector.GormDB()).Register(app)
+
+	payload := RequestDTO{
+		ID:            old.ID,
+		Title:         "Change requested title, now fixed",
+		EventDate:     time.Now(),
+		Category:      models.ArticleCategoryLie,
+		Body:          "Selon l'INSEE, le chômage a baissé de 10% en un an. " + strings.Repeat("a", 200),
+		Tags:          []string{"Emploi"},
+		PoliticianIDs: []uuid.UUID{data.politicians[0].ID},
+		Sources:       []SourceDTO{{URL: "https://www.insee.fr", Type: models.ArticleSourceTypePressArticle}},
+	}
+	b, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(Method, Path+"?publish=true", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	var newVersion models.Article
+	if err := connector.GormDB().Where("reference = ? AND status = ?", ref, models.ArticleStatusUnderReview).First(&newVersion).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var moved models.ReviewComment
+	if err := connector.GormDB().First(&moved, "id = ?", comment.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, newVersion.ID, moved.ArticleID)
+	if assert.NotNil(t, moved.AnchorStart) && assert.NotNil(t, moved.AnchorEnd) {
+		assert.Equal(t, 38, *moved.AnchorStart)
+		assert.Equal(t, 41, *moved.AnchorEnd)
+	}
+}
@@ -1,7 +1,10 @@
 package redactor_save_article
 
 import (
+	"errors"
 	"fmt"
+	"time"
+	"vdm/core/article_lifecycle"
 	"vdm/core/models"
 
 	"github.com/google/uuid"
@@ -10,9 +13,11 @@ import (
 
 type Repository interface {
 	findArticle(articleID, redactorID uuid.UUID) (models.Article, error)
-	archiveOldVersionAndCreateNew(article *models.Article) error
+	archiveOldVersionAndCreateNew(article *models.Article, oldStatus models.ArticleStatus) error
 	createArticle(article *models.Article) error
+	revisionInProgressExists(reference uuid.UUID) (bool, error)
 	updateArticle(article *models.Article) error
+	findOccupationAtDate(politicianID uuid.UUID, date time.Time) (*models.Occupation, error)
 }
 
 type repository struct {
@@ -29,9 +34,11 @@ func (r *repository) findArticle(articleID, redactorID uuid.UUID) (models.Articl
 	return article, nil
 }
 
-func (r *repository) archiveOldVersionAndCreateNew(article *models.Article) error {
+func (r *repository) archiveOldVersionAndCreateNew(article *models.Article, oldStatus models.ArticleStatus) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		if article.Major == 0 && article.Minor == 1 { // in this case, don't archive the first draft: just delete it
+		var events []*models.ArticleStatusEvent
+
+		if oldStatus == models.ArticleStatusDraft { // in this case, don't archive the draft: just delete it
 			if err := tx.Unscoped(). // hard delete
 							Delete(&models.Article{ID: article.ID}).Error; err != nil {
 				return fmt.Errorf("failed to delete first draft: %v", err)
@@ -42,18 +49,83 @@ func (r *repository) archiveOldVersionAndCreateNew(article *models.Article) erro
 				Update("status", models.ArticleStatusArchived).Error; err != nil {
 				return fmt.Errorf("failed to archive old version: %v", err)
 			}
+			events = append(events, article_lifecycle.NewEvent(*article, article.RedactorID, oldStatus, models.ArticleStatusArchived))
 		}
 
+		oldID := article.ID
 		article.ID = uuid.New()
 		if err := tx.Create(article).Error; err != nil {
 			return fmt.Errorf("failed to create new version: %v", err)
 		}
-		return nil
+
+		events = append(events, article_lifecycle.NewEvent(*article, article.RedactorID, oldStatus, article.Status))
+		if err := tx.Create(events).Error; err != nil {
+			return fmt.Errorf("failed to record status events: %v", err)
+		}
+
+		return moveReviewThreads(tx, oldID, *article)
 	})
 }
 
+// moveReviewThreads attaches the review threads of the previous version to the new one,
+// so the discussion survives resubmission. Body comments are re-anchored on their quote since the body may have changed.
+func moveReviewThreads(tx *gorm.DB, fromID uuid.UUID, to models.Article) error {
+	if err := tx.Model(&models.ReviewComment{}).
+		Where("article_id = ?", fromID).
+		Update("article_id", to.ID).Error; err != nil {
+		return fmt.Errorf("failed to move review comments: %v", err)
+	}
+
+	var anchored []models.ReviewComment
+	if err := tx.Where("article_id = ? AND parent_id IS NULL AND quote <> ''", to.ID).
+		Select("id", "anchor_start", "quote").
+		Find(&anchored).Error; err != nil {
+		return fmt.Errorf("failed to find anchored review comments: %v", err)
+	}
+
+	for _, comment := range anchored {
+		updates := map[string]any{"anchor_start": nil, "anchor_end": nil}
+		if start, end, ok := reanchor(to.Body, comment.Quote, comment.AnchorStart); ok {
+			updates = map[string]any{"anchor_start": start, "anchor_end": end}
+		}
+
+		if err := tx.Model(&models.ReviewComment{}).
+			Where("id = ?", comment.ID).
+			Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to re-anchor ReviewComment{ID=%s}: %v", comment.ID, err)
+		}
+	}
+
+	return nil
+}
+
 func (r *repository) createArticle(article *models.Article) error {
-	return r.db.Create(article).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(article).Error; err != nil {
+			return err
+		}
+
+		event := article_lifecycle.NewEvent(*article, article.RedactorID, article_lifecycle.Created, article.Status)
+		if err := tx.Create(event).Error; err != nil {
+			return fmt.Errorf("failed to record status event: %v", err)
+		}
+
+		return nil
+	})
+}
+
+// revisionInProgressExists reports whether the reference has a version that is neither published nor archived.
+func (r *repository) revisionInProgressExists(reference uuid.UUID) (bool, error) {
+	var count int64
+
+	if err := r.db.Model(&models.Article{}).
+		Where("reference = ? AND status NOT IN ?", reference,
+			[]models.ArticleStatus{models.ArticleStatusPublished, models.ArticleStatusArchived}).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to count revisions of article %s: %v", reference, err)
+	}
+
+	return count > 0, nil
 }
 
 func (r *repository) updateArticle(article *models.Article) error {
@@ -65,6 +137,8 @@ func (r *repository) updateArticle(article *models.Article) error {
 				"body":       article.Body,
 				"event_date": article.EventDate,
 				"category":   article.Category,
+
+				"correction_note": article.CorrectionNote,
 			}).Error; err != nil {
 			return fmt.Errorf("failed to update article: %v", err)
 		}
@@ -102,3 +176,25 @@ func (r *repository) updateArticle(article *models.Article) error {
 		return nil
 	})
 }
+
+// findOccupationAtDate favors a presidential mandate over a government occupation, then the most recent one.
+func (r *repository) findOccupationAtDate(politicianID uuid.UUID, date time.Time) (*models.Occupation, error) {
+	var occupation models.Occupation
+
+	if err := r.db.Where("politician_id = ? AND start_date <= ? AND (end_date IS NULL OR end_date > ?)", politicianID, date, date).
+		Order("presidential_reference IS NULL, start_date DESC").
+		Preload("Government").
+		Preload("Government.PrimeMinister", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "first_name", "last_name")
+		}).
+		First(&occupation).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &occupation, nil
+}
@@ -2,6 +2,9 @@ package redactor_save_article
 
 import (
 	"fmt"
+	"time"
+	"unicode/utf8"
+	"vdm/core/article_lifecycle"
 	"vdm/core/models"
 
 	"github.com/gofiber/fiber/v2"
@@ -10,6 +13,9 @@ import (
 
 // TODO: test EVERY use case
 
+// minCorrectionNoteLength is the minimum number of characters of the public note explaining why a published article was corrected
+const minCorrectionNoteLength = 20
+
 type Service interface {
 	saveArticleForRedactor(publish bool, newArticle models.Article) (uuid.UUID, error)
 }
@@ -19,14 +25,17 @@ type service struct {
 }
 
 func (s *service) saveArticleForRedactor(publish bool, newArticle models.Article) (uuid.UUID, error) {
+	if err := s.resolveOffices(&newArticle); err != nil {
+		return uuid.Nil, err
+	}
+
 	if newArticle.ID == uuid.Nil {
 		newArticle.Reference = uuid.New()
+		newArticle.Status = nextStatus(publish, models.ArticleStatusDraft)
 		if publish {
-			newArticle.Status = models.ArticleStatusUnderReview
 			newArticle.Minor = 1 // increment minor version each time user submits for publication
-		} else {
-			newArticle.Status = models.ArticleStatusDraft
 		}
+		newArticle.CorrectionNote = "" // only versions superseding a published one carry a correction note
 		return newArticle.Reference, s.repo.createArticle(&newArticle)
 	}
 
@@ -35,10 +44,18 @@ func (s *service) saveArticleForRedactor(publish bool, newArticle models.Article
 		return uuid.Nil, err
 	}
 
-	if oldArticle.Status != models.ArticleStatusDraft &&
-		oldArticle.Status != models.ArticleStatusChangeRequested { // do NOT allow update if status is not DRAFT or CHANGE_REQUESTED
-		return uuid.Nil, &fiber.Error{Code: fiber.StatusConflict, Message: fmt.Sprintf("expected one of [%s, %s], got [%s]",
-			models.ArticleStatusDraft, models.ArticleStatusChangeRequested, oldArticle.Status)}
+	if publish && oldArticle.Major > 0 && utf8.RuneCountInString(newArticle.CorrectionNote) < minCorrectionNoteLength {
+		return uuid.Nil, &fiber.Error{Code: fiber.StatusBadRequest, Message: fmt.Sprintf(
+			"a correction note of at least %d characters is required to resubmit a published article", minCorrectionNoteLength)}
+	}
+
+	if oldArticle.Status == models.ArticleStatusPublished {
+		return s.createRevision(publish, oldArticle, newArticle)
+	}
+
+	newArticle.Status = nextStatus(publish, oldArticle.Status)
+	if err = article_lifecycle.Transition(models.RoleRedactor, oldArticle.Status, newArticle.Status); err != nil {
+		return uuid.Nil, err
 	}
 
 	if !publish {
@@ -47,14 +64,120 @@ func (s *service) saveArticleForRedactor(publish bool, newArticle models.Article
 	}
 
 	newArticle.ModeratorID = oldArticle.ModeratorID
+	if newArticle.ModeratorID != nil { // the moderator who requested changes gets a fresh claim on the new version
+		now := time.Now()
+		newArticle.ClaimedAt = &now
+	}
 	newArticle.Reference = oldArticle.Reference // set reference to track version history
 	newArticle.Major = oldArticle.Major
-	newArticle.Minor = oldArticle.Minor + 1 // increment minor version each time user submits for publication
-	newArticle.Status = models.ArticleStatusUnderReview
 
-	if err = s.repo.archiveOldVersionAndCreateNew(&newArticle); err != nil {
+	// a draft has never been reviewed, so it is replaced by the first submission instead of being archived
+	if oldArticle.Status == models.ArticleStatusDraft {
+		newArticle.Minor = 1
+	} else {
+		newArticle.Minor = oldArticle.Minor + 1 // increment minor version each time user submits for publication
+	}
+
+	if err = s.repo.archiveOldVersionAndCreateNew(&newArticle, oldArticle.Status); err != nil {
+		return uuid.Nil, err
+	}
+
+	return newArticle.Reference, nil
+}
+
+// createRevision opens a new version of a published article under the same reference.
+// The published version stays live until a moderator publishes the revision.
+func (s *service) createRevision(publish bool, published models.Article, newArticle models.Article) (uuid.UUID, error) {
+	inProgress, err := s.repo.revisionInProgressExists(published.Reference)
+	if err != nil {
 		return uuid.Nil, err
 	}
+	if inProgress {
+		return uuid.Nil, &fiber.Error{Code: fiber.StatusConflict, Message: fmt.Sprintf(
+			"a revision of article %s is already in progress", published.Reference)}
+	}
+
+	newArticle.ID = uuid.New()
+	newArticle.Reference = published.Reference
+	newArticle.Major = published.Major
+	newArticle.Minor = 1 // minor 0 is the published version
+	newArticle.Status = nextStatus(publish, models.ArticleStatusDraft)
+
+	if err = s.repo.createArticle(&newArticle); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create revision of article %s: %v", published.Reference, err)
+	}
 
 	return newArticle.Reference, nil
 }
+
+// nextStatus is the status of the saved version: submitting sends it to review, saving keeps the current status.
+func nextStatus(publish bool, current models.ArticleStatus) models.ArticleStatus {
+	if publish {
+		return models.ArticleStatusUnderReview
+	}
+	return current
+}
+
+// resolveOffices stores on each ArticlePolitician the occupation held by the politician at the article's event date.
+func (s *service) resolveOffices(article *models.Article) error {
+	for _, ap := range article.ArticlePoliticians {
+		occupation, err := s.repo.findOccupationAtDate(ap.PoliticianID, article.EventDate)
+		if err != nil {
+			return fmt.Errorf("failed to find occupation of politician %s: %v", ap.PoliticianID, err)
+		}
+		if occupation == nil {
+			continue
+		}
+
+		ap.OccupationID = &occupation.ID
+		ap.Office = officeLabel(*occupation)
+	}
+
+	return nil
+}
+
+// officeLabel formats an occupation for display, e.g. "Ministre de l'Intérieur, gouvernement Attal".
+func officeLabel(occupation models.Occupation) string {
+	if occupation.Government == nil || occupation.Government.PrimeMinister == nil {
+		return occupation.Title
+	}
+
+	return fmt.Sprintf("%s, gouvernement %s", occupation.Title, occupation.Government.PrimeMinister.LastName)
+}
+
+// reanchor finds quote in body, favoring the occurrence closest to the previous anchor.
+// Offsets are expressed in characters, like the anchors sent by moderators.
+func reanchor(body, quote string, previousStart *int) (start, end int, ok bool) {
+	runes, quoteLen := []rune(body), len([]rune(quote))
+	if quoteLen == 0 {
+		return 0, 0, false
+	}
+
+	hint := 0
+	if previousStart != nil {
+		hint = *previousStart
+	}
+
+	best := -1
+	for i := 0; i+quoteLen <= len(runes); i++ {
+		if string(runes[i:i+quoteLen]) != quote {
+			continue
+		}
+		if best < 0 || abs(i-hint) < abs(best-hint) {
+			best = i
+		}
+	}
+
+	if best < 0 {
+		return 0, 0, false
+	}
+
+	return best, best + quoteLen, true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,146 @@
+package redactor_save_article
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfficeLabel(t *testing.T) {
+	presidentialReference := int16(25)
+
+	assert.Equal(t, "Président de la République", officeLabel(models.Occupation{
+		Title:                 "Président de la République",
+		PresidentialReference: &presidentialReference,
+	}))
+
+	assert.Equal(t, "Ministre de l'Intérieur, gouvernement Attal", officeLabel(models.Occupation{
+		Title:      "Ministre de l'Intérieur",
+		Government: &models.Government{PrimeMinister: &models.Politician{FirstName: "Gabriel", LastName: "Attal"}},
+	}))
+}
+
+type stubRepository struct {
+	oldArticle models.Article
+	inProgress bool
+	archived   bool
+	created    *models.Article
+}
+
+func (r *stubRepository) findArticle(articleID, redactorID uuid.UUID) (models.Article, error) {
+	return r.oldArticle, nil
+}
+
+func (r *stubRepository) archiveOldVersionAndCreateNew(article *models.Article, oldStatus models.ArticleStatus) error {
+	r.archived = true
+	return nil
+}
+
+func (r *stubRepository) createArticle(article *models.Article) error {
+	r.created = article
+	return nil
+}
+
+func (r *stubRepository) revisionInProgressExists(reference uuid.UUID) (bool, error) {
+	return r.inProgress, nil
+}
+
+func (r *stubRepository) updateArticle(article *models.Article) error { return nil }
+
+func (r *stubRepository) findOccupationAtDate(politicianID uuid.UUID, date time.Time) (*models.Occupation, error) {
+	return nil, nil
+}
+
+func TestService_CorrectionNoteRequiredForPublishedArticle(t *testing.T) {
+	repo := &stubRepository{oldArticle: models.Article{
+		ID:     uuid.New(),
+		Status: models.ArticleStatusDraft,
+		Major:  1,
+	}}
+	svc := &service{repo}
+
+	_, err := svc.saveArticleForRedactor(true, models.Article{ID: repo.oldArticle.ID, CorrectionNote: "Fixed a typo"})
+	var fiberErr *fiber.Error
+	if assert.ErrorAs(t, err, &fiberErr) {
+		assert.Equal(t, fiber.StatusBadRequest, fiberErr.Code)
+	}
+	assert.False(t, repo.archived)
+
+	// the length is counted in characters, not bytes
+	_, err = svc.saveArticleForRedactor(true, models.Article{ID: repo.oldArticle.ID, CorrectionNote: strings.Repeat("é", minCorrectionNoteLength-1)})
+	if assert.ErrorAs(t, err, &fiberErr) {
+		assert.Equal(t, fiber.StatusBadRequest, fiberErr.Code)
+	}
+	assert.False(t, repo.archived)
+
+	_, err = svc.saveArticleForRedactor(true, models.Article{
+		ID:             repo.oldArticle.ID,
+		CorrectionNote: "Le chiffre cité était tiré d'un rapport périmé.",
+	})
+	assert.NoError(t, err)
+	assert.True(t, repo.archived)
+}
+
+func TestService_RevisionOfPublishedArticle(t *testing.T) {
+	repo := &stubRepository{oldArticle: models.Article{
+		ID:        uuid.New(),
+		Reference: uuid.New(),
+		Status:    models.ArticleStatusPublished,
+		Major:     2,
+	}}
+	svc := &service{repo}
+
+	reference, err := svc.saveArticleForRedactor(false, models.Article{ID: repo.oldArticle.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, repo.oldArticle.Reference, reference)
+	if assert.NotNil(t, repo.created) {
+		assert.NotEqual(t, repo.oldArticle.ID, repo.created.ID)
+		assert.Equal(t, models.ArticleStatusDraft, repo.created.Status)
+		assert.Equal(t, int16(2), repo.created.Major)
+		assert.Equal(t, int16(1), repo.created.Minor)
+	}
+	assert.False(t, repo.archived)
+
+	repo.inProgress = true
+	_, err = svc.saveArticleForRedactor(false, models.Article{ID: repo.oldArticle.ID})
+	var fiberErr *fiber.Error
+	if assert.ErrorAs(t, err, &fiberErr) {
+		assert.Equal(t, fiber.StatusConflict, fiberErr.Code)
+	}
+}
+
+func TestService_ErrConflict_ForbiddenTransition(t *testing.T) {
+	for _, status := range []models.ArticleStatus{models.ArticleStatusUnderReview, models.ArticleStatusArchived} {
+		repo := &stubRepository{oldArticle: models.Article{ID: uuid.New(), Status: status}}
+		svc := &service{repo}
+
+		_, err := svc.saveArticleForRedactor(false, models.Article{ID: repo.oldArticle.ID})
+		var fiberErr *fiber.Error
+		if assert.ErrorAs(t, err, &fiberErr) {
+			assert.Equal(t, fiber.StatusConflict, fiberErr.Code)
+		}
+	}
+}
+
+func TestReanchor(t *testing.T) {
+	previousStart := 25
+
+	// the quote moved after an edit earlier in the body
+	start, end, ok := reanchor("Selon l'INSEE, le chômage a baissé de 10% en un an.", "10%", &previousStart)
+	assert.True(t, ok)
+	assert.Equal(t, 38, start)
+	assert.Equal(t, 41, end)
+
+	// the closest occurrence wins
+	start, _, ok = reanchor("10% ici, 10% là.", "10%", &previousStart)
+	assert.True(t, ok)
+	assert.Equal(t, 9, start)
+
+	_, _, ok = reanchor("Le chômage a baissé en un an.", "10%", &previousStart)
+	assert.False(t, ok)
+}
@@ -0,0 +1,13 @@
+package redactor_save_review_comment
+
+import "github.com/google/uuid"
+
+// RequestDTO is a reply: only moderators open new threads.
+type RequestDTO struct {
+	ParentID uuid.UUID `json:"parentId" validate:"required"`
+	Text     string    `json:"text" validate:"required,max=2000"`
+}
+
+type ResponseDTO struct {
+	ID uuid.UUID `json:"id"`
+}
@@ -0,0 +1,45 @@
+package redactor_save_review_comment
+
+import (
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	saveReviewCommentForRedactor(c *fiber.Ctx) error
+}
+
+type handler struct {
+	svc Service
+}
+
+func (h *handler) saveReviewCommentForRedactor(c *fiber.Ctx) error {
+	authedUser, ok := c.Locals("authedUser").(locals.AuthedUser)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "can't locals authed user"}
+	}
+
+	articleID, err := uuid.Parse(c.Params(local_keys.ArticleID))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid article id"}
+	}
+
+	var reqDTO RequestDTO
+	if err = c.BodyParser(&reqDTO); err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid request body"}
+	}
+	if err = validation.Validate(reqDTO); err != nil {
+		return err
+	}
+
+	commentID, err := h.svc.saveReply(authedUser.ID, articleID, reqDTO)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(ResponseDTO{ID: commentID})
+}
@@ -0,0 +1,157 @@
+package redactor_save_review_comment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	redactor *models.User
+	article  *models.Article
+	root     *models.ReviewComment
+	reply    *models.ReviewComment
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.redactor = &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(data.redactor).Error; err != nil {
+		return
+	}
+
+	moderator := &models.User{Email: "moderator@test.com", Tag: "moderator0123", Password: "x"}
+	if err = connector.GormDB().Create(moderator).Error; err != nil {
+		return
+	}
+
+	data.article = &models.Article{
+		RedactorID:  data.redactor.ID,
+		ModeratorID: &moderator.ID,
+		Title:       "Change requested",
+		Body:        "Le chômage a baissé de 10% en un an.",
+		Status:      models.ArticleStatusChangeRequested,
+		Reference:   uuid.New(),
+		Minor:       1,
+	}
+	if err = connector.GormDB().Create(data.article).Error; err != nil {
+		return
+	}
+
+	anchorStart, anchorEnd := 23, 26
+	data.root = &models.ReviewComment{
+		ArticleID:   data.article.ID,
+		AuthorID:    moderator.ID,
+		AnchorStart: &anchorStart,
+		AnchorEnd:   &anchorEnd,
+		Quote:       "10%",
+		Text:        "Quelle est la source de ce chiffre ?",
+	}
+	if err = connector.GormDB().Create(data.root).Error; err != nil {
+		return
+	}
+
+	data.reply = &models.ReviewComment{
+		ArticleID: data.article.ID,
+		ParentID:  &data.root.ID,
+		AuthorID:  moderator.ID,
+		Text:      "Et sur quelle période ?",
+	}
+	err = connector.GormDB().Create(data.reply).Error
+	return
+}
+
+func newAppWithAuthedUser(userID uuid.UUID) *fiber.App {
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("authedUser", locals.AuthedUser{ID: userID})
+		return c.Next()
+	})
+	return app
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := newAppWithAuthedUser(data.redactor.ID)
+	Route(connector.GormDB()).Register(app)
+
+	// replying to a reply adds to the root thread
+	b, _ := json.Marshal(RequestDTO{ParentID: data.reply.ID, Text: "Le rapport annuel de l'INSEE."})
+	req := httptest.NewRequest(Method, "/"+data.article.ID.String()+"/comments", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != fiber.StatusCreated {
+		t.Fatalf("Expected status code 201, got %d", res.StatusCode)
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resDTO ResponseDTO
+	if err = json.Unmarshal(resBody, &resDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	var saved models.ReviewComment
+	if err := connector.GormDB().First(&saved, "id = ?", resDTO.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, data.redactor.ID, saved.AuthorID)
+	if assert.NotNil(t, saved.ParentID) {
+		assert.Equal(t, data.root.ID, *saved.ParentID)
+	}
+}
+
+func TestIntegration_ErrNotFound(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := newAppWithAuthedUser(data.redactor.ID)
+	Route(connector.GormDB()).Register(app)
+
+	b, _ := json.Marshal(RequestDTO{ParentID: uuid.New(), Text: "Réponse"})
+	req := httptest.NewRequest(Method, "/"+data.article.ID.String()+"/comments", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
@@ -0,0 +1,57 @@
+package redactor_save_review_comment
+
+import (
+	"errors"
+	"fmt"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	redactorArticleExists(articleID, redactorID uuid.UUID) (bool, error)
+	findComment(commentID, articleID uuid.UUID) (*models.ReviewComment, error)
+	createComment(comment *models.ReviewComment) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// redactorArticleExists ignores archived versions, whose threads have moved to the next version.
+func (r *repository) redactorArticleExists(articleID, redactorID uuid.UUID) (bool, error) {
+	var count int64
+
+	if err := r.db.Model(&models.Article{}).
+		Where("id = ? AND redactor_id = ? AND status <> ?", articleID, redactorID, models.ArticleStatusArchived).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to count Article{ID=%s RedactorID=%s}: %v", articleID, redactorID, err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *repository) findComment(commentID, articleID uuid.UUID) (*models.ReviewComment, error) {
+	var comment models.ReviewComment
+
+	if err := r.db.Where("id = ? AND article_id = ?", commentID, articleID).
+		Select("id", "parent_id").
+		First(&comment).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to find ReviewComment{ID=%s}: %v", commentID, err)
+	}
+
+	return &comment, nil
+}
+
+func (r *repository) createComment(comment *models.ReviewComment) error {
+	if err := r.db.Create(comment).Error; err != nil {
+		return fmt.Errorf("failed to create review comment: %v", err)
+	}
+	return nil
+}
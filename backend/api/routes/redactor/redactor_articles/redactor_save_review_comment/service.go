@@ -0,0 +1,51 @@
+package redactor_save_review_comment
+
+import (
+	"fmt"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	saveReply(redactorID, articleID uuid.UUID, reqDTO RequestDTO) (uuid.UUID, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func (s *service) saveReply(redactorID, articleID uuid.UUID, reqDTO RequestDTO) (uuid.UUID, error) {
+	exists, err := s.repo.redactorArticleExists(articleID, redactorID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !exists {
+		return uuid.Nil, &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("article with id %s not found", articleID)}
+	}
+
+	parent, err := s.repo.findComment(reqDTO.ParentID, articleID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if parent == nil {
+		return uuid.Nil, &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("comment with id %s not found", reqDTO.ParentID)}
+	}
+
+	reply := &models.ReviewComment{
+		ArticleID: articleID,
+		ParentID:  &parent.ID,
+		AuthorID:  redactorID,
+		Text:      reqDTO.Text,
+	}
+	if parent.ParentID != nil { // keep threads flat: replying to a reply adds to the thread of its root comment
+		reply.ParentID = parent.ParentID
+	}
+
+	if err = s.repo.createComment(reply); err != nil {
+		return uuid.Nil, err
+	}
+
+	return reply.ID, nil
+}
@@ -0,0 +1,21 @@
+package sessions
+
+import (
+	"vdm/api/routes/sessions/routes/get_sessions"
+	"vdm/api/routes/sessions/routes/revoke_session"
+	"vdm/core/dependencies"
+	"vdm/core/fiberx"
+)
+
+const Prefix = "/sessions"
+
+func Group(deps *dependencies.Dependencies) *fiberx.Group {
+	group := fiberx.NewGroup(Prefix)
+
+	group.Add(
+		get_sessions.Route(deps.GormDB()),
+		revoke_session.Route(deps.GormDB()),
+	)
+
+	return group
+}
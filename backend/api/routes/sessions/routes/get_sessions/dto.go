@@ -0,0 +1,18 @@
+package get_sessions
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SessionDTO struct {
+	ID          uuid.UUID `json:"id"`
+	DeviceLabel string    `json:"deviceLabel"`
+	UserAgent   string    `json:"userAgent"`
+	IP          string    `json:"ip"`
+	CreatedAt   time.Time `json:"createdAt"`
+	LastUsedAt  time.Time `json:"lastUsedAt"`
+	Expiry      time.Time `json:"expiry"`
+	Current     bool      `json:"current"`
+}
@@ -0,0 +1,45 @@
+package get_sessions
+
+import (
+	"fmt"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	getSessions(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) getSessions(c *fiber.Ctx) error {
+	authedUser, ok := c.Locals(local_keys.AuthedUser).(locals.AuthedUser)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "can't locals authed user"}
+	}
+
+	tokens, err := h.repo.getSessions(authedUser.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get sessions: %v", err)
+	}
+
+	sessions := make([]SessionDTO, len(tokens))
+	for i, token := range tokens {
+		sessions[i] = SessionDTO{
+			ID:          *token.FamilyID,
+			DeviceLabel: token.DeviceLabel,
+			UserAgent:   token.UserAgent,
+			IP:          token.IP,
+			CreatedAt:   token.CreatedAt,
+			LastUsedAt:  token.LastUsedAt,
+			Expiry:      token.Expiry,
+			Current:     *token.FamilyID == authedUser.SessionID,
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(sessions)
+}
@@ -0,0 +1,99 @@
+package get_sessions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	user    *models.User
+	current uuid.UUID
+	other   uuid.UUID
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.user = &models.User{Email: "user@test.com", Tag: "user0123", Password: "x"}
+	otherUser := &models.User{Email: "other@test.com", Tag: "other0123", Password: "x"}
+	if err = connector.GormDB().Create([]*models.User{data.user, otherUser}).Error; err != nil {
+		return
+	}
+
+	data.current, data.other = uuid.New(), uuid.New()
+	expired, foreign := uuid.New(), uuid.New()
+	now := time.Now()
+	consumedAt := now.Add(-time.Minute)
+
+	err = connector.GormDB().Create([]*models.UserToken{
+		{UserID: data.user.ID, Hash: "current", Category: models.UserTokenCategoryRefresh, Expiry: now.Add(time.Hour),
+			FamilyID: &data.current, DeviceLabel: "Firefox on Windows", LastUsedAt: now},
+		// rotated token of the current session
+		{UserID: data.user.ID, Hash: "consumed", Category: models.UserTokenCategoryRefresh, Expiry: now.Add(time.Hour),
+			FamilyID: &data.current, ConsumedAt: &consumedAt},
+		{UserID: data.user.ID, Hash: "other", Category: models.UserTokenCategoryRefresh, Expiry: now.Add(time.Hour),
+			FamilyID: &data.other, DeviceLabel: "Safari on iOS", LastUsedAt: now.Add(-time.Hour)},
+		{UserID: data.user.ID, Hash: "expired", Category: models.UserTokenCategoryRefresh, Expiry: now.Add(-time.Minute),
+			FamilyID: &expired},
+		{UserID: otherUser.ID, Hash: "foreign", Category: models.UserTokenCategoryRefresh, Expiry: now.Add(time.Hour),
+			FamilyID: &foreign},
+	}).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(local_keys.AuthedUser, locals.AuthedUser{ID: data.user.ID, SessionID: data.current})
+		return c.Next()
+	})
+	Route(connector.GormDB()).Register(app)
+
+	res, err := app.Test(httptest.NewRequest(Method, Path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	var sessions []SessionDTO
+	if err = json.NewDecoder(res.Body).Decode(&sessions); err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, sessions, 2) {
+		assert.Equal(t, data.current, sessions[0].ID)
+		assert.Equal(t, "Firefox on Windows", sessions[0].DeviceLabel)
+		assert.True(t, sessions[0].Current)
+
+		assert.Equal(t, data.other, sessions[1].ID)
+		assert.Equal(t, "Safari on iOS", sessions[1].DeviceLabel)
+		assert.False(t, sessions[1].Current)
+	}
+}
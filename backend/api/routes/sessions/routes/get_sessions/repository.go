@@ -0,0 +1,33 @@
+package get_sessions
+
+import (
+	"time"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	getSessions(userID uuid.UUID) ([]models.UserToken, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// getSessions returns the unconsumed refresh token of each active session of the user, the most recently used first
+func (r *repository) getSessions(userID uuid.UUID) ([]models.UserToken, error) {
+	var tokens []models.UserToken
+
+	if err := r.db.Model(&models.UserToken{}).
+		Where("user_id = ? AND category = ? AND family_id IS NOT NULL AND consumed_at IS NULL AND expiry > ?",
+			userID, models.UserTokenCategoryRefresh, time.Now()).
+		Select("family_id", "device_label", "user_agent", "ip", "created_at", "last_used_at", "expiry").
+		Order("last_used_at DESC").
+		Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,41 @@
+package revoke_session
+
+import (
+	"fmt"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	revokeSession(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) revokeSession(c *fiber.Ctx) error {
+	authedUser, ok := c.Locals(local_keys.AuthedUser).(locals.AuthedUser)
+	if !ok {
+		return &fiber.Error{Code: fiber.StatusInternalServerError, Message: "can't locals authed user"}
+	}
+
+	sessionID, err := uuid.Parse(c.Params(local_keys.SessionID))
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid session id"}
+	}
+
+	// sessions of other users are reported as not found
+	found, err := h.repo.deleteSession(authedUser.ID, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %v", err)
+	}
+	if !found {
+		return &fiber.Error{Code: fiber.StatusNotFound, Message: fmt.Sprintf("session %s not found", sessionID)}
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
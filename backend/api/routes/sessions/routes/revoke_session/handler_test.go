@@ -0,0 +1,37 @@
+package revoke_session
+
+import (
+	"net/http/httptest"
+	"testing"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type nullRepository struct{}
+
+func (nullRepository) deleteSession(userID, sessionID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func TestHandler_ErrBadRequest(t *testing.T) {
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(local_keys.AuthedUser, locals.AuthedUser{ID: uuid.New()})
+		return c.Next()
+	})
+	h := &handler{repo: nullRepository{}}
+	app.Add(Method, Path, h.revokeSession)
+
+	res, err := app.Test(httptest.NewRequest(Method, "/not-a-uuid", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
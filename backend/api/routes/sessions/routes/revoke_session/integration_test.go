@@ -0,0 +1,109 @@
+package revoke_session
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/locals"
+	"vdm/core/locals/local_keys"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	user      *models.User
+	otherUser *models.User
+	session   uuid.UUID
+	kept      uuid.UUID
+	foreign   uuid.UUID
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.user = &models.User{Email: "user@test.com", Tag: "user0123", Password: "x"}
+	data.otherUser = &models.User{Email: "other@test.com", Tag: "other0123", Password: "x"}
+	if err = connector.GormDB().Create([]*models.User{data.user, data.otherUser}).Error; err != nil {
+		return
+	}
+
+	data.session, data.kept, data.foreign = uuid.New(), uuid.New(), uuid.New()
+	expiry := time.Now().Add(time.Hour)
+
+	err = connector.GormDB().Create([]*models.UserToken{
+		{UserID: data.user.ID, Hash: "session", Category: models.UserTokenCategoryRefresh, Expiry: expiry, FamilyID: &data.session},
+		{UserID: data.user.ID, Hash: "kept", Category: models.UserTokenCategoryRefresh, Expiry: expiry, FamilyID: &data.kept},
+		{UserID: data.otherUser.ID, Hash: "foreign", Category: models.UserTokenCategoryRefresh, Expiry: expiry, FamilyID: &data.foreign},
+	}).Error
+	return
+}
+
+func newApp(connector database.Connector, userID uuid.UUID) *fiber.App {
+	app := fiberx.NewApp()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(local_keys.AuthedUser, locals.AuthedUser{ID: userID})
+		return c.Next()
+	})
+	Route(connector.GormDB()).Register(app)
+	return app
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := newApp(connector, data.user.ID)
+
+	res, err := app.Test(httptest.NewRequest(Method, "/"+data.session.String(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNoContent, res.StatusCode)
+
+	var hashes []string
+	if err = connector.GormDB().Model(&models.UserToken{}).Order("hash").Pluck("hash", &hashes).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"foreign", "kept"}, hashes)
+}
+
+func TestIntegration_ErrNotFound(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := newApp(connector, data.user.ID)
+
+	// the session of another user can't be revoked
+	res, err := app.Test(httptest.NewRequest(Method, "/"+data.foreign.String(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+
+	var count int64
+	if err = connector.GormDB().Model(&models.UserToken{}).Where("family_id = ?", data.foreign).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,23 @@
+package revoke_session
+
+import (
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	deleteSession(userID, sessionID uuid.UUID) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// deleteSession deletes the refresh tokens of the session and tells whether the user had such a session
+func (r *repository) deleteSession(userID, sessionID uuid.UUID) (bool, error) {
+	res := r.db.Where("user_id = ? AND category = ? AND family_id = ?", userID, models.UserTokenCategoryRefresh, sessionID).
+		Delete(&models.UserToken{})
+	return res.RowsAffected > 0, res.Error
+}
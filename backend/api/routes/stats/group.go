@@ -0,0 +1,33 @@
+package stats
+
+import (
+	"slices"
+	"vdm/api/routes/stats/routes/get_government_stats"
+	"vdm/api/routes/stats/routes/get_monthly_stats"
+	"vdm/api/routes/stats/routes/get_party_stats"
+	"vdm/api/routes/stats/routes/get_politician_stats"
+	"vdm/core/dependencies"
+	"vdm/core/fiberx"
+	"vdm/core/http_cache"
+)
+
+const Prefix = "/stats"
+
+func Group(deps *dependencies.Dependencies) *fiberx.Group {
+	group := fiberx.NewGroup(Prefix)
+
+	group.Add(
+		// stats aggregate published articles per politician, government and party
+		fiberx.NewMiddleware(deps.Cache.Middleware(
+			slices.Concat(get_politician_stats.QueryParams, get_monthly_stats.QueryParams),
+			http_cache.ScopeArticles, http_cache.ScopePoliticians,
+		)),
+
+		get_politician_stats.Route(deps.GormDB()),
+		get_government_stats.Route(deps.GormDB()),
+		get_party_stats.Route(deps.GormDB()),
+		get_monthly_stats.Route(deps.GormDB()),
+	)
+
+	return group
+}
@@ -0,0 +1,42 @@
+package get_government_stats
+
+import (
+	"time"
+	"vdm/core/dto/response_dto"
+)
+
+type GovernmentStatsDTO struct {
+	Reference     int16                    `json:"reference"`
+	PrimeMinister *response_dto.Politician `json:"primeMinister,omitempty"`
+	StartDate     time.Time                `json:"startDate"`
+	EndDate       *time.Time               `json:"endDate,omitempty"`
+	response_dto.CategoryCounts
+}
+
+type ResponseDTO []GovernmentStatsDTO
+
+func newResponseDTO(rows []governmentCounts) ResponseDTO {
+	respDTO := make(ResponseDTO, len(rows))
+
+	for i, row := range rows {
+		respDTO[i] = GovernmentStatsDTO{
+			Reference: row.Reference,
+			StartDate: row.StartDate,
+			EndDate:   row.EndDate,
+			CategoryCounts: response_dto.CategoryCounts{
+				Lies:       row.Lies,
+				Falsehoods: row.Falsehoods,
+				Total:      row.Total,
+			},
+		}
+
+		if row.PrimeMinisterID != nil {
+			respDTO[i].PrimeMinister = &response_dto.Politician{
+				ID:       *row.PrimeMinisterID,
+				FullName: row.PrimeMinisterFirstName + " " + row.PrimeMinisterLastName,
+			}
+		}
+	}
+
+	return respDTO
+}
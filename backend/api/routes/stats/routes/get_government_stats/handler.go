@@ -0,0 +1,24 @@
+package get_government_stats
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	getGovernmentStats(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) getGovernmentStats(c *fiber.Ctx) error {
+	rows, err := h.repo.getGovernmentCounts()
+	if err != nil {
+		return fmt.Errorf("failed to get government counts: %v", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newResponseDTO(rows))
+}
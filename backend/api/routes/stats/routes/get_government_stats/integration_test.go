@@ -0,0 +1,139 @@
+package get_government_stats
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	politicians := []*models.Politician{
+		{FirstName: "Élisabeth", LastName: "Borne"},
+		{FirstName: "Gabriel", LastName: "Attal"},
+		{FirstName: "Gérald", LastName: "Darmanin"},
+	}
+	if err = connector.GormDB().Create(&politicians).Error; err != nil {
+		return
+	}
+
+	governments := []*models.Government{
+		{
+			PrimeMinisterID: politicians[0].ID,
+			Reference:       43,
+			StartDate:       time.Date(2022, 5, 16, 0, 0, 0, 0, time.UTC),
+			EndDate:         sql.NullTime{Time: time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+		{
+			PrimeMinisterID: politicians[1].ID,
+			Reference:       44,
+			StartDate:       time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+			EndDate:         sql.NullTime{Time: time.Date(2024, 9, 21, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+	}
+	if err = connector.GormDB().Create(&governments).Error; err != nil {
+		return
+	}
+
+	occupations := []*models.Occupation{
+		{PoliticianID: politicians[1].ID, GovernmentID: &governments[1].ID, Code: "PM", Title: "Premier ministre", StartDate: governments[1].StartDate},
+		{PoliticianID: politicians[2].ID, GovernmentID: &governments[1].ID, Code: "MIN_INT", Title: "Ministre de l'Intérieur", StartDate: governments[1].StartDate},
+	}
+	if err = connector.GormDB().Create(&occupations).Error; err != nil {
+		return
+	}
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	articles := []*models.Article{
+		{Category: models.ArticleCategoryLie, Status: models.ArticleStatusPublished},
+		{Category: models.ArticleCategoryFalsehood, Status: models.ArticleStatusPublished},
+		{Category: models.ArticleCategoryLie, Status: models.ArticleStatusDraft},
+	}
+	for _, article := range articles {
+		article.RedactorID = redactor.ID
+		article.Title = "Title"
+		article.Reference = uuid.New()
+		article.EventDate = time.Date(2024, 6, 9, 20, 0, 0, 0, time.UTC)
+	}
+	if err = connector.GormDB().Create(&articles).Error; err != nil {
+		return
+	}
+
+	err = connector.GormDB().Create([]*models.ArticlePolitician{
+		// both members of the government are involved in the first article, which is counted once
+		{ArticleID: articles[0].ID, PoliticianID: politicians[1].ID, OccupationID: &occupations[0].ID},
+		{ArticleID: articles[0].ID, PoliticianID: politicians[2].ID, OccupationID: &occupations[1].ID},
+		{ArticleID: articles[1].ID, PoliticianID: politicians[2].ID, OccupationID: &occupations[1].ID},
+		{ArticleID: articles[2].ID, PoliticianID: politicians[2].ID, OccupationID: &occupations[1].ID},
+	}).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	res, err := app.Test(httptest.NewRequest(Method, Path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var respDTO ResponseDTO
+	if err = json.Unmarshal(body, &respDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	if !assert.Len(t, respDTO, 2) {
+		return
+	}
+
+	assert.Equal(t, int16(44), respDTO[0].Reference)
+	if assert.NotNil(t, respDTO[0].PrimeMinister) {
+		assert.Equal(t, "Gabriel Attal", respDTO[0].PrimeMinister.FullName)
+	}
+	assert.Equal(t, int64(1), respDTO[0].Lies)
+	assert.Equal(t, int64(1), respDTO[0].Falsehoods)
+	assert.Equal(t, int64(2), respDTO[0].Total)
+
+	assert.Equal(t, int16(43), respDTO[1].Reference)
+	assert.Equal(t, int64(0), respDTO[1].Total)
+}
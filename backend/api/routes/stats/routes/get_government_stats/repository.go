@@ -0,0 +1,64 @@
+package get_government_stats
+
+import (
+	"time"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// governmentsSQL counts the published articles involving a member of each government, in the office held at the event date.
+// An article involving several members of the same government is counted once.
+const governmentsSQL = `
+SELECT g.reference,
+       g.start_date,
+       g.end_date,
+       pm.id                                                       AS prime_minister_id,
+       COALESCE(pm.first_name, '')                                 AS prime_minister_first_name,
+       COALESCE(pm.last_name, '')                                  AS prime_minister_last_name,
+       COUNT(DISTINCT a.id) FILTER (WHERE a.category = @lie)       AS lies,
+       COUNT(DISTINCT a.id) FILTER (WHERE a.category = @falsehood) AS falsehoods,
+       COUNT(DISTINCT a.id)                                        AS total
+FROM governments g
+         LEFT JOIN politicians pm ON pm.id = g.prime_minister_id AND pm.deleted_at IS NULL
+         LEFT JOIN occupations o ON o.government_id = g.id AND o.deleted_at IS NULL
+         LEFT JOIN article_politicians ap ON ap.occupation_id = o.id
+         LEFT JOIN articles a ON a.id = ap.article_id AND a.status = @published AND a.deleted_at IS NULL
+WHERE g.deleted_at IS NULL
+GROUP BY g.id, pm.id
+ORDER BY g.reference DESC`
+
+type governmentCounts struct {
+	Reference              int16
+	StartDate              time.Time
+	EndDate                *time.Time
+	PrimeMinisterID        *uuid.UUID
+	PrimeMinisterFirstName string
+	PrimeMinisterLastName  string
+	Lies                   int64
+	Falsehoods             int64
+	Total                  int64
+}
+
+type Repository interface {
+	getGovernmentCounts() ([]governmentCounts, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) getGovernmentCounts() ([]governmentCounts, error) {
+	var rows []governmentCounts
+
+	if err := r.db.Raw(governmentsSQL, map[string]any{
+		"lie":       models.ArticleCategoryLie,
+		"falsehood": models.ArticleCategoryFalsehood,
+		"published": models.ArticleStatusPublished,
+	}).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,103 @@
+package get_monthly_stats
+
+import (
+	"fmt"
+	"time"
+	"vdm/core/dto/response_dto"
+
+	"github.com/google/uuid"
+)
+
+const (
+	monthLayout = "2006-01"
+
+	// defaultMonths is the length of the series when the window start is omitted
+	defaultMonths = 12
+	maxMonths     = 120
+)
+
+// QueryParams are the filters of the series, other query params are ignored by the cache
+var QueryParams = []string{"from", "to", "politicianId"}
+
+type RequestDTO struct {
+	From         string `query:"from"`
+	To           string `query:"to"`
+	PoliticianID string `query:"politicianId"`
+}
+
+type filter struct {
+	// from and to are the first days of the first and last months of the series, in UTC
+	from         time.Time
+	to           time.Time
+	politicianID uuid.UUID
+}
+
+// toFilter defaults the window to the last defaultMonths months up to the current month
+func (dto RequestDTO) toFilter(now time.Time) (filter, error) {
+	var f filter
+
+	now = now.UTC()
+	f.to = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if dto.To != "" {
+		to, err := time.Parse(monthLayout, dto.To)
+		if err != nil {
+			return filter{}, fmt.Errorf("invalid month: %s", dto.To)
+		}
+		f.to = to
+	}
+
+	f.from = f.to.AddDate(0, 1-defaultMonths, 0)
+	if dto.From != "" {
+		from, err := time.Parse(monthLayout, dto.From)
+		if err != nil {
+			return filter{}, fmt.Errorf("invalid month: %s", dto.From)
+		}
+		f.from = from
+	}
+
+	if f.from.After(f.to) {
+		return filter{}, fmt.Errorf("the window must not start after it ends")
+	}
+	if months(f.from, f.to) > maxMonths {
+		return filter{}, fmt.Errorf("the window must not exceed %d months", maxMonths)
+	}
+
+	if dto.PoliticianID != "" {
+		politicianID, err := uuid.Parse(dto.PoliticianID)
+		if err != nil {
+			return filter{}, fmt.Errorf("invalid politician id: %s", dto.PoliticianID)
+		}
+		f.politicianID = politicianID
+	}
+
+	return f, nil
+}
+
+// months counts the months from the month of from to the month of to, both included
+func months(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
+}
+
+type MonthStatsDTO struct {
+	Month string `json:"month"`
+	response_dto.CategoryCounts
+}
+
+type ResponseDTO []MonthStatsDTO
+
+func newResponseDTO(rows []monthCounts) ResponseDTO {
+	respDTO := make(ResponseDTO, len(rows))
+
+	for i, row := range rows {
+		respDTO[i] = MonthStatsDTO{
+			Month: row.Month.UTC().Format(monthLayout),
+			CategoryCounts: response_dto.CategoryCounts{
+				Lies:       row.Lies,
+				Falsehoods: row.Falsehoods,
+				Total:      row.Total,
+			},
+		}
+	}
+
+	return respDTO
+}
@@ -0,0 +1,36 @@
+package get_monthly_stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestDTO_ToFilter(t *testing.T) {
+	now := time.Date(2024, 6, 10, 8, 30, 0, 0, time.UTC)
+
+	f, err := RequestDTO{}.toFilter(now)
+	if assert.NoError(t, err) {
+		assert.Equal(t, time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), f.from)
+		assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), f.to)
+		assert.Equal(t, defaultMonths, months(f.from, f.to))
+	}
+
+	f, err = RequestDTO{From: "2024-01", To: "2024-03"}.toFilter(now)
+	if assert.NoError(t, err) {
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), f.from)
+		assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), f.to)
+	}
+
+	for _, dto := range []RequestDTO{
+		{From: "2024-13"},
+		{To: "2024-06-01"},
+		{From: "2024-04", To: "2024-03"},
+		{From: "2000-01", To: "2024-03"},
+		{PoliticianID: "not-a-uuid"},
+	} {
+		_, err = dto.toFilter(now)
+		assert.Error(t, err, dto)
+	}
+}
@@ -0,0 +1,50 @@
+package get_monthly_stats
+
+import (
+	"time"
+	"vdm/core/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	getMonthlyStats(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+	now  func() time.Time
+}
+
+func (h *handler) getMonthlyStats(c *fiber.Ctx) error {
+	var reqDTO RequestDTO
+	if err := c.QueryParser(&reqDTO); err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: "invalid query params"}
+	}
+	if err := validation.Validate(reqDTO); err != nil {
+		return err
+	}
+
+	f, err := reqDTO.toFilter(h.now())
+	if err != nil {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: err.Error()}
+	}
+
+	if f.politicianID != uuid.Nil {
+		politician, err := h.repo.findPolitician(f.politicianID)
+		if err != nil {
+			return err
+		}
+		if politician == nil {
+			return &fiber.Error{Code: fiber.StatusNotFound, Message: "politician not found"}
+		}
+	}
+
+	rows, err := h.repo.getMonthCounts(f)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newResponseDTO(rows))
+}
@@ -0,0 +1,58 @@
+package get_monthly_stats
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type nullRepository struct{}
+
+func (*nullRepository) getMonthCounts(f filter) ([]monthCounts, error) {
+	return nil, nil
+}
+
+func (*nullRepository) findPolitician(politicianID uuid.UUID) (*models.Politician, error) {
+	return nil, nil
+}
+
+func newAppWithNullRepo() *fiber.App {
+	app := fiberx.NewApp()
+	h := &handler{repo: &nullRepository{}, now: time.Now}
+	app.Add(Method, Path, h.getMonthlyStats)
+	return app
+}
+
+func TestHandler_ErrBadRequest(t *testing.T) {
+	app := newAppWithNullRepo()
+
+	for _, target := range []string{Path + "?from=2024-13", Path + "?from=2024-04&to=2024-03", Path + "?politicianId=abc"} {
+		req := httptest.NewRequest(Method, target, nil)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode, target)
+	}
+}
+
+func TestHandler_ErrNotFound(t *testing.T) {
+	app := newAppWithNullRepo()
+
+	req := httptest.NewRequest(Method, Path+"?politicianId="+uuid.New().String(), nil)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
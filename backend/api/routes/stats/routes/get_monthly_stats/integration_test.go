@@ -0,0 +1,114 @@
+package get_monthly_stats
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	politician *models.Politician
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.politician = &models.Politician{FirstName: "Emmanuel", LastName: "Macron"}
+	if err = connector.GormDB().Create(data.politician).Error; err != nil {
+		return
+	}
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	articles := []*models.Article{
+		{Category: models.ArticleCategoryLie, Status: models.ArticleStatusPublished, EventDate: time.Date(2024, 1, 31, 23, 30, 0, 0, time.UTC)},
+		{Category: models.ArticleCategoryFalsehood, Status: models.ArticleStatusPublished, EventDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Category: models.ArticleCategoryLie, Status: models.ArticleStatusPublished, EventDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{Category: models.ArticleCategoryLie, Status: models.ArticleStatusDraft, EventDate: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, article := range articles {
+		article.RedactorID = redactor.ID
+		article.Title = "Title"
+		article.Reference = uuid.New()
+	}
+	if err = connector.GormDB().Create(&articles).Error; err != nil {
+		return
+	}
+
+	err = connector.GormDB().Create(&models.ArticlePolitician{ArticleID: articles[2].ID, PoliticianID: data.politician.ID}).Error
+	return
+}
+
+func getMonthlyStats(t *testing.T, app *fiber.App, target string) ResponseDTO {
+	res, err := app.Test(httptest.NewRequest(Method, target, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var respDTO ResponseDTO
+	if err = json.Unmarshal(body, &respDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	return respDTO
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	respDTO := getMonthlyStats(t, app, Path+"?from=2024-01&to=2024-04")
+	if assert.Len(t, respDTO, 4) {
+		assert.Equal(t, "2024-01", respDTO[0].Month)
+		assert.Equal(t, int64(1), respDTO[0].Lies)
+		assert.Equal(t, int64(0), respDTO[1].Total)
+		assert.Equal(t, int64(1), respDTO[2].Lies)
+		assert.Equal(t, int64(1), respDTO[2].Falsehoods)
+		assert.Equal(t, int64(2), respDTO[2].Total)
+		assert.Equal(t, "2024-04", respDTO[3].Month)
+		assert.Equal(t, int64(0), respDTO[3].Total)
+	}
+
+	respDTO = getMonthlyStats(t, app, Path+"?from=2024-01&to=2024-03&politicianId="+data.politician.ID.String())
+	if assert.Len(t, respDTO, 3) {
+		assert.Equal(t, int64(0), respDTO[0].Total)
+		assert.Equal(t, int64(1), respDTO[2].Lies)
+		assert.Equal(t, int64(1), respDTO[2].Total)
+	}
+}
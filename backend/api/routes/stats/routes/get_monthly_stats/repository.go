@@ -0,0 +1,82 @@
+package get_monthly_stats
+
+import (
+	"fmt"
+	"time"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// monthsSQL counts the published articles per month of their event date, in UTC.
+// Months without any article are part of the series, with zero counts.
+const monthsSQL = `
+SELECT m.month,
+       COUNT(a.id) FILTER (WHERE a.category = @lie)       AS lies,
+       COUNT(a.id) FILTER (WHERE a.category = @falsehood) AS falsehoods,
+       COUNT(a.id)                                        AS total
+FROM generate_series(@from::timestamp, @to::timestamp, interval '1 month') AS m(month)
+         LEFT JOIN articles a ON a.event_date >= m.month AT TIME ZONE 'UTC'
+    AND a.event_date < (m.month + interval '1 month') AT TIME ZONE 'UTC'
+    AND a.status = @published
+    AND a.deleted_at IS NULL
+    AND (@politicianID::uuid IS NULL OR
+         EXISTS (SELECT 1 FROM article_politicians ap WHERE ap.article_id = a.id AND ap.politician_id = @politicianID))
+GROUP BY m.month
+ORDER BY m.month`
+
+type monthCounts struct {
+	Month      time.Time
+	Lies       int64
+	Falsehoods int64
+	Total      int64
+}
+
+type Repository interface {
+	getMonthCounts(f filter) ([]monthCounts, error)
+	findPolitician(politicianID uuid.UUID) (*models.Politician, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) getMonthCounts(f filter) ([]monthCounts, error) {
+	var politicianID *uuid.UUID
+	if f.politicianID != uuid.Nil {
+		politicianID = &f.politicianID
+	}
+
+	var rows []monthCounts
+
+	if err := r.db.Raw(monthsSQL, map[string]any{
+		"lie":          models.ArticleCategoryLie,
+		"falsehood":    models.ArticleCategoryFalsehood,
+		"published":    models.ArticleStatusPublished,
+		"from":         f.from.Format(time.DateOnly),
+		"to":           f.to.Format(time.DateOnly),
+		"politicianID": politicianID,
+	}).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get month counts: %v", err)
+	}
+
+	return rows, nil
+}
+
+func (r *repository) findPolitician(politicianID uuid.UUID) (*models.Politician, error) {
+	var politicians []models.Politician
+
+	if err := r.db.Where("id = ?", politicianID).
+		Select("id").
+		Limit(1).
+		Find(&politicians).Error; err != nil {
+		return nil, fmt.Errorf("failed to find Politician{ID=%s}: %v", politicianID, err)
+	}
+
+	if len(politicians) == 0 {
+		return nil, nil
+	}
+
+	return &politicians[0], nil
+}
@@ -0,0 +1,20 @@
+package get_monthly_stats
+
+import (
+	"time"
+	"vdm/core/fiberx"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	Path   = "/months"
+	Method = fiber.MethodGet
+)
+
+func Route(db *gorm.DB) *fiberx.Route {
+	repo := &repository{db}
+	handler := &handler{repo, time.Now}
+	return fiberx.NewRoute(Method, Path, handler.getMonthlyStats)
+}
@@ -0,0 +1,31 @@
+package get_party_stats
+
+import "vdm/core/dto/response_dto"
+
+type PartyStatsDTO struct {
+	response_dto.Party
+	response_dto.CategoryCounts
+}
+
+type ResponseDTO []PartyStatsDTO
+
+func newResponseDTO(rows []partyCounts) ResponseDTO {
+	respDTO := make(ResponseDTO, len(rows))
+
+	for i, row := range rows {
+		respDTO[i] = PartyStatsDTO{
+			Party: response_dto.Party{
+				ID:   row.ID,
+				Code: row.Code,
+				Name: row.Name,
+			},
+			CategoryCounts: response_dto.CategoryCounts{
+				Lies:       row.Lies,
+				Falsehoods: row.Falsehoods,
+				Total:      row.Total,
+			},
+		}
+	}
+
+	return respDTO
+}
@@ -0,0 +1,24 @@
+package get_party_stats
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler interface {
+	getPartyStats(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) getPartyStats(c *fiber.Ctx) error {
+	rows, err := h.repo.getPartyCounts()
+	if err != nil {
+		return fmt.Errorf("failed to get party counts: %v", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newResponseDTO(rows))
+}
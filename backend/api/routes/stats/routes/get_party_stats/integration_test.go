@@ -0,0 +1,128 @@
+package get_party_stats
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	parties []*models.Party
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	politicians := []*models.Politician{
+		{FirstName: "Nicolas", LastName: "Sarkozy"},
+		{FirstName: "François", LastName: "Fillon"},
+	}
+	if err = connector.GormDB().Create(&politicians).Error; err != nil {
+		return
+	}
+
+	data.parties = []*models.Party{
+		{Code: "UMP", Name: "Union pour un mouvement populaire"},
+		{Code: "LR", Name: "Les Républicains"},
+	}
+	if err = connector.GormDB().Create(&data.parties).Error; err != nil {
+		return
+	}
+
+	founding := time.Date(2002, 11, 17, 0, 0, 0, 0, time.UTC)
+	refoundation := time.Date(2015, 5, 30, 0, 0, 0, 0, time.UTC)
+
+	if err = connector.GormDB().Create([]*models.PoliticianAffiliation{
+		{PoliticianID: politicians[0].ID, PartyID: data.parties[0].ID, StartDate: founding, EndDate: sql.NullTime{Time: refoundation, Valid: true}},
+		{PoliticianID: politicians[1].ID, PartyID: data.parties[0].ID, StartDate: founding, EndDate: sql.NullTime{Time: refoundation, Valid: true}},
+		{PoliticianID: politicians[0].ID, PartyID: data.parties[1].ID, StartDate: refoundation},
+	}).Error; err != nil {
+		return
+	}
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	articles := []*models.Article{
+		// both politicians were UMP members at the time, the article is counted once
+		{Category: models.ArticleCategoryLie, Status: models.ArticleStatusPublished, EventDate: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
+			Politicians: politicians},
+		{Category: models.ArticleCategoryFalsehood, Status: models.ArticleStatusPublished, EventDate: time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC),
+			Politicians: politicians[1:]},
+		// drafts are not counted
+		{Category: models.ArticleCategoryLie, Status: models.ArticleStatusDraft, EventDate: time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC),
+			Politicians: politicians[:1]},
+	}
+	for _, article := range articles {
+		article.RedactorID = redactor.ID
+		article.Title = "Title"
+		article.Reference = uuid.New()
+	}
+
+	err = connector.GormDB().Create(&articles).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	res, err := app.Test(httptest.NewRequest(Method, Path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var respDTO ResponseDTO
+	if err = json.Unmarshal(body, &respDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	if !assert.Len(t, respDTO, 2) {
+		return
+	}
+
+	assert.Equal(t, data.parties[0].ID, respDTO[0].ID)
+	assert.Equal(t, "UMP", respDTO[0].Code)
+	assert.Equal(t, int64(1), respDTO[0].Lies)
+	assert.Equal(t, int64(1), respDTO[0].Falsehoods)
+	assert.Equal(t, int64(2), respDTO[0].Total)
+
+	assert.Equal(t, "LR", respDTO[1].Code)
+	assert.Equal(t, int64(0), respDTO[1].Total)
+}
@@ -0,0 +1,60 @@
+package get_party_stats
+
+import (
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// partiesSQL counts the published articles involving a member of each party, as of the event date.
+// An article involving several members of the same party is counted once.
+const partiesSQL = `
+SELECT p.id,
+       p.code,
+       p.name,
+       COUNT(DISTINCT a.id) FILTER (WHERE a.category = @lie)       AS lies,
+       COUNT(DISTINCT a.id) FILTER (WHERE a.category = @falsehood) AS falsehoods,
+       COUNT(DISTINCT a.id)                                        AS total
+FROM parties p
+         LEFT JOIN politician_affiliations pa ON pa.party_id = p.id AND pa.deleted_at IS NULL
+         LEFT JOIN article_politicians ap ON ap.politician_id = pa.politician_id
+         LEFT JOIN articles a ON a.id = ap.article_id
+    AND a.status = @published
+    AND a.deleted_at IS NULL
+    AND a.event_date >= pa.start_date
+    AND (pa.end_date IS NULL OR a.event_date < pa.end_date)
+WHERE p.deleted_at IS NULL
+GROUP BY p.id
+ORDER BY total DESC, lies DESC, p.name, p.id`
+
+type partyCounts struct {
+	ID         uuid.UUID
+	Code       string
+	Name       string
+	Lies       int64
+	Falsehoods int64
+	Total      int64
+}
+
+type Repository interface {
+	getPartyCounts() ([]partyCounts, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) getPartyCounts() ([]partyCounts, error) {
+	var rows []partyCounts
+
+	if err := r.db.Raw(partiesSQL, map[string]any{
+		"lie":       models.ArticleCategoryLie,
+		"falsehood": models.ArticleCategoryFalsehood,
+		"published": models.ArticleStatusPublished,
+	}).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,64 @@
+package get_politician_stats
+
+import (
+	"vdm/core/dto/response_dto"
+
+	"github.com/google/uuid"
+)
+
+const (
+	limitParam  = "limit"
+	offsetParam = "offset"
+
+	defaultLimit = 20
+	maxLimit     = 100
+
+	// topTagsSize is the number of most used tags listed per politician
+	topTagsSize = 5
+)
+
+// QueryParams page through the stats
+var QueryParams = []string{limitParam, offsetParam}
+
+type TagCountDTO struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+type PoliticianStatsDTO struct {
+	response_dto.Politician
+	response_dto.CategoryCounts
+	TopTags []TagCountDTO `json:"topTags"`
+}
+
+type ResponseDTO []PoliticianStatsDTO
+
+func newResponseDTO(rows []politicianCounts, tags []politicianTag) ResponseDTO {
+	topTags := make(map[uuid.UUID][]TagCountDTO, len(rows))
+	for _, tag := range tags {
+		topTags[tag.PoliticianID] = append(topTags[tag.PoliticianID], TagCountDTO{Tag: tag.Tag, Count: tag.Count})
+	}
+
+	respDTO := make(ResponseDTO, len(rows))
+
+	for i, row := range rows {
+		respDTO[i] = PoliticianStatsDTO{
+			Politician: response_dto.Politician{
+				ID:       row.ID,
+				FullName: row.FirstName + " " + row.LastName,
+			},
+			CategoryCounts: response_dto.CategoryCounts{
+				Lies:       row.Lies,
+				Falsehoods: row.Falsehoods,
+				Total:      row.Total,
+			},
+			TopTags: topTags[row.ID],
+		}
+
+		if respDTO[i].TopTags == nil {
+			respDTO[i].TopTags = []TagCountDTO{}
+		}
+	}
+
+	return respDTO
+}
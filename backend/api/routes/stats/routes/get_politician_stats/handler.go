@@ -0,0 +1,48 @@
+package get_politician_stats
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler interface {
+	getPoliticianStats(c *fiber.Ctx) error
+}
+
+type handler struct {
+	repo Repository
+}
+
+func (h *handler) getPoliticianStats(c *fiber.Ctx) error {
+	limit := c.QueryInt(limitParam, defaultLimit)
+	if limit < 1 || limit > maxLimit {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: fmt.Sprintf("query param <%s> must be between 1 and %d", limitParam, maxLimit)}
+	}
+
+	offset := c.QueryInt(offsetParam, 0)
+	if offset < 0 {
+		return &fiber.Error{Code: fiber.StatusBadRequest, Message: fmt.Sprintf("query param <%s> must be positive", offsetParam)}
+	}
+
+	rows, err := h.repo.getPoliticianCounts(limit, offset)
+	if err != nil {
+		return fmt.Errorf("failed to get politician counts: %v", err)
+	}
+
+	var tags []politicianTag
+
+	if len(rows) > 0 {
+		politicianIDs := make([]uuid.UUID, len(rows))
+		for i := range rows {
+			politicianIDs[i] = rows[i].ID
+		}
+
+		if tags, err = h.repo.getTopTags(politicianIDs, topTagsSize); err != nil {
+			return fmt.Errorf("failed to get top tags: %v", err)
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newResponseDTO(rows, tags))
+}
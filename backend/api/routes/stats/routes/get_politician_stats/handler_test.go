@@ -0,0 +1,85 @@
+package get_politician_stats
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"vdm/core/fiberx"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubRepository struct {
+	rows []politicianCounts
+	tags []politicianTag
+}
+
+func (r *stubRepository) getPoliticianCounts(limit, offset int) ([]politicianCounts, error) {
+	return r.rows, nil
+}
+
+func (r *stubRepository) getTopTags(politicianIDs []uuid.UUID, size int) ([]politicianTag, error) {
+	return r.tags, nil
+}
+
+func TestHandler_ErrBadRequest(t *testing.T) {
+	app := fiberx.NewApp()
+	h := &handler{repo: &stubRepository{}}
+	app.Add(Method, Path, h.getPoliticianStats)
+
+	for _, target := range []string{Path + "?limit=0", Path + "?limit=101", Path + "?offset=-1"} {
+		req := httptest.NewRequest(Method, target, nil)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode, target)
+	}
+}
+
+func TestHandler_Success(t *testing.T) {
+	macron, attal := uuid.New(), uuid.New()
+
+	app := fiberx.NewApp()
+	h := &handler{repo: &stubRepository{
+		rows: []politicianCounts{
+			{ID: macron, FirstName: "Emmanuel", LastName: "Macron", Lies: 2, Falsehoods: 1, Total: 3},
+			{ID: attal, FirstName: "Gabriel", LastName: "Attal", Lies: 1, Total: 1},
+		},
+		tags: []politicianTag{
+			{PoliticianID: macron, Tag: "Chômage", Count: 2},
+			{PoliticianID: macron, Tag: "Retraites", Count: 1},
+		},
+	}}
+	app.Add(Method, Path, h.getPoliticianStats)
+
+	res, err := app.Test(httptest.NewRequest(Method, Path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var respDTO ResponseDTO
+	if err = json.Unmarshal(body, &respDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, respDTO, 2) {
+		assert.Equal(t, "Emmanuel Macron", respDTO[0].FullName)
+		assert.Equal(t, int64(3), respDTO[0].Total)
+		assert.Equal(t, []TagCountDTO{{Tag: "Chômage", Count: 2}, {Tag: "Retraites", Count: 1}}, respDTO[0].TopTags)
+		assert.Equal(t, []TagCountDTO{}, respDTO[1].TopTags)
+	}
+}
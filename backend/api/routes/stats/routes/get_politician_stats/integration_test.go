@@ -0,0 +1,115 @@
+package get_politician_stats
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/fiberx"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	politicians []*models.Politician
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	data.politicians = []*models.Politician{
+		{FirstName: "Emmanuel", LastName: "Macron"},
+		{FirstName: "Gabriel", LastName: "Attal"},
+	}
+	if err = connector.GormDB().Create(&data.politicians).Error; err != nil {
+		return
+	}
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	articles := []*models.Article{
+		{Category: models.ArticleCategoryLie, Status: models.ArticleStatusPublished, Tags: []*models.ArticleTag{{Tag: "Chômage"}, {Tag: "Retraites"}}},
+		{Category: models.ArticleCategoryFalsehood, Status: models.ArticleStatusPublished, Tags: []*models.ArticleTag{{Tag: "Chômage"}}},
+		{Category: models.ArticleCategoryLie, Status: models.ArticleStatusPublished},
+		// drafts are not counted
+		{Category: models.ArticleCategoryLie, Status: models.ArticleStatusDraft, Tags: []*models.ArticleTag{{Tag: "Retraites"}}},
+	}
+	for _, article := range articles {
+		article.RedactorID = redactor.ID
+		article.Title = "Title"
+		article.Reference = uuid.New()
+		article.EventDate = time.Date(2024, 6, 9, 20, 0, 0, 0, time.UTC)
+	}
+	if err = connector.GormDB().Create(&articles).Error; err != nil {
+		return
+	}
+
+	err = connector.GormDB().Create([]*models.ArticlePolitician{
+		{ArticleID: articles[0].ID, PoliticianID: data.politicians[0].ID},
+		{ArticleID: articles[1].ID, PoliticianID: data.politicians[0].ID},
+		{ArticleID: articles[2].ID, PoliticianID: data.politicians[1].ID},
+		{ArticleID: articles[3].ID, PoliticianID: data.politicians[1].ID},
+	}).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	app := fiberx.NewApp()
+	Route(connector.GormDB()).Register(app)
+
+	res, err := app.Test(httptest.NewRequest(Method, Path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var respDTO ResponseDTO
+	if err = json.Unmarshal(body, &respDTO); err != nil {
+		t.Fatal(err)
+	}
+
+	if !assert.Len(t, respDTO, 2) {
+		return
+	}
+
+	assert.Equal(t, data.politicians[0].ID, respDTO[0].ID)
+	assert.Equal(t, int64(1), respDTO[0].Lies)
+	assert.Equal(t, int64(1), respDTO[0].Falsehoods)
+	assert.Equal(t, int64(2), respDTO[0].Total)
+	assert.Equal(t, []TagCountDTO{{Tag: "Chômage", Count: 2}, {Tag: "Retraites", Count: 1}}, respDTO[0].TopTags)
+
+	assert.Equal(t, data.politicians[1].ID, respDTO[1].ID)
+	assert.Equal(t, int64(1), respDTO[1].Total)
+	assert.Empty(t, respDTO[1].TopTags)
+}
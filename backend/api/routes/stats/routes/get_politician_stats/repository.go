@@ -0,0 +1,96 @@
+package get_politician_stats
+
+import (
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// leaderboardSQL ranks politicians by number of published articles they are involved in
+const leaderboardSQL = `
+SELECT p.id,
+       p.first_name,
+       p.last_name,
+       COUNT(*) FILTER (WHERE a.category = @lie)       AS lies,
+       COUNT(*) FILTER (WHERE a.category = @falsehood) AS falsehoods,
+       COUNT(*)                                        AS total
+FROM articles a
+         JOIN article_politicians ap ON ap.article_id = a.id
+         JOIN politicians p ON p.id = ap.politician_id AND p.deleted_at IS NULL
+WHERE a.status = @published
+  AND a.deleted_at IS NULL
+GROUP BY p.id
+ORDER BY total DESC, lies DESC, p.last_name, p.first_name, p.id
+LIMIT @limit OFFSET @offset`
+
+// topTagsSQL returns the most used tags in the published articles of each politician, ties broken by tag
+const topTagsSQL = `
+SELECT politician_id, tag, count
+FROM (SELECT ap.politician_id,
+             t.tag,
+             COUNT(*)                                                                     AS count,
+             ROW_NUMBER() OVER (PARTITION BY ap.politician_id ORDER BY COUNT(*) DESC, t.tag) AS rank
+      FROM articles a
+               JOIN article_politicians ap ON ap.article_id = a.id
+               JOIN article_tags t ON t.article_id = a.id
+      WHERE a.status = @published
+        AND a.deleted_at IS NULL
+        AND ap.politician_id IN @politicianIDs
+      GROUP BY ap.politician_id, t.tag) AS ranked
+WHERE rank <= @size
+ORDER BY politician_id, rank`
+
+type politicianCounts struct {
+	ID         uuid.UUID
+	FirstName  string
+	LastName   string
+	Lies       int64
+	Falsehoods int64
+	Total      int64
+}
+
+type politicianTag struct {
+	PoliticianID uuid.UUID
+	Tag          string
+	Count        int64
+}
+
+type Repository interface {
+	getPoliticianCounts(limit, offset int) ([]politicianCounts, error)
+	getTopTags(politicianIDs []uuid.UUID, size int) ([]politicianTag, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func (r *repository) getPoliticianCounts(limit, offset int) ([]politicianCounts, error) {
+	var rows []politicianCounts
+
+	if err := r.db.Raw(leaderboardSQL, map[string]any{
+		"lie":       models.ArticleCategoryLie,
+		"falsehood": models.ArticleCategoryFalsehood,
+		"published": models.ArticleStatusPublished,
+		"limit":     limit,
+		"offset":    offset,
+	}).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *repository) getTopTags(politicianIDs []uuid.UUID, size int) ([]politicianTag, error) {
+	var tags []politicianTag
+
+	if err := r.db.Raw(topTagsSQL, map[string]any{
+		"published":     models.ArticleStatusPublished,
+		"politicianIDs": politicianIDs,
+		"size":          size,
+	}).Scan(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
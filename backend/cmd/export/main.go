@@ -0,0 +1,72 @@
+// Command export writes the open-data dataset of published articles, and its manifest, to a directory.
+//
+//	go run ./cmd/export -out ./open-data
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"vdm/core/dependencies/database"
+	"vdm/core/env"
+	"vdm/core/logger"
+	"vdm/core/open_data"
+)
+
+func main() {
+	out := flag.String("out", "open-data", "directory the dataset is written to")
+	flag.Parse()
+
+	cfg, err := env.LoadConfig()
+	if err != nil {
+		logger.Error("failed to load config", logger.Err(err))
+		os.Exit(1)
+	}
+	if err := cfg.Validate(); err != nil {
+		logger.Error("invalid config", logger.Err(err))
+		os.Exit(1)
+	}
+
+	dbConn, err := database.NewConnector(cfg.Database)
+	if err != nil {
+		logger.Error("failed to init database", logger.Err(err))
+		os.Exit(1)
+	}
+
+	defer func(dbConn database.Connector) {
+		if err := dbConn.Close(); err != nil {
+			logger.Error("failed to close database connection", logger.Err(err))
+		}
+	}(dbConn)
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		logger.Error("failed to create output directory", logger.Err(err))
+		os.Exit(1)
+	}
+
+	var files []*os.File
+
+	manifest, err := open_data.Export(dbConn.GormDB(), func(name string) (io.Writer, error) {
+		f, err := os.Create(filepath.Join(*out, name))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+		return f, nil
+	})
+
+	for _, f := range files {
+		if err := f.Close(); err != nil {
+			logger.Error("failed to close file", logger.Any("file", f.Name()), logger.Err(err))
+			os.Exit(1)
+		}
+	}
+
+	if err != nil {
+		logger.Error("failed to export open data", logger.Err(err))
+		os.Exit(1)
+	}
+
+	logger.Info("open data exported", logger.Any("out", *out), logger.Any("articles", manifest.Articles))
+}
@@ -0,0 +1,44 @@
+package aes_utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAesUtils(t *testing.T) {
+	key := bytes.Repeat([]byte("k"), 32)
+
+	ciphertext, err := Encrypt("JBSWY3DPEHPK3PXP", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotContains(t, ciphertext, "JBSWY3DPEHPK3PXP")
+
+	// the nonce is random
+	other, err := Encrypt("JBSWY3DPEHPK3PXP", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, ciphertext, other)
+
+	plaintext, err := Decrypt(ciphertext, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", plaintext)
+}
+
+func TestAesUtils_WrongKey(t *testing.T) {
+	ciphertext, err := Encrypt("JBSWY3DPEHPK3PXP", bytes.Repeat([]byte("k"), 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Decrypt(ciphertext, bytes.Repeat([]byte("w"), 32))
+	assert.Error(t, err)
+
+	_, err = Encrypt("JBSWY3DPEHPK3PXP", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package article_lifecycle
+
+import (
+	"fmt"
+	"slices"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Created is the status of a version before it is stored.
+const Created models.ArticleStatus = ""
+
+// transitions lists, per role, the statuses an article version may move to from a given status.
+// A transition to the same status is a save that leaves the status untouched.
+var transitions = map[models.RoleName]map[models.ArticleStatus][]models.ArticleStatus{
+	models.RoleRedactor: {
+		Created:                   {models.ArticleStatusDraft, models.ArticleStatusUnderReview},
+		models.ArticleStatusDraft: {models.ArticleStatusDraft, models.ArticleStatusUnderReview},
+		// archived when superseded by the resubmitted version
+		models.ArticleStatusChangeRequested: {models.ArticleStatusChangeRequested, models.ArticleStatusUnderReview, models.ArticleStatusArchived},
+	},
+	models.RoleModerator: {
+		models.ArticleStatusUnderReview: {models.ArticleStatusPublished, models.ArticleStatusChangeRequested},
+		// archived when superseded by a newly published version
+		models.ArticleStatusPublished: {models.ArticleStatusArchived},
+	},
+}
+
+// Allowed reports whether role may move an article version from one status to another.
+func Allowed(role models.RoleName, from, to models.ArticleStatus) bool {
+	return slices.Contains(transitions[role][from], to)
+}
+
+// Transition returns a conflict error if role may not move an article version from one status to another.
+func Transition(role models.RoleName, from, to models.ArticleStatus) error {
+	if Allowed(role, from, to) {
+		return nil
+	}
+
+	return &fiber.Error{Code: fiber.StatusConflict, Message: fmt.Sprintf(
+		"%s can't move an article from %s to %s, expected one of %v", role, label(from), to, transitions[role][from])}
+}
+
+// NewEvent builds the audit trail entry of a transition.
+func NewEvent(article models.Article, actorID uuid.UUID, from, to models.ArticleStatus) *models.ArticleStatusEvent {
+	event := &models.ArticleStatusEvent{
+		ArticleID: &article.ID,
+		Reference: article.Reference,
+		ActorID:   actorID,
+		ToStatus:  to,
+	}
+	if from != Created {
+		event.FromStatus = &from
+	}
+
+	return event
+}
+
+func label(status models.ArticleStatus) string {
+	if status == Created {
+		return "CREATED"
+	}
+	return string(status)
+}
@@ -0,0 +1,45 @@
+package article_lifecycle
+
+import (
+	"testing"
+	"vdm/core/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowed(t *testing.T) {
+	assert.True(t, Allowed(models.RoleRedactor, Created, models.ArticleStatusDraft))
+	assert.True(t, Allowed(models.RoleRedactor, models.ArticleStatusDraft, models.ArticleStatusUnderReview))
+	assert.True(t, Allowed(models.RoleRedactor, models.ArticleStatusChangeRequested, models.ArticleStatusChangeRequested))
+	assert.True(t, Allowed(models.RoleModerator, models.ArticleStatusUnderReview, models.ArticleStatusPublished))
+
+	// redactors never publish, moderators only decide on articles under review
+	assert.False(t, Allowed(models.RoleRedactor, models.ArticleStatusDraft, models.ArticleStatusPublished))
+	assert.False(t, Allowed(models.RoleRedactor, models.ArticleStatusUnderReview, models.ArticleStatusUnderReview))
+	assert.False(t, Allowed(models.RoleModerator, models.ArticleStatusUnderReview, models.ArticleStatusDraft))
+	assert.False(t, Allowed(models.RoleModerator, models.ArticleStatusUnderReview, models.ArticleStatusArchived))
+	assert.False(t, Allowed(models.RoleModerator, models.ArticleStatusArchived, models.ArticleStatusPublished))
+	assert.False(t, Allowed(models.RoleAdmin, models.ArticleStatusUnderReview, models.ArticleStatusPublished))
+}
+
+func TestTransition(t *testing.T) {
+	assert.NoError(t, Transition(models.RoleRedactor, models.ArticleStatusDraft, models.ArticleStatusDraft))
+
+	err := Transition(models.RoleRedactor, models.ArticleStatusArchived, models.ArticleStatusUnderReview)
+	var fiberErr *fiber.Error
+	if assert.ErrorAs(t, err, &fiberErr) {
+		assert.Equal(t, fiber.StatusConflict, fiberErr.Code)
+	}
+}
+
+func TestNewEvent(t *testing.T) {
+	event := NewEvent(models.Article{}, uuid.New(), Created, models.ArticleStatusDraft)
+	assert.Nil(t, event.FromStatus)
+
+	event = NewEvent(models.Article{}, uuid.New(), models.ArticleStatusDraft, models.ArticleStatusUnderReview)
+	if assert.NotNil(t, event.FromStatus) {
+		assert.Equal(t, models.ArticleStatusDraft, *event.FromStatus)
+	}
+}
@@ -52,11 +52,129 @@ func NewConnector(config env.DatabaseConfig) (Connector, error) {
 func (p *PostgresConnector) GormDB() *gorm.DB { return p.DB }
 
 func (p *PostgresConnector) Migrate() error {
-	return p.DB.AutoMigrate(
-		&models.Politician{}, &models.Occupation{}, &models.Government{},
-		&models.User{}, &models.Role{}, &models.UserRole{}, &models.UserToken{},
+	if err := p.DB.AutoMigrate(
+		&models.Politician{}, &models.Occupation{}, &models.Government{}, &models.Party{}, &models.PoliticianAffiliation{},
+		&models.User{}, &models.Role{}, &models.UserRole{}, &models.UserToken{}, &models.UserTOTP{}, &models.UserRecoveryCode{},
+		&models.UserPasskey{}, &models.WebAuthnChallenge{},
 		&models.Article{}, &models.ArticlePolitician{}, &models.ArticleReview{}, &models.ArticleTag{}, &models.ArticleSource{},
-	)
+		&models.ReviewComment{}, &models.ArticleStatusEvent{}, &models.SourceSnapshot{},
+	); err != nil {
+		return err
+	}
+
+	if err := p.migrateTextSearch(); err != nil {
+		return err
+	}
+
+	return p.migrateSearchVector()
+}
+
+// migrateTextSearch mirrors the french_unaccent text search configuration declared in schema.sql
+func (p *PostgresConnector) migrateTextSearch() error {
+	if err := p.DB.Exec("CREATE EXTENSION IF NOT EXISTS unaccent").Error; err != nil {
+		return fmt.Errorf("failed to create unaccent extension: %v", err)
+	}
+
+	if err := p.DB.Exec(`DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'french_unaccent') THEN
+        CREATE TEXT SEARCH CONFIGURATION french_unaccent (COPY = french);
+        ALTER TEXT SEARCH CONFIGURATION french_unaccent
+            ALTER MAPPING FOR hword, hword_part, word WITH unaccent, french_stem;
+    END IF;
+END
+$$`).Error; err != nil {
+		return fmt.Errorf("failed to create french_unaccent text search configuration: %v", err)
+	}
+
+	return nil
+}
+
+// searchVectorSQL mirrors the search_vector column of articles declared in schema.sql, with its triggers and index
+var searchVectorSQL = []string{
+	`ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_vector TSVECTOR NOT NULL DEFAULT ''`,
+	`CREATE OR REPLACE FUNCTION article_search_vector(article_id UUID, title TEXT, body TEXT) RETURNS TSVECTOR
+    LANGUAGE sql
+    STABLE
+AS
+$$
+SELECT setweight(to_tsvector('french_unaccent', title), 'A') ||
+       setweight(to_tsvector('french_unaccent', coalesce((SELECT string_agg(p.first_name || ' ' || p.last_name, ' ')
+                                                          FROM article_politicians ap
+                                                                   JOIN politicians p ON p.id = ap.politician_id
+                                                          WHERE ap.article_id = article_search_vector.article_id), '')), 'A') ||
+       setweight(to_tsvector('french_unaccent', coalesce((SELECT string_agg(t.tag, ' ')
+                                                          FROM article_tags t
+                                                          WHERE t.article_id = article_search_vector.article_id), '')), 'B') ||
+       setweight(to_tsvector('french_unaccent', body), 'C')
+$$`,
+	`CREATE OR REPLACE FUNCTION articles_set_search_vector() RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    NEW.search_vector := article_search_vector(NEW.id, NEW.title, NEW.body);
+    RETURN NEW;
+END
+$$`,
+	`CREATE OR REPLACE TRIGGER trg_articles_search_vector
+    BEFORE INSERT OR UPDATE OF title, body
+    ON articles
+    FOR EACH ROW
+EXECUTE FUNCTION articles_set_search_vector()`,
+	`CREATE OR REPLACE FUNCTION article_links_refresh_search_vector() RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE articles SET search_vector = article_search_vector(id, title, body) WHERE id = OLD.article_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        UPDATE articles SET search_vector = article_search_vector(id, title, body) WHERE id = NEW.article_id;
+    END IF;
+    RETURN NULL;
+END
+$$`,
+	`CREATE OR REPLACE TRIGGER trg_article_tags_search_vector
+    AFTER INSERT OR UPDATE OR DELETE
+    ON article_tags
+    FOR EACH ROW
+EXECUTE FUNCTION article_links_refresh_search_vector()`,
+	`CREATE OR REPLACE TRIGGER trg_article_politicians_search_vector
+    AFTER INSERT OR UPDATE OR DELETE
+    ON article_politicians
+    FOR EACH ROW
+EXECUTE FUNCTION article_links_refresh_search_vector()`,
+	`CREATE OR REPLACE FUNCTION politicians_refresh_search_vector() RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    UPDATE articles a
+    SET search_vector = article_search_vector(a.id, a.title, a.body)
+    FROM article_politicians ap
+    WHERE ap.article_id = a.id
+      AND ap.politician_id = NEW.id;
+    RETURN NULL;
+END
+$$`,
+	`CREATE OR REPLACE TRIGGER trg_politicians_search_vector
+    AFTER UPDATE OF first_name, last_name
+    ON politicians
+    FOR EACH ROW
+    WHEN (OLD.first_name IS DISTINCT FROM NEW.first_name OR OLD.last_name IS DISTINCT FROM NEW.last_name)
+EXECUTE FUNCTION politicians_refresh_search_vector()`,
+	`CREATE INDEX IF NOT EXISTS idx_articles_search_vector ON articles USING GIN (search_vector)`,
+}
+
+func (p *PostgresConnector) migrateSearchVector() error {
+	for _, sql := range searchVectorSQL {
+		if err := p.DB.Exec(sql).Error; err != nil {
+			return fmt.Errorf("failed to migrate articles search vector: %v", err)
+		}
+	}
+	return nil
 }
 
 func (p *PostgresConnector) Close() error {
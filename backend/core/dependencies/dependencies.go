@@ -4,7 +4,10 @@ import (
 	"vdm/core/dependencies/database"
 	"vdm/core/dependencies/mailer"
 	"vdm/core/env"
+	"vdm/core/http_cache"
+	"vdm/core/lockout"
 
+	"github.com/go-webauthn/webauthn/webauthn"
 	"gorm.io/gorm"
 )
 
@@ -12,16 +15,22 @@ type Dependencies struct {
 	Config      env.Config
 	dbConnector database.Connector
 	Mailer      mailer.Mailer
+	Cache       *http_cache.Cache
+	WebAuthn    *webauthn.WebAuthn
+	Lockout     *lockout.Guard
 }
 
 func (d *Dependencies) GormDB() *gorm.DB {
 	return d.dbConnector.GormDB()
 }
 
-func New(cfg env.Config, dbConnector database.Connector, mailer mailer.Mailer) *Dependencies {
+func New(cfg env.Config, dbConnector database.Connector, mailer mailer.Mailer, cache *http_cache.Cache, webAuthn *webauthn.WebAuthn, lockout *lockout.Guard) *Dependencies {
 	return &Dependencies{
 		Config:      cfg,
 		dbConnector: dbConnector,
 		Mailer:      mailer,
+		Cache:       cache,
+		WebAuthn:    webAuthn,
+		Lockout:     lockout,
 	}
 }
@@ -0,0 +1,73 @@
+// Package device describes the devices users sign in from, for them to recognize their sessions.
+package device
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxUserAgentLength bounds the user agent stored with a session, since clients choose it
+const maxUserAgentLength = 512
+
+type Device struct {
+	UserAgent string
+	IP        string
+}
+
+func FromCtx(c *fiber.Ctx) Device {
+	userAgent := c.Get(fiber.HeaderUserAgent)
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	return Device{UserAgent: userAgent, IP: c.IP()}
+}
+
+// browsers and systems are matched in order: most user agents also claim to be the ones listed after them
+var browsers = []struct{ token, name string }{
+	{"Edg/", "Edge"},
+	{"EdgA/", "Edge"},
+	{"OPR/", "Opera"},
+	{"SamsungBrowser/", "Samsung Internet"},
+	{"Firefox/", "Firefox"},
+	{"FxiOS/", "Firefox"},
+	{"CriOS/", "Chrome"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+}
+
+var systems = []struct{ token, name string }{
+	{"iPhone", "iOS"},
+	{"iPad", "iPadOS"},
+	{"Android", "Android"},
+	{"Windows", "Windows"},
+	{"CrOS", "ChromeOS"},
+	{"Mac OS X", "macOS"},
+	{"Linux", "Linux"},
+}
+
+// Label names the device from its user agent, e.g. "Firefox on Windows"
+func (d Device) Label() string {
+	browser := match(d.UserAgent, browsers)
+	system := match(d.UserAgent, systems)
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	default:
+		return "Unknown device"
+	}
+}
+
+func match(userAgent string, candidates []struct{ token, name string }) string {
+	for _, candidate := range candidates {
+		if strings.Contains(userAgent, candidate.token) {
+			return candidate.name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package device
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"vdm/core/fiberx"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDevice_Label(t *testing.T) {
+	for userAgent, label := range map[string]string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:131.0) Gecko/20100101 Firefox/131.0":                                                  "Firefox on Windows",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/130.0.0.0 Safari/537.36 Edg/130.0.0.0":     "Edge on Windows",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.0 Safari/605.1.15":             "Safari on macOS",
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 18_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/130.0.6723.90 Mobile/15E148": "Chrome on iOS",
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/130.0.0.0 Mobile Safari/537.36":             "Chrome on Android",
+		"curl/8.5.0": "Unknown device",
+		"":           "Unknown device",
+	} {
+		assert.Equal(t, label, Device{UserAgent: userAgent}.Label(), userAgent)
+	}
+}
+
+func TestFromCtx(t *testing.T) {
+	app := fiberx.NewApp()
+
+	var actual Device
+	app.Get("/", func(c *fiber.Ctx) error {
+		actual = FromCtx(c)
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+	req.Header.Set(fiber.HeaderUserAgent, strings.Repeat("a", 2*maxUserAgentLength))
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	assert.Len(t, actual.UserAgent, maxUserAgentLength)
+	assert.NotEmpty(t, actual.IP)
+}
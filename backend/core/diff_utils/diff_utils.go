@@ -0,0 +1,161 @@
+package diff_utils
+
+import (
+	"regexp"
+	"slices"
+)
+
+type Operation string
+
+const (
+	OperationEqual  Operation = "equal"
+	OperationInsert Operation = "insert"
+	OperationDelete Operation = "delete"
+)
+
+type Edit struct {
+	Operation Operation `json:"op"`
+	Text      string    `json:"text"`
+}
+
+var tokenRegexp = regexp.MustCompile(`\s+|[^\s]+`)
+
+// Words computes a word-level diff turning from into to.
+// Whitespace is kept so that concatenating the equal and insert edits gives back to.
+func Words(from, to string) []Edit {
+	a := tokenRegexp.FindAllString(from, -1)
+	b := tokenRegexp.FindAllString(to, -1)
+
+	// common prefix and suffix don't need the quadratic LCS table
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var edits []Edit
+	edits = appendTokens(edits, OperationEqual, a[:prefix])
+	edits = append(edits, lcsEdits(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	edits = appendTokens(edits, OperationEqual, a[len(a)-suffix:])
+
+	return merge(edits)
+}
+
+// maxDiffCells bounds the work of a diff, about a tenth of a second. Past it, the changed middle is shown as replaced.
+const maxDiffCells = 25_000_000
+
+// lcsEdits turns a into b along a longest common subsequence.
+func lcsEdits(a, b []string) []Edit {
+	if len(a)*len(b) > maxDiffCells {
+		edits := appendTokens(nil, OperationDelete, a)
+		return appendTokens(edits, OperationInsert, b)
+	}
+	return hirschberg(make([]Edit, 0, len(a)+len(b)), a, b)
+}
+
+// hirschberg splits a in halves, and b where the longest common subsequences of both halves meet,
+// so that only two rows of the quadratic table are ever kept.
+func hirschberg(edits []Edit, a, b []string) []Edit {
+	switch {
+	case len(a) == 0:
+		return appendTokens(edits, OperationInsert, b)
+	case len(b) == 0:
+		return appendTokens(edits, OperationDelete, a)
+	case len(a) == 1:
+		i := slices.Index(b, a[0])
+		if i < 0 {
+			edits = appendTokens(edits, OperationDelete, a)
+			return appendTokens(edits, OperationInsert, b)
+		}
+		edits = appendTokens(edits, OperationInsert, b[:i])
+		edits = append(edits, Edit{Operation: OperationEqual, Text: a[0]})
+		return appendTokens(edits, OperationInsert, b[i+1:])
+	}
+
+	mid := len(a) / 2
+	forward := lcsLengths(a[:mid], b, false)
+	backward := lcsLengths(a[mid:], b, true)
+
+	split, best := 0, -1
+	for j := 0; j <= len(b); j++ {
+		if l := forward[j] + backward[len(b)-j]; l > best {
+			split, best = j, l
+		}
+	}
+
+	edits = hirschberg(edits, a[:mid], b[:split])
+	return hirschberg(edits, a[mid:], b[split:])
+}
+
+// lcsLengths returns, for every j, the length of the longest common subsequence of a and the first j tokens of b,
+// or of a and the last j tokens of b when reverse, both being read backwards.
+func lcsLengths(a, b []string, reverse bool) []int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+
+	for i := range a {
+		ai := a[i]
+		if reverse {
+			ai = a[len(a)-1-i]
+		}
+
+		for j := 1; j <= len(b); j++ {
+			bj := b[j-1]
+			if reverse {
+				bj = b[len(b)-j]
+			}
+
+			if ai == bj {
+				cur[j] = prev[j-1] + 1
+			} else {
+				cur[j] = max(prev[j], cur[j-1])
+			}
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev
+}
+
+func appendTokens(edits []Edit, op Operation, tokens []string) []Edit {
+	for _, token := range tokens {
+		edits = append(edits, Edit{Operation: op, Text: token})
+	}
+	return edits
+}
+
+// merge joins consecutive edits sharing the same operation.
+func merge(edits []Edit) []Edit {
+	merged := make([]Edit, 0, len(edits))
+
+	for _, edit := range edits {
+		if n := len(merged); n > 0 && merged[n-1].Operation == edit.Operation {
+			merged[n-1].Text += edit.Text
+			continue
+		}
+		merged = append(merged, edit)
+	}
+
+	return merged
+}
+
+// Sets returns the values of to missing from from (added) and the values of from missing from to (removed).
+// Both are empty rather than nil when nothing changed, so that they encode to [].
+func Sets[T comparable](from, to []T) (added, removed []T) {
+	added, removed = make([]T, 0), make([]T, 0)
+	for _, v := range to {
+		if !slices.Contains(from, v) {
+			added = append(added, v)
+		}
+	}
+	for _, v := range from {
+		if !slices.Contains(to, v) {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,96 @@
+package diff_utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rebuild(edits []Edit, skip Operation) string {
+	var sb strings.Builder
+	for _, edit := range edits {
+		if edit.Operation != skip {
+			sb.WriteString(edit.Text)
+		}
+	}
+	return sb.String()
+}
+
+func TestWords(t *testing.T) {
+	from := "Le président a affirmé que le chômage baissait."
+	to := "Le président a déclaré que le chômage augmentait fortement."
+
+	edits := Words(from, to)
+
+	assert.Equal(t, []Edit{
+		{Operation: OperationEqual, Text: "Le président a "},
+		{Operation: OperationDelete, Text: "affirmé"},
+		{Operation: OperationInsert, Text: "déclaré"},
+		{Operation: OperationEqual, Text: " que le chômage "},
+		{Operation: OperationDelete, Text: "baissait."},
+		{Operation: OperationInsert, Text: "augmentait fortement."},
+	}, edits)
+
+	assert.Equal(t, from, rebuild(edits, OperationInsert))
+	assert.Equal(t, to, rebuild(edits, OperationDelete))
+}
+
+func TestWords_Identical(t *testing.T) {
+	assert.Equal(t, []Edit{{Operation: OperationEqual, Text: "même texte"}}, Words("même texte", "même texte"))
+	assert.Empty(t, Words("", ""))
+}
+
+func TestWords_FromEmpty(t *testing.T) {
+	assert.Equal(t, []Edit{{Operation: OperationInsert, Text: "nouveau texte"}}, Words("", "nouveau texte"))
+	assert.Equal(t, []Edit{{Operation: OperationDelete, Text: "ancien texte"}}, Words("ancien texte", ""))
+}
+
+// equalTokens counts the tokens kept by the edits, the length of the common subsequence they follow
+func equalTokens(edits []Edit) int {
+	n := 0
+	for _, edit := range edits {
+		if edit.Operation == OperationEqual {
+			n += len(tokenRegexp.FindAllString(edit.Text, -1))
+		}
+	}
+	return n
+}
+
+func TestWords_LongestCommonSubsequence(t *testing.T) {
+	from := "a b c d e f g h"
+	to := "x b c y e f z h w"
+
+	edits := Words(from, to)
+
+	// b, c, e, f, h and the 7 spaces of from are kept
+	assert.Equal(t, 12, equalTokens(edits))
+	assert.Equal(t, from, rebuild(edits, OperationInsert))
+	assert.Equal(t, to, rebuild(edits, OperationDelete))
+}
+
+func TestWords_TooLarge(t *testing.T) {
+	// both ends differ, so that the whole texts are left to diff
+	from := "début " + strings.Repeat("mot ", 5000) + "fin"
+	to := "commencement " + strings.Repeat("terme ", 5000) + "conclusion"
+
+	edits := Words(from, to)
+
+	assert.Equal(t, []Edit{
+		{Operation: OperationDelete, Text: from},
+		{Operation: OperationInsert, Text: to},
+	}, edits)
+}
+
+func TestSets(t *testing.T) {
+	added, removed := Sets([]string{"a", "b", "c"}, []string{"b", "c", "d"})
+
+	assert.Equal(t, []string{"d"}, added)
+	assert.Equal(t, []string{"a"}, removed)
+
+	added, removed = Sets([]string{"a"}, []string{"a"})
+	assert.NotNil(t, added)
+	assert.NotNil(t, removed)
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
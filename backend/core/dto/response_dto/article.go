@@ -1,6 +1,7 @@
 package response_dto
 
 import (
+	"slices"
 	"time"
 	"vdm/core/models"
 
@@ -26,11 +27,15 @@ type Article struct {
 	Minor int16 `json:"minor,omitempty"`
 	Major int16 `json:"major,omitempty"`
 
-	Review *ArticleReview `json:"review,omitempty"`
+	CorrectionNote string     `json:"correctionNote,omitempty"`
+	PublishedAt    *time.Time `json:"publishedAt,omitempty"`
 
-	Sources     []string     `json:"sources,omitempty"`
-	Politicians []Politician `json:"politicians,omitempty"`
-	Tags        []string     `json:"tags,omitempty"`
+	Review  *ArticleReview  `json:"review,omitempty"`
+	Reviews []ArticleReview `json:"reviews,omitempty"`
+
+	Sources     []ArticleSource `json:"sources,omitempty"`
+	Politicians []Politician    `json:"politicians,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
 }
 
 func NewArticle(entity models.Article) Article {
@@ -44,6 +49,9 @@ func NewArticle(entity models.Article) Article {
 		UpdatedAt: entity.UpdatedAt,
 		Minor:     entity.Minor,
 		Major:     entity.Major,
+
+		CorrectionNote: entity.CorrectionNote,
+		PublishedAt:    entity.PublishedAt,
 	}
 
 	if entity.Reference != uuid.Nil {
@@ -58,15 +66,21 @@ func NewArticle(entity models.Article) Article {
 		dto.ModeratorTag = entity.Moderator.Tag
 	}
 
-	if entity.Review != nil {
-		reviewDTO := NewArticleReview(*entity.Review)
-		dto.Review = &reviewDTO
+	if len(entity.Reviews) > 0 {
+		reviews := slices.Clone(entity.Reviews)
+		slices.SortStableFunc(reviews, func(a, b *models.ArticleReview) int { return b.CreatedAt.Compare(a.CreatedAt) })
+
+		dto.Reviews = make([]ArticleReview, len(reviews))
+		for i := range reviews {
+			dto.Reviews[i] = NewArticleReview(*reviews[i])
+		}
+		dto.Review = &dto.Reviews[0] // latest decision
 	}
 
 	if len(entity.Sources) > 0 {
-		dto.Sources = make([]string, len(entity.Sources))
+		dto.Sources = make([]ArticleSource, len(entity.Sources))
 		for i := range entity.Sources {
-			dto.Sources[i] = entity.Sources[i].URL
+			dto.Sources[i] = NewArticleSource(*entity.Sources[i])
 		}
 	}
 
@@ -75,6 +89,15 @@ func NewArticle(entity models.Article) Article {
 		for i := range entity.Politicians {
 			dto.Politicians[i] = NewPolitician(*entity.Politicians[i])
 		}
+
+		// offices are only available when ArticlePoliticians are preloaded
+		for _, ap := range entity.ArticlePoliticians {
+			for i := range dto.Politicians {
+				if dto.Politicians[i].ID == ap.PoliticianID {
+					dto.Politicians[i].Office = ap.Office
+				}
+			}
+		}
 	}
 
 	if len(entity.Tags) > 0 {
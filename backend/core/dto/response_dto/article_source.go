@@ -0,0 +1,26 @@
+package response_dto
+
+import (
+	"time"
+	"vdm/core/models"
+)
+
+type ArticleSource struct {
+	URL              string                   `json:"url"`
+	Type             models.ArticleSourceType `json:"type,omitempty"`
+	Publisher        string                   `json:"publisher,omitempty"`
+	PublishedAt      *time.Time               `json:"publishedAt,omitempty"`
+	TimestampSeconds *int                     `json:"timestampSeconds,omitempty"`
+	Quote            string                   `json:"quote,omitempty"`
+}
+
+func NewArticleSource(entity models.ArticleSource) ArticleSource {
+	return ArticleSource{
+		URL:              entity.URL,
+		Type:             entity.Type,
+		Publisher:        entity.Publisher,
+		PublishedAt:      entity.PublishedAt,
+		TimestampSeconds: entity.TimestampSeconds,
+		Quote:            entity.Quote,
+	}
+}
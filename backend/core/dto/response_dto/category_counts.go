@@ -0,0 +1,8 @@
+package response_dto
+
+// CategoryCounts counts published articles per category
+type CategoryCounts struct {
+	Lies       int64 `json:"lies"`
+	Falsehoods int64 `json:"falsehoods"`
+	Total      int64 `json:"total"`
+}
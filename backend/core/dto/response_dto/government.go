@@ -0,0 +1,40 @@
+package response_dto
+
+import (
+	"time"
+	"vdm/core/models"
+)
+
+type Government struct {
+	Reference     int16       `json:"reference"`
+	PrimeMinister *Politician `json:"primeMinister,omitempty"`
+	StartDate     time.Time   `json:"startDate"`
+	EndDate       *time.Time  `json:"endDate,omitempty"`
+
+	Cabinet []Occupation `json:"cabinet,omitempty"`
+}
+
+func NewGovernment(entity models.Government) Government {
+	dto := Government{
+		Reference: entity.Reference,
+		StartDate: entity.StartDate,
+	}
+
+	if entity.PrimeMinister != nil {
+		primeMinisterDTO := NewPolitician(*entity.PrimeMinister)
+		dto.PrimeMinister = &primeMinisterDTO
+	}
+
+	if entity.EndDate.Valid {
+		dto.EndDate = &entity.EndDate.Time
+	}
+
+	if len(entity.Occupations) > 0 {
+		dto.Cabinet = make([]Occupation, len(entity.Occupations))
+		for i := range entity.Occupations {
+			dto.Cabinet[i] = NewOccupation(*entity.Occupations[i])
+		}
+	}
+
+	return dto
+}
@@ -0,0 +1,43 @@
+package response_dto
+
+import (
+	"time"
+	"vdm/core/models"
+)
+
+type Occupation struct {
+	Code      string     `json:"code"`
+	Title     string     `json:"title"`
+	StartDate time.Time  `json:"startDate"`
+	EndDate   *time.Time `json:"endDate,omitempty"`
+
+	PresidentialReference *int16 `json:"presidentialReference,omitempty"`
+
+	Politician *Politician `json:"politician,omitempty"`
+	Government *Government `json:"government,omitempty"`
+}
+
+func NewOccupation(entity models.Occupation) Occupation {
+	dto := Occupation{
+		Code:                  entity.Code,
+		Title:                 entity.Title,
+		StartDate:             entity.StartDate,
+		PresidentialReference: entity.PresidentialReference,
+	}
+
+	if entity.EndDate.Valid {
+		dto.EndDate = &entity.EndDate.Time
+	}
+
+	if entity.Politician != nil {
+		politicianDTO := NewPolitician(*entity.Politician)
+		dto.Politician = &politicianDTO
+	}
+
+	if entity.Government != nil {
+		governmentDTO := NewGovernment(*entity.Government)
+		dto.Government = &governmentDTO
+	}
+
+	return dto
+}
@@ -0,0 +1,42 @@
+package response_dto
+
+import (
+	"time"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+)
+
+type Party struct {
+	ID   uuid.UUID `json:"id"`
+	Code string    `json:"code"`
+	Name string    `json:"name"`
+}
+
+func NewParty(entity models.Party) Party {
+	return Party{
+		ID:   entity.ID,
+		Code: entity.Code,
+		Name: entity.Name,
+	}
+}
+
+type Affiliation struct {
+	Party     Party      `json:"party"`
+	StartDate time.Time  `json:"startDate"`
+	EndDate   *time.Time `json:"endDate,omitempty"`
+}
+
+func NewAffiliation(entity models.PoliticianAffiliation) Affiliation {
+	dto := Affiliation{StartDate: entity.StartDate}
+
+	if entity.Party != nil {
+		dto.Party = NewParty(*entity.Party)
+	}
+
+	if entity.EndDate.Valid {
+		dto.EndDate = &entity.EndDate.Time
+	}
+
+	return dto
+}
@@ -9,11 +9,31 @@ import (
 type Politician struct {
 	ID       uuid.UUID `json:"id"`
 	FullName string    `json:"fullName"`
+	Office   string    `json:"office,omitempty"`
+	Party    *Party    `json:"party,omitempty"`
 }
 
+// NewPolitician sets the current party when the affiliations of the politician are loaded
 func NewPolitician(entity models.Politician) Politician {
-	return Politician{
+	dto := Politician{
 		ID:       entity.ID,
 		FullName: entity.FirstName + " " + entity.LastName,
 	}
+
+	var current *models.PoliticianAffiliation
+	for _, affiliation := range entity.Affiliations {
+		if affiliation.EndDate.Valid || affiliation.Party == nil {
+			continue
+		}
+		if current == nil || affiliation.StartDate.After(current.StartDate) {
+			current = affiliation
+		}
+	}
+
+	if current != nil {
+		partyDTO := NewParty(*current.Party)
+		dto.Party = &partyDTO
+	}
+
+	return dto
 }
@@ -0,0 +1,57 @@
+package response_dto
+
+import (
+	"time"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+)
+
+type ReviewComment struct {
+	ID         uuid.UUID            `json:"id"`
+	AuthorTag  string               `json:"authorTag,omitempty"`
+	Anchor     *ReviewCommentAnchor `json:"anchor,omitempty"`
+	Quote      string               `json:"quote,omitempty"`
+	SourceURL  string               `json:"sourceUrl,omitempty"`
+	Text       string               `json:"text"`
+	ResolvedAt *time.Time           `json:"resolvedAt,omitempty"`
+	CreatedAt  time.Time            `json:"createdAt"`
+
+	Replies []ReviewComment `json:"replies,omitempty"`
+}
+
+type ReviewCommentAnchor struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+func NewReviewComment(entity models.ReviewComment) ReviewComment {
+	dto := ReviewComment{
+		ID:         entity.ID,
+		Quote:      entity.Quote,
+		Text:       entity.Text,
+		ResolvedAt: entity.ResolvedAt,
+		CreatedAt:  entity.CreatedAt,
+	}
+
+	if entity.Author != nil {
+		dto.AuthorTag = entity.Author.Tag
+	}
+
+	if entity.AnchorStart != nil && entity.AnchorEnd != nil {
+		dto.Anchor = &ReviewCommentAnchor{Start: *entity.AnchorStart, End: *entity.AnchorEnd}
+	}
+
+	if entity.SourceURL != nil {
+		dto.SourceURL = *entity.SourceURL
+	}
+
+	if len(entity.Replies) > 0 {
+		dto.Replies = make([]ReviewComment, len(entity.Replies))
+		for i := range entity.Replies {
+			dto.Replies[i] = NewReviewComment(*entity.Replies[i])
+		}
+	}
+
+	return dto
+}
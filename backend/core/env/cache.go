@@ -0,0 +1,57 @@
+package env
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	CacheStoreMemory = "memory"
+	CacheStoreRedis  = "redis"
+)
+
+type CacheConfig struct {
+	// Store is either memory, local to each replica, or redis, shared by all replicas
+	Store    string
+	RedisURL string
+	// MaxEntries is the number of responses a memory store keeps, the least recently used are evicted first
+	MaxEntries int
+	// TTL is how long a response is kept by the server when nothing invalidates it
+	TTL time.Duration
+	// MaxAge is how long clients may reuse a response before revalidating it with its ETag
+	MaxAge time.Duration
+	// WatchInterval is how often data changed outside the API, such as imported politicians, is looked for
+	WatchInterval time.Duration
+}
+
+func loadCacheConfig() (CacheConfig, error) {
+	ttl, err := time.ParseDuration(getEnv("CACHE_TTL", "24h"))
+	if err != nil {
+		return CacheConfig{}, fmt.Errorf("failed to parse CACHE_TTL: %v", err)
+	}
+
+	maxAge, err := time.ParseDuration(getEnv("CACHE_MAX_AGE", "1m"))
+	if err != nil {
+		return CacheConfig{}, fmt.Errorf("failed to parse CACHE_MAX_AGE: %v", err)
+	}
+
+	watchInterval, err := time.ParseDuration(getEnv("CACHE_WATCH_INTERVAL", "1m"))
+	if err != nil {
+		return CacheConfig{}, fmt.Errorf("failed to parse CACHE_WATCH_INTERVAL: %v", err)
+	}
+
+	maxEntries, err := strconv.Atoi(getEnv("CACHE_MAX_ENTRIES", "10000"))
+	if err != nil {
+		return CacheConfig{}, fmt.Errorf("failed to parse CACHE_MAX_ENTRIES: %v", err)
+	}
+
+	return CacheConfig{
+		Store:         getEnv("CACHE_STORE", CacheStoreMemory),
+		RedisURL:      getEnv("CACHE_REDIS_URL", ""),
+		MaxEntries:    maxEntries,
+		TTL:           ttl,
+		MaxAge:        maxAge,
+		WatchInterval: watchInterval,
+	}, nil
+}
@@ -1,6 +1,7 @@
 package env
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 )
@@ -11,6 +12,11 @@ type Config struct {
 	Database      DatabaseConfig
 	Security      SecurityConfig
 	Mailer        MailerConfig
+	Moderation    ModerationConfig
+	Sources       SourcesConfig
+	Cache         CacheConfig
+	Lockout       LockoutConfig
+	Proxy         ProxyConfig
 }
 
 func LoadConfig() (Config, error) {
@@ -29,12 +35,37 @@ func LoadConfig() (Config, error) {
 		return Config{}, fmt.Errorf("failed to load mailer config: %v", err)
 	}
 
+	moderationConfig, err := loadModerationConfig()
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to load moderation config: %v", err)
+	}
+
+	sourcesConfig, err := loadSourcesConfig()
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to load sources config: %v", err)
+	}
+
+	cacheConfig, err := loadCacheConfig()
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to load cache config: %v", err)
+	}
+
+	lockoutConfig, err := loadLockoutConfig()
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to load lockout config: %v", err)
+	}
+
 	return Config{
 		ActiveProfile: getEnv("ACTIVE_PROFILE", "test"),
 		ClientURL:     getEnv("CLIENT_URL", "http://localhost:5173"),
 		Database:      dbConfig,
 		Security:      securityConfig,
 		Mailer:        mailerConfig,
+		Moderation:    moderationConfig,
+		Sources:       sourcesConfig,
+		Cache:         cacheConfig,
+		Lockout:       lockoutConfig,
+		Proxy:         loadProxyConfig(),
 	}, nil
 }
 
@@ -46,10 +77,75 @@ func (e Config) Validate() error {
 	if e.Security.RefreshTokenTTL <= 0 {
 		return fmt.Errorf("REFRESH_TOKEN_TTL must be > 0")
 	}
+	if e.Security.WebAuthnChallengeTTL <= 0 {
+		return fmt.Errorf("WEBAUTHN_CHALLENGE_TTL must be > 0")
+	}
+	if e.Moderation.ClaimTTL <= 0 {
+		return fmt.Errorf("MODERATION_CLAIM_TTL must be > 0")
+	}
+	if e.Moderation.ClaimSweepInterval <= 0 {
+		return fmt.Errorf("MODERATION_CLAIM_SWEEP_INTERVAL must be > 0")
+	}
+	if e.Moderation.PublicationQuorum < 1 {
+		return fmt.Errorf("MODERATION_PUBLICATION_QUORUM must be >= 1")
+	}
+	if e.Sources.CheckInterval <= 0 || e.Sources.RecheckAfter <= 0 || e.Sources.FetchTimeout <= 0 {
+		return fmt.Errorf("SOURCES_CHECK_INTERVAL, SOURCES_RECHECK_AFTER and SOURCES_FETCH_TIMEOUT must be > 0")
+	}
+	if e.Cache.TTL <= 0 || e.Cache.MaxAge < 0 || e.Cache.WatchInterval <= 0 {
+		return fmt.Errorf("CACHE_TTL and CACHE_WATCH_INTERVAL must be > 0, CACHE_MAX_AGE must be >= 0")
+	}
+	switch e.Cache.Store {
+	case CacheStoreMemory:
+		if e.Cache.MaxEntries < 1 {
+			return fmt.Errorf("CACHE_MAX_ENTRIES must be >= 1 when CACHE_STORE is %s", CacheStoreMemory)
+		}
+	case CacheStoreRedis:
+		if e.Cache.RedisURL == "" {
+			return fmt.Errorf("CACHE_REDIS_URL is required when CACHE_STORE is %s", CacheStoreRedis)
+		}
+	default:
+		return fmt.Errorf("CACHE_STORE must be %s or %s", CacheStoreMemory, CacheStoreRedis)
+	}
+	if e.Lockout.BackoffAfter < 1 || e.Lockout.AccountMaxFailures <= e.Lockout.BackoffAfter || e.Lockout.IPMaxFailures <= e.Lockout.BackoffAfter {
+		return fmt.Errorf("LOCKOUT_BACKOFF_AFTER must be >= 1, LOCKOUT_ACCOUNT_MAX_FAILURES and LOCKOUT_IP_MAX_FAILURES must exceed it")
+	}
+	if e.Lockout.BackoffBase <= 0 || e.Lockout.BackoffMax < e.Lockout.BackoffBase || e.Lockout.Duration <= 0 {
+		return fmt.Errorf("LOCKOUT_BACKOFF_BASE and LOCKOUT_DURATION must be > 0, LOCKOUT_BACKOFF_MAX must be >= LOCKOUT_BACKOFF_BASE")
+	}
+	if e.Lockout.FailuresTTL < e.Lockout.Duration {
+		return fmt.Errorf("LOCKOUT_FAILURES_TTL must be >= LOCKOUT_DURATION")
+	}
+	switch e.Lockout.Store {
+	case LockoutStoreMemory:
+	case LockoutStoreRedis:
+		if e.Lockout.RedisURL == "" {
+			return fmt.Errorf("LOCKOUT_REDIS_URL is required when LOCKOUT_STORE is %s", LockoutStoreRedis)
+		}
+	default:
+		return fmt.Errorf("LOCKOUT_STORE must be %s or %s", LockoutStoreMemory, LockoutStoreRedis)
+	}
+	// an MFA token signed with the access token secret would otherwise pass for an access token, skipping the second factor
+	if len(e.Security.MfaTokenSecret) != 0 && bytes.Equal(e.Security.MfaTokenSecret, e.Security.AccessTokenSecret) {
+		return fmt.Errorf("MFA_TOKEN_SECRET must differ from ACCESS_TOKEN_SECRET")
+	}
+	if e.Proxy.Header != "" && len(e.Proxy.TrustedProxies) == 0 {
+		return fmt.Errorf("TRUSTED_PROXIES is required when PROXY_HEADER is set")
+	}
 	if e.ActiveProfile == "prod" {
+		// behind the ingress, every client would otherwise share its IP and the lockouts by IP
+		if e.Proxy.Header == "" {
+			return fmt.Errorf("PROXY_HEADER is required in prod")
+		}
 		if len(e.Security.AccessTokenSecret) == 0 {
 			return fmt.Errorf("ACCESS_TOKEN_SECRET is required in prod")
 		}
+		if len(e.Security.MfaTokenSecret) == 0 {
+			return fmt.Errorf("MFA_TOKEN_SECRET is required in prod")
+		}
+		if len(e.Security.MfaEncryptionKey) == 0 {
+			return fmt.Errorf("MFA_ENCRYPTION_KEY is required in prod")
+		}
 		if e.Database.Host == "" || e.Database.User == "" || e.Database.Name == "" {
 			return fmt.Errorf("DB_* vars (host,user,name) are required in prod")
 		}
@@ -0,0 +1,77 @@
+package env
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	LockoutStoreMemory = "memory"
+	LockoutStoreRedis  = "redis"
+)
+
+type LockoutConfig struct {
+	// Store is either memory, local to each replica, or redis, shared by all replicas
+	Store    string
+	RedisURL string
+	// BackoffAfter is the number of failed sign-ins after which every new failure doubles the wait before the next attempt
+	BackoffAfter int64
+	BackoffBase  time.Duration
+	BackoffMax   time.Duration
+	// AccountMaxFailures and IPMaxFailures are the numbers of failed sign-ins locking an account or an IP for Duration
+	AccountMaxFailures int64
+	IPMaxFailures      int64
+	Duration           time.Duration
+	// FailuresTTL is how long failed sign-ins are remembered after the last one
+	FailuresTTL time.Duration
+}
+
+func loadLockoutConfig() (LockoutConfig, error) {
+	backoffAfter, err := strconv.ParseInt(getEnv("LOCKOUT_BACKOFF_AFTER", "3"), 10, 64)
+	if err != nil {
+		return LockoutConfig{}, fmt.Errorf("failed to parse LOCKOUT_BACKOFF_AFTER: %v", err)
+	}
+
+	backoffBase, err := time.ParseDuration(getEnv("LOCKOUT_BACKOFF_BASE", "1s"))
+	if err != nil {
+		return LockoutConfig{}, fmt.Errorf("failed to parse LOCKOUT_BACKOFF_BASE: %v", err)
+	}
+
+	backoffMax, err := time.ParseDuration(getEnv("LOCKOUT_BACKOFF_MAX", "5m"))
+	if err != nil {
+		return LockoutConfig{}, fmt.Errorf("failed to parse LOCKOUT_BACKOFF_MAX: %v", err)
+	}
+
+	accountMaxFailures, err := strconv.ParseInt(getEnv("LOCKOUT_ACCOUNT_MAX_FAILURES", "10"), 10, 64)
+	if err != nil {
+		return LockoutConfig{}, fmt.Errorf("failed to parse LOCKOUT_ACCOUNT_MAX_FAILURES: %v", err)
+	}
+
+	ipMaxFailures, err := strconv.ParseInt(getEnv("LOCKOUT_IP_MAX_FAILURES", "50"), 10, 64)
+	if err != nil {
+		return LockoutConfig{}, fmt.Errorf("failed to parse LOCKOUT_IP_MAX_FAILURES: %v", err)
+	}
+
+	duration, err := time.ParseDuration(getEnv("LOCKOUT_DURATION", "30m"))
+	if err != nil {
+		return LockoutConfig{}, fmt.Errorf("failed to parse LOCKOUT_DURATION: %v", err)
+	}
+
+	failuresTTL, err := time.ParseDuration(getEnv("LOCKOUT_FAILURES_TTL", "24h"))
+	if err != nil {
+		return LockoutConfig{}, fmt.Errorf("failed to parse LOCKOUT_FAILURES_TTL: %v", err)
+	}
+
+	return LockoutConfig{
+		Store:              getEnv("LOCKOUT_STORE", LockoutStoreMemory),
+		RedisURL:           getEnv("LOCKOUT_REDIS_URL", ""),
+		BackoffAfter:       backoffAfter,
+		BackoffBase:        backoffBase,
+		BackoffMax:         backoffMax,
+		AccountMaxFailures: accountMaxFailures,
+		IPMaxFailures:      ipMaxFailures,
+		Duration:           duration,
+		FailuresTTL:        failuresTTL,
+	}, nil
+}
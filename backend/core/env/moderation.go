@@ -0,0 +1,39 @@
+package env
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type ModerationConfig struct {
+	// ClaimTTL is how long a moderator may keep an article under review before it goes back to the pending queue
+	ClaimTTL time.Duration
+	// ClaimSweepInterval is how often expired claims are released
+	ClaimSweepInterval time.Duration
+	// PublicationQuorum is the number of distinct moderators who must approve an article before it is published
+	PublicationQuorum int
+}
+
+func loadModerationConfig() (ModerationConfig, error) {
+	claimTTL, err := time.ParseDuration(getEnv("MODERATION_CLAIM_TTL", "72h"))
+	if err != nil {
+		return ModerationConfig{}, fmt.Errorf("failed to parse MODERATION_CLAIM_TTL: %v", err)
+	}
+
+	claimSweepInterval, err := time.ParseDuration(getEnv("MODERATION_CLAIM_SWEEP_INTERVAL", "15m"))
+	if err != nil {
+		return ModerationConfig{}, fmt.Errorf("failed to parse MODERATION_CLAIM_SWEEP_INTERVAL: %v", err)
+	}
+
+	publicationQuorum, err := strconv.Atoi(getEnv("MODERATION_PUBLICATION_QUORUM", "2"))
+	if err != nil {
+		return ModerationConfig{}, fmt.Errorf("failed to parse MODERATION_PUBLICATION_QUORUM: %v", err)
+	}
+
+	return ModerationConfig{
+		ClaimTTL:           claimTTL,
+		ClaimSweepInterval: claimSweepInterval,
+		PublicationQuorum:  publicationQuorum,
+	}, nil
+}
@@ -0,0 +1,24 @@
+package env
+
+import "strings"
+
+type ProxyConfig struct {
+	// Header carries the IP of the client, set by the reverse proxy in front of the API. Empty when the API is reached directly.
+	Header string
+	// TrustedProxies are the IPs and CIDRs of the reverse proxies, Header being ignored on requests coming from anywhere else
+	TrustedProxies []string
+}
+
+func loadProxyConfig() ProxyConfig {
+	var trustedProxies []string
+	for _, proxy := range strings.Split(getEnv("TRUSTED_PROXIES", ""), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+
+	return ProxyConfig{
+		Header:         getEnv("PROXY_HEADER", ""),
+		TrustedProxies: trustedProxies,
+	}
+}
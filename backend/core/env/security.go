@@ -1,7 +1,9 @@
 package env
 
 import (
+	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -18,6 +20,21 @@ type SecurityConfig struct {
 	PasswordTokenSecret []byte
 	PasswordTokenTTL    time.Duration
 
+	// MfaTokenSecret signs the challenges of the second sign-in step
+	MfaTokenSecret []byte
+	MfaTokenTTL    time.Duration
+	// MfaEncryptionKey encrypts the TOTP secrets and keys the hashes of recovery codes
+	MfaEncryptionKey []byte
+	// MfaIssuer names the account in authenticator apps
+	MfaIssuer string
+
+	// WebAuthnRPID is the domain passkeys are bound to, WebAuthnRPOrigins the origins of the client allowed to use them
+	WebAuthnRPID      string
+	WebAuthnRPName    string
+	WebAuthnRPOrigins []string
+	// WebAuthnChallengeTTL is how long a passkey registration or sign-in ceremony can take
+	WebAuthnChallengeTTL time.Duration
+
 	RefreshCookieName string
 	AccessCookieName  string
 	CsrfCookieName    string
@@ -46,19 +63,45 @@ func loadSecurityConfig() (SecurityConfig, error) {
 		return SecurityConfig{}, fmt.Errorf("failed to parse PASSWORD_TOKEN_TTL: %v", err)
 	}
 
+	mfaTokenTTL, err := time.ParseDuration(getEnv("MFA_TOKEN_TTL", "5m"))
+	if err != nil {
+		return SecurityConfig{}, fmt.Errorf("failed to parse MFA_TOKEN_TTL: %v", err)
+	}
+
+	mfaEncryptionKey, err := base64.StdEncoding.DecodeString(getEnv("MFA_ENCRYPTION_KEY", ""))
+	if err != nil {
+		return SecurityConfig{}, fmt.Errorf("failed to parse MFA_ENCRYPTION_KEY: %v", err)
+	}
+	if len(mfaEncryptionKey) != 0 && len(mfaEncryptionKey) != 32 {
+		return SecurityConfig{}, fmt.Errorf("MFA_ENCRYPTION_KEY must be 32 bytes long, got %d", len(mfaEncryptionKey))
+	}
+
+	webAuthnChallengeTTL, err := time.ParseDuration(getEnv("WEBAUTHN_CHALLENGE_TTL", "5m"))
+	if err != nil {
+		return SecurityConfig{}, fmt.Errorf("failed to parse WEBAUTHN_CHALLENGE_TTL: %v", err)
+	}
+
 	return SecurityConfig{
-		EmailTokenSecret:    []byte(getEnv("EMAIL_TOKEN_SECRET", "")),
-		EmailTokenTTL:       emailTokenTTL,
-		PasswordTokenSecret: []byte(getEnv("PASSWORD_TOKEN_SECRET", "")),
-		PasswordTokenTTL:    passwordTokenTTL,
-		AccessTokenSecret:   []byte(getEnv("ACCESS_TOKEN_SECRET", "")),
-		AccessTokenTTL:      accessTokenTTL,
-		RefreshTokenSecret:  []byte(getEnv("REFRESH_TOKEN_SECRET", "")),
-		RefreshTokenTTL:     refreshTokenTTL,
-		AccessCookieName:    getEnv("ACCESS_COOKIE_NAME", "__Host-jwt"),
-		RefreshCookieName:   getEnv("REFRESH_COOKIE_NAME", "__Host-rft"),
-		CookieSameSite:      getEnv("COOKIE_SAME_SITE", "strict"),
-		CookieSecure:        getEnv("COOKIE_SECURE", "true") == "true",
-		CsrfCookieName:      getEnv("CSRF_COOKIE_NAME", "__Host-csrf_"),
+		EmailTokenSecret:     []byte(getEnv("EMAIL_TOKEN_SECRET", "")),
+		EmailTokenTTL:        emailTokenTTL,
+		PasswordTokenSecret:  []byte(getEnv("PASSWORD_TOKEN_SECRET", "")),
+		PasswordTokenTTL:     passwordTokenTTL,
+		MfaTokenSecret:       []byte(getEnv("MFA_TOKEN_SECRET", "")),
+		MfaTokenTTL:          mfaTokenTTL,
+		MfaEncryptionKey:     mfaEncryptionKey,
+		MfaIssuer:            getEnv("MFA_ISSUER", "Vigie du mensonge"),
+		WebAuthnRPID:         getEnv("WEBAUTHN_RP_ID", "localhost"),
+		WebAuthnRPName:       getEnv("WEBAUTHN_RP_NAME", "Vigie du mensonge"),
+		WebAuthnRPOrigins:    strings.Split(getEnv("WEBAUTHN_RP_ORIGINS", "http://localhost:5173"), ","),
+		WebAuthnChallengeTTL: webAuthnChallengeTTL,
+		AccessTokenSecret:    []byte(getEnv("ACCESS_TOKEN_SECRET", "")),
+		AccessTokenTTL:       accessTokenTTL,
+		RefreshTokenSecret:   []byte(getEnv("REFRESH_TOKEN_SECRET", "")),
+		RefreshTokenTTL:      refreshTokenTTL,
+		AccessCookieName:     getEnv("ACCESS_COOKIE_NAME", "__Host-jwt"),
+		RefreshCookieName:    getEnv("REFRESH_COOKIE_NAME", "__Host-rft"),
+		CookieSameSite:       getEnv("COOKIE_SAME_SITE", "strict"),
+		CookieSecure:         getEnv("COOKIE_SECURE", "true") == "true",
+		CsrfCookieName:       getEnv("CSRF_COOKIE_NAME", "__Host-csrf_"),
 	}, nil
 }
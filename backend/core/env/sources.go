@@ -0,0 +1,39 @@
+package env
+
+import (
+	"fmt"
+	"time"
+)
+
+type SourcesConfig struct {
+	// CheckInterval is how often new sources are archived and stale ones re-checked,
+	// and the first delay before fetching again a source that couldn't be captured
+	CheckInterval time.Duration
+	// RecheckAfter is how long a source stays checked before its link is verified again
+	RecheckAfter time.Duration
+	// FetchTimeout bounds each request to a source
+	FetchTimeout time.Duration
+}
+
+func loadSourcesConfig() (SourcesConfig, error) {
+	checkInterval, err := time.ParseDuration(getEnv("SOURCES_CHECK_INTERVAL", "5m"))
+	if err != nil {
+		return SourcesConfig{}, fmt.Errorf("failed to parse SOURCES_CHECK_INTERVAL: %v", err)
+	}
+
+	recheckAfter, err := time.ParseDuration(getEnv("SOURCES_RECHECK_AFTER", "168h"))
+	if err != nil {
+		return SourcesConfig{}, fmt.Errorf("failed to parse SOURCES_RECHECK_AFTER: %v", err)
+	}
+
+	fetchTimeout, err := time.ParseDuration(getEnv("SOURCES_FETCH_TIMEOUT", "15s"))
+	if err != nil {
+		return SourcesConfig{}, fmt.Errorf("failed to parse SOURCES_FETCH_TIMEOUT: %v", err)
+	}
+
+	return SourcesConfig{
+		CheckInterval: checkInterval,
+		RecheckAfter:  recheckAfter,
+		FetchTimeout:  fetchTimeout,
+	}, nil
+}
@@ -2,14 +2,31 @@ package fiberx
 
 import (
 	"errors"
+	"vdm/core/env"
 	"vdm/core/logger"
 
 	"github.com/bytedance/sonic"
 	"github.com/gofiber/fiber/v2"
 )
 
-func NewApp() *fiber.App {
-	return fiber.New(fiber.Config{
+type Option func(cfg *fiber.Config)
+
+// WithProxy makes c.IP return the IP of the client rather than the one of the reverse proxy the request came through.
+// The header is only read on requests coming from a trusted proxy, so that clients can't choose their IP.
+func WithProxy(proxy env.ProxyConfig) Option {
+	return func(cfg *fiber.Config) {
+		if proxy.Header == "" {
+			return
+		}
+		cfg.ProxyHeader = proxy.Header
+		cfg.EnableTrustedProxyCheck = true
+		cfg.TrustedProxies = proxy.TrustedProxies
+		cfg.EnableIPValidation = true
+	}
+}
+
+func NewApp(opts ...Option) *fiber.App {
+	cfg := fiber.Config{
 		JSONEncoder: sonic.ConfigFastest.Marshal,
 		JSONDecoder: sonic.ConfigFastest.Unmarshal,
 		BodyLimit:   2 * 1024 * 1024,
@@ -30,5 +47,11 @@ func NewApp() *fiber.App {
 
 			return c.SendStatus(code)
 		},
-	})
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return fiber.New(cfg)
 }
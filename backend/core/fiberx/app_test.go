@@ -0,0 +1,49 @@
+package fiberx
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+	"vdm/core/env"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newIPApp(proxy env.ProxyConfig) *fiber.App {
+	app := NewApp(WithProxy(proxy))
+	app.Get("/ip", func(c *fiber.Ctx) error {
+		return c.SendString(c.IP())
+	})
+	return app
+}
+
+func getIP(t *testing.T, app *fiber.App, realIP string) string {
+	req := httptest.NewRequest(fiber.MethodGet, "/ip", nil)
+	req.Header.Set("X-Real-Ip", realIP)
+
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestWithProxy(t *testing.T) {
+	// requests made by app.Test come from 0.0.0.0
+	trusted := newIPApp(env.ProxyConfig{Header: "X-Real-Ip", TrustedProxies: []string{"0.0.0.0/8"}})
+	assert.Equal(t, "203.0.113.7", getIP(t, trusted, "203.0.113.7"))
+	assert.Equal(t, "0.0.0.0", getIP(t, trusted, "not an ip"))
+
+	untrusted := newIPApp(env.ProxyConfig{Header: "X-Real-Ip", TrustedProxies: []string{"10.0.0.0/8"}})
+	assert.Equal(t, "0.0.0.0", getIP(t, untrusted, "203.0.113.7"))
+
+	direct := newIPApp(env.ProxyConfig{})
+	assert.Equal(t, "0.0.0.0", getIP(t, direct, "203.0.113.7"))
+}
@@ -17,3 +17,13 @@ func HashUUID(raw uuid.UUID, secret []byte) string {
 
 	return base64.RawURLEncoding.EncodeToString(sum)
 }
+
+func HashString(raw string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+
+	mac.Write([]byte(raw))
+
+	sum := mac.Sum(nil)
+
+	return base64.RawURLEncoding.EncodeToString(sum)
+}
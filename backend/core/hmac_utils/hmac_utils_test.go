@@ -23,3 +23,19 @@ func TestHmacUtils(t *testing.T) {
 		t.Fatalf("Hashes match with wrong secret: %s == %s", h1, h3)
 	}
 }
+
+func TestHmacUtils_String(t *testing.T) {
+	secret := []byte("secret")
+
+	h1 := HashString("abcde23456", secret)
+	h2 := HashString("abcde23456", secret)
+
+	if h1 != h2 {
+		t.Fatalf("Hashes do not match: %s != %s", h1, h2)
+	}
+
+	h3 := HashString("abcde23456", []byte("wrong-secret"))
+	if h1 == h3 {
+		t.Fatalf("Hashes match with wrong secret: %s == %s", h1, h3)
+	}
+}
@@ -0,0 +1,221 @@
+// Package http_cache caches the responses of public routes, answers conditional requests with strong ETags,
+// and drops cached responses when the data they were built from changes.
+package http_cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"vdm/core/env"
+	"vdm/core/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Scope is a set of data that cached responses are built from. Invalidating a scope drops every response built from it.
+type Scope string
+
+const (
+	ScopeArticles    Scope = "articles"
+	ScopePoliticians Scope = "politicians"
+)
+
+const keyPrefix = "http_cache:"
+
+// storedHeaders are replayed with a cached body
+var storedHeaders = []string{fiber.HeaderContentType, fiber.HeaderLastModified, fiber.HeaderVary}
+
+// Invalidator is implemented by Cache, for the routes that change cached data
+type Invalidator interface {
+	Invalidate(ctx context.Context, scopes ...Scope) error
+}
+
+type Cache struct {
+	store  Store
+	ttl    time.Duration
+	maxAge time.Duration
+}
+
+type entry struct {
+	Headers map[string]string `json:"headers"`
+	Body    []byte            `json:"body"`
+}
+
+func New(store Store, ttl, maxAge time.Duration) *Cache {
+	return &Cache{store: store, ttl: ttl, maxAge: maxAge}
+}
+
+func NewStore(cfg env.CacheConfig) (Store, error) {
+	switch cfg.Store {
+	case env.CacheStoreRedis:
+		return NewRedisStore(cfg.RedisURL)
+	default:
+		return NewMemoryStore(cfg.MaxEntries), nil
+	}
+}
+
+// Invalidate drops the responses built from scopes. Generations are bumped rather than keys deleted,
+// so that stale entries are never read again and simply expire.
+func (c *Cache) Invalidate(ctx context.Context, scopes ...Scope) error {
+	for _, scope := range scopes {
+		if _, err := c.store.Incr(ctx, generationKey(scope)); err != nil {
+			return fmt.Errorf("failed to invalidate %s: %v", scope, err)
+		}
+	}
+	return nil
+}
+
+// Middleware caches the successful GET responses of the routes registered after it, which are built from scopes.
+// Only the query params in queries, those read by the routes, tell responses apart: the others can't fill the store.
+// The cache is bypassed when the store fails, so that it never makes a route unavailable.
+func (c *Cache) Middleware(queries []string, scopes ...Scope) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if ctx.Method() != fiber.MethodGet && ctx.Method() != fiber.MethodHead {
+			return ctx.Next()
+		}
+
+		key, err := c.key(ctx, queries, scopes)
+		if err != nil {
+			logger.Error("failed to compute cache key", logger.Err(err))
+			return ctx.Next()
+		}
+
+		raw, err := c.store.Get(ctx.UserContext(), key)
+		if err != nil {
+			logger.Error("failed to get cached response", logger.Err(err))
+			return ctx.Next()
+		}
+
+		if raw != nil {
+			var cached entry
+			if err = json.Unmarshal(raw, &cached); err == nil {
+				return c.serve(ctx, cached)
+			}
+			logger.Error("failed to decode cached response", logger.Err(err))
+		}
+
+		if err = ctx.Next(); err != nil {
+			return err
+		}
+
+		if ctx.Method() != fiber.MethodGet || ctx.Response().StatusCode() != fiber.StatusOK {
+			return nil
+		}
+
+		fresh := entry{Headers: make(map[string]string), Body: append([]byte(nil), ctx.Response().Body()...)}
+		for _, header := range storedHeaders {
+			if value := ctx.GetRespHeader(header); value != "" {
+				fresh.Headers[header] = value
+			}
+		}
+
+		if raw, err = json.Marshal(fresh); err != nil {
+			logger.Error("failed to encode response", logger.Err(err))
+		} else if err = c.store.Set(ctx.UserContext(), key, raw, c.ttl); err != nil {
+			logger.Error("failed to cache response", logger.Err(err))
+		}
+
+		return c.serve(ctx, fresh)
+	}
+}
+
+func (c *Cache) serve(ctx *fiber.Ctx, e entry) error {
+	etag := strongETag(e.Body)
+
+	ctx.Set(fiber.HeaderETag, etag)
+	ctx.Set(fiber.HeaderCacheControl, c.cacheControl())
+	for header, value := range e.Headers {
+		ctx.Set(header, value)
+	}
+
+	var lastModified time.Time
+	if value, ok := e.Headers[fiber.HeaderLastModified]; ok {
+		lastModified, _ = http.ParseTime(value)
+	}
+
+	if NotModified(ctx.Get(fiber.HeaderIfNoneMatch), ctx.Get(fiber.HeaderIfModifiedSince), etag, lastModified) {
+		ctx.Response().ResetBody()
+		return ctx.SendStatus(fiber.StatusNotModified)
+	}
+
+	return ctx.Status(fiber.StatusOK).Send(e.Body)
+}
+
+func (c *Cache) cacheControl() string {
+	if c.maxAge <= 0 {
+		return "public, no-cache"
+	}
+	return "public, max-age=" + strconv.Itoa(int(c.maxAge.Seconds()))
+}
+
+// key identifies a representation: the path, the known query params and the negotiated content type,
+// for the current generations of scopes
+func (c *Cache) key(ctx *fiber.Ctx, queries []string, scopes []Scope) (string, error) {
+	var b strings.Builder
+
+	for _, scope := range scopes {
+		raw, err := c.store.Get(ctx.UserContext(), generationKey(scope))
+		if err != nil {
+			return "", err
+		}
+		generation := "0"
+		if raw != nil {
+			generation = string(raw)
+		}
+		b.WriteString(string(scope) + "=" + generation + ";")
+	}
+
+	query := url.Values{}
+	for _, k := range queries {
+		if v := ctx.Query(k); v != "" {
+			query.Set(k, v)
+		}
+	}
+
+	sum := sha256.Sum256([]byte(ctx.Path() + "?" + query.Encode() + "|" + ctx.Get(fiber.HeaderAccept)))
+
+	return keyPrefix + b.String() + hex.EncodeToString(sum[:]), nil
+}
+
+func generationKey(scope Scope) string {
+	return keyPrefix + "generation:" + string(scope)
+}
+
+// strongETag changes with any byte of the body
+func strongETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// NotModified answers a conditional request. If-None-Match takes precedence over If-Modified-Since, as per RFC 9110.
+// If-Modified-Since is ignored when lastModified is zero.
+func NotModified(ifNoneMatch, ifModifiedSince, etag string, lastModified time.Time) bool {
+	if ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			// If-None-Match uses the weak comparison
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+		// Last-Modified has a one second precision
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
@@ -0,0 +1,284 @@
+package http_cache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vdm/core/fiberx"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingStore struct{}
+
+func (failingStore) Get(context.Context, string) ([]byte, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (failingStore) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func (failingStore) Incr(context.Context, string) (int64, error) {
+	return 0, errors.New("connection refused")
+}
+
+// newApp counts the calls reaching the handler, which answers with the current body
+func newApp(cache *Cache, calls *int, body *string) *fiber.App {
+	app := fiberx.NewApp()
+	app.Use(cache.Middleware([]string{"a", "b", "fail"}, ScopeArticles, ScopePoliticians))
+	app.Get("/articles", func(c *fiber.Ctx) error {
+		*calls++
+		if c.Query("fail") != "" {
+			return &fiber.Error{Code: fiber.StatusBadRequest}
+		}
+		return c.Status(fiber.StatusOK).JSON(*body)
+	})
+	return app
+}
+
+func get(app *fiber.App, target string, headers map[string]string) (*response, error) {
+	req := httptest.NewRequest(fiber.MethodGet, target, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := app.Test(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{status: res.StatusCode, etag: res.Header.Get(fiber.HeaderETag),
+		cacheControl: res.Header.Get(fiber.HeaderCacheControl), contentType: res.Header.Get(fiber.HeaderContentType), body: string(body)}, nil
+}
+
+type response struct {
+	status       int
+	etag         string
+	cacheControl string
+	contentType  string
+	body         string
+}
+
+func TestMiddleware_CachesAndRevalidates(t *testing.T) {
+	cache := New(NewMemoryStore(100), time.Hour, time.Minute)
+	calls, body := 0, "v1"
+	app := newApp(cache, &calls, &body)
+
+	first, err := get(app, "/articles?b=2&a=1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusOK, first.status)
+	assert.Equal(t, `"v1"`, first.body)
+	assert.Equal(t, fiber.MIMEApplicationJSON, first.contentType)
+	assert.Equal(t, "public, max-age=60", first.cacheControl)
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, first.etag)
+
+	// the query order does not matter
+	second, err := get(app, "/articles?a=1&b=2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, first.body, second.body)
+	assert.Equal(t, first.etag, second.etag)
+	assert.Equal(t, fiber.MIMEApplicationJSON, second.contentType)
+
+	notModified, err := get(app, "/articles?a=1&b=2", map[string]string{fiber.HeaderIfNoneMatch: first.etag})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, fiber.StatusNotModified, notModified.status)
+	assert.Empty(t, notModified.body)
+	assert.Equal(t, first.etag, notModified.etag)
+	assert.Equal(t, 1, calls)
+}
+
+func TestMiddleware_IgnoresUnknownQueryParams(t *testing.T) {
+	store := newMemoryStore(100, time.Now)
+	cache := New(store, time.Hour, time.Minute)
+	calls, body := 0, "v1"
+	app := newApp(cache, &calls, &body)
+
+	for _, target := range []string{"/articles?a=1", "/articles?a=1&x=1", "/articles?x=2&a=1", "/articles?a=1&b="} {
+		res, err := get(app, target, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fiber.StatusOK, res.status)
+	}
+
+	assert.Equal(t, 1, calls)
+	assert.Len(t, store.items, 1)
+
+	if _, err := get(app, "/articles?a=2", nil); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestMiddleware_Invalidate(t *testing.T) {
+	cache := New(NewMemoryStore(100), time.Hour, 0)
+	calls, body := 0, "v1"
+	app := newApp(cache, &calls, &body)
+
+	first, err := get(app, "/articles", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "public, no-cache", first.cacheControl)
+
+	body = "v2"
+	if err = cache.Invalidate(context.Background(), ScopePoliticians); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := get(app, "/articles", map[string]string{fiber.HeaderIfNoneMatch: first.etag})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, fiber.StatusOK, second.status)
+	assert.Equal(t, `"v2"`, second.body)
+	assert.NotEqual(t, first.etag, second.etag)
+}
+
+func TestMiddleware_SkipsErrors(t *testing.T) {
+	cache := New(NewMemoryStore(100), time.Hour, time.Minute)
+	calls, body := 0, "v1"
+	app := newApp(cache, &calls, &body)
+
+	for range 2 {
+		res, err := get(app, "/articles?fail=1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fiber.StatusBadRequest, res.status)
+		assert.Empty(t, res.etag)
+	}
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestMiddleware_BypassedWhenStoreFails(t *testing.T) {
+	cache := New(failingStore{}, time.Hour, time.Minute)
+	calls, body := 0, "v1"
+	app := newApp(cache, &calls, &body)
+
+	for range 2 {
+		res, err := get(app, "/articles", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, fiber.StatusOK, res.status)
+		assert.Equal(t, `"v1"`, res.body)
+	}
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestMemoryStore(t *testing.T) {
+	now := time.Date(2024, 6, 10, 8, 30, 0, 0, time.UTC)
+	store := newMemoryStore(10, func() time.Time { return now })
+	ctx := context.Background()
+
+	if err := store.Set(ctx, "key", []byte("value"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := store.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	now = now.Add(time.Minute)
+
+	value, err = store.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	for i := int64(1); i <= 2; i++ {
+		n, err := store.Incr(ctx, "counter")
+		assert.NoError(t, err)
+		assert.Equal(t, i, n)
+	}
+
+	now = now.Add(365 * 24 * time.Hour)
+
+	value, err = store.Get(ctx, "counter")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("2"), value)
+}
+
+func TestMemoryStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	store := newMemoryStore(2, time.Now)
+	ctx := context.Background()
+
+	if _, err := store.Incr(ctx, "generation"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"a", "b"} {
+		if err := store.Set(ctx, key, []byte(key), time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// reading a makes b the least recently used
+	value, err := store.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("a"), value)
+
+	if err = store.Set(ctx, "c", []byte("c"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err = store.Get(ctx, "b")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	for _, key := range []string{"a", "c"} {
+		value, err = store.Get(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(key), value)
+	}
+
+	// overwriting a key doesn't evict anything
+	if err = store.Set(ctx, "c", []byte("c2"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, store.items, 2)
+
+	// generations are never evicted
+	value, err = store.Get(ctx, "generation")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+}
+
+func TestNotModified(t *testing.T) {
+	lastModified := time.Date(2024, 6, 10, 8, 30, 0, 500, time.UTC)
+
+	assert.True(t, NotModified(`W/"a", "b"`, "", `"b"`, time.Time{}))
+	assert.True(t, NotModified(`"b"`, "", `W/"b"`, time.Time{}))
+	assert.True(t, NotModified("*", "", `"b"`, time.Time{}))
+	assert.False(t, NotModified(`"a"`, "Mon, 10 Jun 2024 08:30:00 GMT", `"b"`, lastModified))
+
+	assert.True(t, NotModified("", "Mon, 10 Jun 2024 08:30:00 GMT", `"b"`, lastModified))
+	assert.False(t, NotModified("", "Mon, 10 Jun 2024 08:29:59 GMT", `"b"`, lastModified))
+	assert.False(t, NotModified("", "Mon, 10 Jun 2024 08:30:00 GMT", `"b"`, time.Time{}))
+	assert.False(t, NotModified("", "not a date", `"b"`, lastModified))
+}
@@ -0,0 +1,114 @@
+package http_cache
+
+import (
+	"container/list"
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// purgeInterval is how often expired entries are removed from a memory store
+const purgeInterval = time.Minute
+
+type memoryItem struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// memoryStore keeps at most maxEntries responses, evicting the least recently used one first.
+// Generations are counted apart and never evicted, so that an evicted generation can't bring stale responses back.
+type memoryStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	items      map[string]*list.Element
+	// recency lists the items from the most to the least recently used
+	recency  *list.List
+	counters map[string]int64
+	purgedAt time.Time
+	now      func() time.Time
+}
+
+func NewMemoryStore(maxEntries int) Store {
+	return newMemoryStore(maxEntries, time.Now)
+}
+
+func newMemoryStore(maxEntries int, now func() time.Time) *memoryStore {
+	return &memoryStore{
+		maxEntries: maxEntries,
+		items:      make(map[string]*list.Element),
+		recency:    list.New(),
+		counters:   make(map[string]int64),
+		now:        now,
+	}
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n, ok := s.counters[key]; ok {
+		return []byte(strconv.FormatInt(n, 10)), nil
+	}
+
+	element, ok := s.items[key]
+	if !ok {
+		return nil, nil
+	}
+
+	item := element.Value.(*memoryItem)
+	if !s.now().Before(item.expiresAt) {
+		s.remove(element)
+		return nil, nil
+	}
+
+	s.recency.MoveToFront(element)
+
+	return item.value, nil
+}
+
+func (s *memoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	if now.Sub(s.purgedAt) >= purgeInterval {
+		for _, element := range s.items {
+			if !now.Before(element.Value.(*memoryItem).expiresAt) {
+				s.remove(element)
+			}
+		}
+		s.purgedAt = now
+	}
+
+	if element, ok := s.items[key]; ok {
+		item := element.Value.(*memoryItem)
+		item.value, item.expiresAt = value, now.Add(ttl)
+		s.recency.MoveToFront(element)
+		return nil
+	}
+
+	for len(s.items) >= s.maxEntries && s.recency.Len() > 0 {
+		s.remove(s.recency.Back())
+	}
+
+	s.items[key] = s.recency.PushFront(&memoryItem{key: key, value: value, expiresAt: now.Add(ttl)})
+
+	return nil
+}
+
+func (s *memoryStore) Incr(_ context.Context, key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counters[key]++
+
+	return s.counters[key], nil
+}
+
+func (s *memoryStore) remove(element *list.Element) {
+	s.recency.Remove(element)
+	delete(s.items, element.Value.(*memoryItem).key)
+}
@@ -0,0 +1,15 @@
+package http_cache
+
+import (
+	"context"
+	"time"
+)
+
+// Store keeps cached responses and scope generations. It is local to a replica in memory, or shared through Redis.
+type Store interface {
+	// Get returns nil when key is missing or expired
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Incr increments the integer at key, starting from 0, and never expires it
+	Incr(ctx context.Context, key string) (int64, error)
+}
@@ -12,8 +12,18 @@ type AuthedUser struct {
 	Email         string
 	EmailVerified bool
 	Roles         []models.RoleName
+
+	// SessionID is the family of the refresh token the access token was issued with
+	SessionID uuid.UUID
+
+	// MFAEnabled is loaded on every request, like Roles
+	MFAEnabled bool
 }
 
 func (a AuthedUser) HasRole(role models.RoleName) bool {
 	return slices.Contains(a.Roles, role)
 }
+
+func (a AuthedUser) RequiresMFA() bool {
+	return slices.ContainsFunc(a.Roles, models.RoleName.RequiresMFA)
+}
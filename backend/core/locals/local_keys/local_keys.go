@@ -5,6 +5,7 @@ const AuthedUser = "authedUser"
 const AccessToken = "accessToken"
 const RefreshToken = "refreshToken"
 const CsrfToken = "csrfToken"
+const MfaChallenge = "mfaChallenge"
 
 const ArticleReference = "articleReference"
 const ArticleID = "articleID"
@@ -12,3 +13,16 @@ const ArticleID = "articleID"
 const UserTag = "userTag"
 
 const RoleName = "roleName"
+
+const PoliticianID = "politicianID"
+
+const GovernmentReference = "governmentReference"
+
+const ReviewCommentID = "reviewCommentID"
+
+const SessionID = "sessionID"
+
+const PasskeyID = "passkeyID"
+
+const LockoutKind = "lockoutKind"
+const LockoutSubject = "lockoutSubject"
@@ -0,0 +1,235 @@
+// Package lockout slows down the accounts and IPs failing to sign in with an exponential backoff,
+// then locks them out for a while, whichever replica they reach.
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"vdm/core/env"
+	"vdm/core/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Kind string
+
+const (
+	KindAccount Kind = "account"
+	KindIP      Kind = "ip"
+)
+
+const keyPrefix = "lockout:"
+
+// Subject is what failed attempts are counted for, an account by its email or an IP
+type Subject struct {
+	Kind  Kind
+	Value string
+}
+
+func Account(email string) Subject {
+	// the same account must not be tried again under another case
+	return Subject{Kind: KindAccount, Value: strings.ToLower(strings.TrimSpace(email))}
+}
+
+func IP(ip string) Subject {
+	return Subject{Kind: KindIP, Value: ip}
+}
+
+// ParseSubject returns false when kind is unknown or value is empty
+func ParseSubject(kind, value string) (Subject, bool) {
+	switch Kind(kind) {
+	case KindAccount:
+		return Account(value), strings.TrimSpace(value) != ""
+	case KindIP:
+		return IP(value), value != ""
+	default:
+		return Subject{}, false
+	}
+}
+
+func (s Subject) key() string {
+	return keyPrefix + string(s.Kind) + ":" + s.Value
+}
+
+func parseKey(key string) Subject {
+	kind, value, _ := strings.Cut(strings.TrimPrefix(key, keyPrefix), ":")
+	return Subject{Kind: Kind(kind), Value: value}
+}
+
+// Error refuses an attempt, until RetryAfter has passed
+type Error struct {
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("too many failed attempts, retry after %s", e.RetryAfter)
+}
+
+// Unwrap lets the error handler answer 429
+func (e *Error) Unwrap() error {
+	return &fiber.Error{Code: fiber.StatusTooManyRequests, Message: e.Error()}
+}
+
+// Entry is a subject refusing attempts
+type Entry struct {
+	Subject
+	Failures      int64
+	LastFailureAt time.Time
+	RetryAt       time.Time
+	// Locked is false while the subject is only backing off
+	Locked bool
+}
+
+type Guard struct {
+	store Store
+	cfg   env.LockoutConfig
+	now   func() time.Time
+}
+
+func New(store Store, cfg env.LockoutConfig) *Guard {
+	return &Guard{store: store, cfg: cfg, now: time.Now}
+}
+
+func NewStore(cfg env.LockoutConfig) (Store, error) {
+	switch cfg.Store {
+	case env.LockoutStoreRedis:
+		return NewRedisStore(cfg.RedisURL)
+	default:
+		return NewMemoryStore(), nil
+	}
+}
+
+// Duration is how long a subject stays locked out
+func (g *Guard) Duration() time.Duration {
+	return g.cfg.Duration
+}
+
+func (g *Guard) maxFailures(kind Kind) int64 {
+	if kind == KindIP {
+		return g.cfg.IPMaxFailures
+	}
+	return g.cfg.AccountMaxFailures
+}
+
+// delay is how long after its last failure a subject waits before its next attempt
+func (g *Guard) delay(kind Kind, failures int64) time.Duration {
+	if failures >= g.maxFailures(kind) {
+		return g.cfg.Duration
+	}
+	if failures < g.cfg.BackoffAfter {
+		return 0
+	}
+
+	d := g.cfg.BackoffBase
+	for i := g.cfg.BackoffAfter; i < failures && d < g.cfg.BackoffMax; i++ {
+		d *= 2
+	}
+
+	return min(d, g.cfg.BackoffMax)
+}
+
+func (g *Guard) entry(subject Subject, attempts Attempts) Entry {
+	return Entry{
+		Subject:       subject,
+		Failures:      attempts.Failures,
+		LastFailureAt: attempts.LastFailureAt,
+		RetryAt:       attempts.LastFailureAt.Add(g.delay(subject.Kind, attempts.Failures)),
+		Locked:        attempts.Failures >= g.maxFailures(subject.Kind),
+	}
+}
+
+// Check refuses an attempt with an *Error while one of subjects is backing off or locked out.
+// The attempt is let through when the store fails, so that it never makes signing in unavailable.
+func (g *Guard) Check(ctx context.Context, subjects ...Subject) error {
+	now := g.now()
+
+	var retryAfter time.Duration
+	for _, subject := range subjects {
+		attempts, err := g.store.Get(ctx, subject.key())
+		if err != nil {
+			logger.Error("failed to get failed attempts", logger.Err(err))
+			continue
+		}
+
+		if wait := g.entry(subject, attempts).RetryAt.Sub(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	if retryAfter > 0 {
+		return &Error{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// Fail counts a failed attempt for every subject, and returns those it locked out.
+// Attempts being refused during a lockout, every failure past the maximum starts a new one.
+func (g *Guard) Fail(ctx context.Context, subjects ...Subject) []Subject {
+	now := g.now()
+
+	var locked []Subject
+	for _, subject := range subjects {
+		attempts, err := g.store.Fail(ctx, subject.key(), now, g.cfg.FailuresTTL)
+		if err != nil {
+			logger.Error("failed to count failed attempt", logger.Err(err))
+			continue
+		}
+
+		if attempts.Failures >= g.maxFailures(subject.Kind) {
+			logger.Warn("subject locked out",
+				logger.Any("event", "lockout"),
+				logger.Any("kind", subject.Kind),
+				logger.Any("failures", attempts.Failures))
+			locked = append(locked, subject)
+		}
+	}
+
+	return locked
+}
+
+// Reset forgets the failed attempts of subjects, after a successful one
+func (g *Guard) Reset(ctx context.Context, subjects ...Subject) {
+	for _, subject := range subjects {
+		if _, err := g.store.Delete(ctx, subject.key()); err != nil {
+			logger.Error("failed to reset failed attempts", logger.Err(err))
+		}
+	}
+}
+
+// Lockouts lists the subjects refusing attempts, the locked out ones first
+func (g *Guard) Lockouts(ctx context.Context) ([]Entry, error) {
+	attempts, err := g.store.List(ctx, keyPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed attempts: %v", err)
+	}
+
+	now := g.now()
+
+	entries := make([]Entry, 0, len(attempts))
+	for key, a := range attempts {
+		if entry := g.entry(parseKey(key), a); entry.RetryAt.After(now) {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Locked != entries[j].Locked {
+			return entries[i].Locked
+		}
+		return entries[i].RetryAt.After(entries[j].RetryAt)
+	})
+
+	return entries, nil
+}
+
+// Clear forgets the failed attempts of subject, and tells whether there were any
+func (g *Guard) Clear(ctx context.Context, subject Subject) (bool, error) {
+	found, err := g.store.Delete(ctx, subject.key())
+	if err != nil {
+		return false, fmt.Errorf("failed to clear failed attempts: %v", err)
+	}
+	return found, nil
+}
@@ -0,0 +1,190 @@
+package lockout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"vdm/core/env"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingStore struct{}
+
+func (failingStore) Get(context.Context, string) (Attempts, error) {
+	return Attempts{}, errors.New("connection refused")
+}
+
+func (failingStore) Fail(context.Context, string, time.Time, time.Duration) (Attempts, error) {
+	return Attempts{}, errors.New("connection refused")
+}
+
+func (failingStore) Delete(context.Context, string) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func (failingStore) List(context.Context, string) (map[string]Attempts, error) {
+	return nil, errors.New("connection refused")
+}
+
+var testConfig = env.LockoutConfig{
+	BackoffAfter:       3,
+	BackoffBase:        time.Second,
+	BackoffMax:         10 * time.Second,
+	AccountMaxFailures: 8,
+	IPMaxFailures:      20,
+	Duration:           30 * time.Minute,
+	FailuresTTL:        24 * time.Hour,
+}
+
+// newTestGuard shares its clock with its memory store
+func newTestGuard(now *time.Time) *Guard {
+	clock := func() time.Time { return *now }
+	store := &memoryStore{items: make(map[string]memoryItem), now: clock}
+	return &Guard{store: store, cfg: testConfig, now: clock}
+}
+
+func TestGuard_Delay(t *testing.T) {
+	g := New(NewMemoryStore(), testConfig)
+
+	for failures, expected := range map[int64]time.Duration{
+		0: 0,
+		2: 0,
+		3: time.Second,
+		4: 2 * time.Second,
+		5: 4 * time.Second,
+		6: 8 * time.Second,
+		7: 10 * time.Second,
+		8: 30 * time.Minute,
+	} {
+		assert.Equal(t, expected, g.delay(KindAccount, failures), failures)
+	}
+
+	assert.Equal(t, 10*time.Second, g.delay(KindIP, 8))
+	assert.Equal(t, 30*time.Minute, g.delay(KindIP, 20))
+}
+
+func TestGuard_BacksOffThenLocksOut(t *testing.T) {
+	now := time.Date(2024, 6, 10, 8, 30, 0, 0, time.UTC)
+	g := newTestGuard(&now)
+	ctx := context.Background()
+
+	account, ip := Account(" User@Email.com"), IP("192.0.2.1")
+
+	for range 2 {
+		assert.NoError(t, g.Check(ctx, account, ip))
+		assert.Empty(t, g.Fail(ctx, account, ip))
+	}
+	assert.NoError(t, g.Check(ctx, account, ip))
+	assert.Empty(t, g.Fail(ctx, account, ip))
+
+	// the third failure starts the backoff, under any case of the email
+	var lockErr *Error
+	if assert.True(t, errors.As(g.Check(ctx, Account("user@email.com")), &lockErr)) {
+		assert.Equal(t, time.Second, lockErr.RetryAfter)
+	}
+
+	var fiberErr *fiber.Error
+	if assert.True(t, errors.As(g.Check(ctx, account, ip), &fiberErr)) {
+		assert.Equal(t, fiber.StatusTooManyRequests, fiberErr.Code)
+	}
+
+	for range 4 {
+		now = now.Add(time.Minute)
+		assert.NoError(t, g.Check(ctx, account, ip))
+		assert.Empty(t, g.Fail(ctx, account, ip))
+	}
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, []Subject{account}, g.Fail(ctx, account, ip))
+
+	if assert.True(t, errors.As(g.Check(ctx, account), &lockErr)) {
+		assert.Equal(t, 30*time.Minute, lockErr.RetryAfter)
+	}
+	// the IP is still only backing off
+	if assert.True(t, errors.As(g.Check(ctx, ip), &lockErr)) {
+		assert.Equal(t, 10*time.Second, lockErr.RetryAfter)
+	}
+
+	now = now.Add(30 * time.Minute)
+	assert.NoError(t, g.Check(ctx, account, ip))
+
+	g.Reset(ctx, account)
+	assert.NoError(t, g.Check(ctx, account))
+	assert.Empty(t, g.Fail(ctx, account))
+}
+
+func TestGuard_LockoutsAndClear(t *testing.T) {
+	now := time.Date(2024, 6, 10, 8, 30, 0, 0, time.UTC)
+	g := newTestGuard(&now)
+	ctx := context.Background()
+
+	locked, backingOff, forgotten := Account("locked@email.com"), IP("192.0.2.1"), Account("forgotten@email.com")
+
+	for range testConfig.AccountMaxFailures {
+		g.Fail(ctx, locked)
+	}
+	for range testConfig.BackoffAfter {
+		g.Fail(ctx, backingOff)
+	}
+	g.Fail(ctx, forgotten)
+
+	entries, err := g.Lockouts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, locked, entries[0].Subject)
+		assert.True(t, entries[0].Locked)
+		assert.Equal(t, now.Add(testConfig.Duration), entries[0].RetryAt)
+		assert.Equal(t, backingOff, entries[1].Subject)
+		assert.False(t, entries[1].Locked)
+	}
+
+	found, err := g.Clear(ctx, locked)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.NoError(t, g.Check(ctx, locked))
+
+	found, err = g.Clear(ctx, locked)
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	// failures are forgotten FailuresTTL after the last one
+	now = now.Add(testConfig.FailuresTTL)
+	entries, err = g.Lockouts(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestGuard_LetsThroughWhenStoreFails(t *testing.T) {
+	g := New(failingStore{}, testConfig)
+	ctx := context.Background()
+
+	assert.NoError(t, g.Check(ctx, Account("user@email.com")))
+	assert.Empty(t, g.Fail(ctx, Account("user@email.com")))
+
+	_, err := g.Lockouts(ctx)
+	assert.Error(t, err)
+}
+
+func TestParseSubject(t *testing.T) {
+	subject, ok := ParseSubject("account", "User@Email.com")
+	assert.True(t, ok)
+	assert.Equal(t, Subject{Kind: KindAccount, Value: "user@email.com"}, subject)
+
+	subject, ok = ParseSubject("ip", "2001:db8::1")
+	assert.True(t, ok)
+	assert.Equal(t, subject, parseKey(subject.key()))
+
+	for _, kind := range []string{"user", ""} {
+		_, ok = ParseSubject(kind, "192.0.2.1")
+		assert.False(t, ok, kind)
+	}
+
+	_, ok = ParseSubject("account", " ")
+	assert.False(t, ok)
+}
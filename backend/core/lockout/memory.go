@@ -0,0 +1,96 @@
+package lockout
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// purgeInterval is how often expired entries are removed from a memory store
+const purgeInterval = time.Minute
+
+type memoryItem struct {
+	attempts  Attempts
+	expiresAt time.Time
+}
+
+type memoryStore struct {
+	mu       sync.Mutex
+	items    map[string]memoryItem
+	purgedAt time.Time
+	now      func() time.Time
+}
+
+func NewMemoryStore() Store {
+	return &memoryStore{items: make(map[string]memoryItem), now: time.Now}
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) (Attempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.items[key]
+	if !ok {
+		return Attempts{}, nil
+	}
+
+	if !s.now().Before(item.expiresAt) {
+		delete(s.items, key)
+		return Attempts{}, nil
+	}
+
+	return item.attempts, nil
+}
+
+func (s *memoryStore) Fail(_ context.Context, key string, at time.Time, ttl time.Duration) (Attempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	if now.Sub(s.purgedAt) >= purgeInterval {
+		for k, item := range s.items {
+			if !now.Before(item.expiresAt) {
+				delete(s.items, k)
+			}
+		}
+		s.purgedAt = now
+	}
+
+	var attempts Attempts
+	if item, ok := s.items[key]; ok && now.Before(item.expiresAt) {
+		attempts = item.attempts
+	}
+
+	attempts.Failures++
+	attempts.LastFailureAt = at
+	s.items[key] = memoryItem{attempts: attempts, expiresAt: now.Add(ttl)}
+
+	return attempts, nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.items[key]
+	delete(s.items, key)
+
+	return ok && s.now().Before(item.expiresAt), nil
+}
+
+func (s *memoryStore) List(_ context.Context, prefix string) (map[string]Attempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	attempts := make(map[string]Attempts)
+	for k, item := range s.items {
+		if strings.HasPrefix(k, prefix) && now.Before(item.expiresAt) {
+			attempts[k] = item.attempts
+		}
+	}
+
+	return attempts, nil
+}
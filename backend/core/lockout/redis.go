@@ -0,0 +1,94 @@
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	failuresField      = "failures"
+	lastFailureAtField = "last_failure_at"
+)
+
+type redisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore connects to any server speaking the Redis protocol, url being redis://[user:password@]host:port/db
+func NewRedisStore(url string) (Store, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis url: %v", err)
+	}
+
+	return &redisStore{client: redis.NewClient(opts)}, nil
+}
+
+func (s *redisStore) Get(ctx context.Context, key string) (Attempts, error) {
+	fields, err := s.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return Attempts{}, err
+	}
+	return parseAttempts(fields)
+}
+
+// Fail increments the failures in a transaction, so that the replicas counting failures under the same key never lose one
+func (s *redisStore) Fail(ctx context.Context, key string, at time.Time, ttl time.Duration) (Attempts, error) {
+	var failures *redis.IntCmd
+
+	if _, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		failures = pipe.HIncrBy(ctx, key, failuresField, 1)
+		pipe.HSet(ctx, key, lastFailureAtField, at.UnixNano())
+		pipe.PExpire(ctx, key, ttl)
+		return nil
+	}); err != nil {
+		return Attempts{}, err
+	}
+
+	return Attempts{Failures: failures.Val(), LastFailureAt: at}, nil
+}
+
+func (s *redisStore) Delete(ctx context.Context, key string) (bool, error) {
+	n, err := s.client.Del(ctx, key).Result()
+	return n > 0, err
+}
+
+func (s *redisStore) List(ctx context.Context, prefix string) (map[string]Attempts, error) {
+	attempts := make(map[string]Attempts)
+
+	iter := s.client.Scan(ctx, 0, prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		a, err := s.Get(ctx, iter.Val())
+		if err != nil {
+			return nil, err
+		}
+		// the key may have expired since it was scanned
+		if a.Failures > 0 {
+			attempts[iter.Val()] = a
+		}
+	}
+
+	return attempts, iter.Err()
+}
+
+func parseAttempts(fields map[string]string) (Attempts, error) {
+	if len(fields) == 0 {
+		return Attempts{}, nil
+	}
+
+	failures, err := strconv.ParseInt(fields[failuresField], 10, 64)
+	if err != nil {
+		return Attempts{}, fmt.Errorf("failed to parse %s: %v", failuresField, err)
+	}
+
+	lastFailureAt, err := strconv.ParseInt(fields[lastFailureAtField], 10, 64)
+	if err != nil {
+		return Attempts{}, fmt.Errorf("failed to parse %s: %v", lastFailureAtField, err)
+	}
+
+	return Attempts{Failures: failures, LastFailureAt: time.Unix(0, lastFailureAt)}, nil
+}
@@ -0,0 +1,24 @@
+package lockout
+
+import (
+	"context"
+	"time"
+)
+
+// Attempts are the failed attempts counted under a key
+type Attempts struct {
+	Failures      int64
+	LastFailureAt time.Time
+}
+
+// Store counts failed attempts. It is local to a replica in memory, or shared through Redis.
+type Store interface {
+	// Get returns zero Attempts when key is missing or expired
+	Get(ctx context.Context, key string) (Attempts, error)
+	// Fail counts a failure made at under key, and forgets every failure under key ttl after it
+	Fail(ctx context.Context, key string, at time.Time, ttl time.Duration) (Attempts, error)
+	// Delete tells whether key was found
+	Delete(ctx context.Context, key string) (bool, error)
+	// List returns the attempts under every key starting with prefix
+	List(ctx context.Context, prefix string) (map[string]Attempts, error)
+}
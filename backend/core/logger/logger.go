@@ -21,6 +21,7 @@ type Field struct {
 type Logger interface {
 	Debug(string, ...Field)
 	Info(string, ...Field)
+	Warn(string, ...Field)
 	Error(string, ...Field)
 }
 
@@ -46,6 +47,10 @@ func Info(s string, fields ...Field) {
 	globalLogger.Info(s, fields...)
 }
 
+func Warn(s string, fields ...Field) {
+	globalLogger.Warn(s, fields...)
+}
+
 func Error(s string, fields ...Field) {
 	globalLogger.Error(s, fields...)
 }
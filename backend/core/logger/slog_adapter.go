@@ -14,6 +14,10 @@ func (a slogAdapter) Info(s string, fields ...Field) {
 	a.inner.Info(s, fieldsToAttr(fields)...)
 }
 
+func (a slogAdapter) Warn(s string, fields ...Field) {
+	a.inner.Warn(s, fieldsToAttr(fields)...)
+}
+
 func (a slogAdapter) Error(s string, fields ...Field) {
 	a.inner.Error(s, fieldsToAttr(fields)...)
 }
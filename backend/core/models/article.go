@@ -29,6 +29,17 @@ func (ac ArticleCategory) Valid() bool {
 	return false
 }
 
+// Label is the public, french name of the category
+func (ac ArticleCategory) Label() string {
+	switch ac {
+	case ArticleCategoryLie:
+		return "Mensonge"
+	case ArticleCategoryFalsehood:
+		return "Contre-vérité"
+	}
+	return ""
+}
+
 const (
 	ArticleStatusDraft           ArticleStatus = "DRAFT"
 	ArticleStatusPublished       ArticleStatus = "PUBLISHED"
@@ -49,13 +60,14 @@ type Article struct {
 	Politicians        []*Politician        `gorm:"many2many:article_politicians;"`
 	Tags               []*ArticleTag        `gorm:"foreignKey:ArticleID"`
 	Sources            []*ArticleSource     `gorm:"foreignKey:ArticleID"`
-	Review             *ArticleReview       `gorm:"foreignKey:ArticleID"`
+	Reviews            []*ArticleReview     `gorm:"foreignKey:ArticleID"`
 
 	RedactorID uuid.UUID `gorm:"column:redactor_id;type:uuid;not null"`
 	Redactor   *User
 
 	ModeratorID *uuid.UUID `gorm:"column:moderator_id;type:uuid"`
 	Moderator   *User
+	ClaimedAt   *time.Time `gorm:"column:claimed_at"`
 
 	Status    ArticleStatus   `gorm:"column:status;type:text;not null"`
 	Category  ArticleCategory `gorm:"column:category;type:text;not null"`
@@ -66,6 +78,11 @@ type Article struct {
 	Major     int16           `gorm:"column:major;not null"`
 	Minor     int16           `gorm:"column:minor;not null"`
 
+	// CorrectionNote is shown publicly when a new major version supersedes a published one.
+	// PublishedAt is set when a moderator publishes the version.
+	CorrectionNote string     `gorm:"column:correction_note;not null;default:''"`
+	PublishedAt    *time.Time `gorm:"column:published_at"`
+
 	CreatedAt time.Time      `gorm:"column:created_at;not null;default:now()"`
 	UpdatedAt time.Time      `gorm:"column:updated_at;not null;default:now()"`
 	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
@@ -7,6 +7,11 @@ import "github.com/google/uuid"
 type ArticlePolitician struct {
 	ArticleID    uuid.UUID `gorm:"column:article_id;type:uuid;primaryKey"`
 	PoliticianID uuid.UUID `gorm:"column:politician_id;type:uuid;primaryKey"`
+
+	// OccupationID and Office capture the politician's role at the article's event date
+	OccupationID *uuid.UUID `gorm:"column:occupation_id;type:uuid"`
+	Occupation   *Occupation
+	Office       string `gorm:"column:office;not null;default:''"`
 }
 
 func (ArticlePolitician) TableName() string { return "article_politicians" }
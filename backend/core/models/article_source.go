@@ -1,12 +1,41 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ArticleSourceType string
+
+func (ast ArticleSourceType) Valid() bool {
+	switch ast {
+	case ArticleSourceTypeVideo, ArticleSourceTypeOfficialTranscript, ArticleSourceTypePressArticle, ArticleSourceTypeSocialPost:
+		return true
+	}
+	return false
+}
+
+const (
+	ArticleSourceTypeVideo              ArticleSourceType = "VIDEO"
+	ArticleSourceTypeOfficialTranscript ArticleSourceType = "OFFICIAL_TRANSCRIPT"
+	ArticleSourceTypePressArticle       ArticleSourceType = "PRESS_ARTICLE"
+	ArticleSourceTypeSocialPost         ArticleSourceType = "SOCIAL_POST"
+)
 
 // ArticleSource represents the article_sources table (composite primary key)
 
 type ArticleSource struct {
 	ArticleID uuid.UUID `gorm:"column:article_id;type:uuid;primaryKey"`
 	URL       string    `gorm:"column:url;primaryKey"`
+
+	Type        ArticleSourceType `gorm:"column:type;type:text;not null"`
+	Publisher   string            `gorm:"column:publisher;not null;default:''"`
+	PublishedAt *time.Time        `gorm:"column:published_at"`
+
+	// TimestampSeconds locates the quote in a video
+	TimestampSeconds *int   `gorm:"column:timestamp_seconds"`
+	Quote            string `gorm:"column:quote;not null;default:''"`
 }
 
 func (ArticleSource) TableName() string { return "article_sources" }
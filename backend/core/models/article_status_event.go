@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArticleStatusEvent represents the article_status_events table
+
+type ArticleStatusEvent struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+
+	// ArticleID is cleared when the version is deleted (a draft replaced by its first submission),
+	// the event stays in the history of the reference.
+	ArticleID *uuid.UUID `gorm:"column:article_id;type:uuid"`
+	Reference uuid.UUID  `gorm:"column:reference;type:uuid;not null"`
+
+	ActorID uuid.UUID `gorm:"column:actor_id;type:uuid;not null"`
+	Actor   *User     `gorm:"foreignKey:ActorID"`
+
+	// FromStatus is nil when the version is created
+	FromStatus *ArticleStatus `gorm:"column:from_status;type:text"`
+	ToStatus   ArticleStatus  `gorm:"column:to_status;type:text;not null"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;default:now()"`
+}
+
+func (ArticleStatusEvent) TableName() string { return "article_status_events" }
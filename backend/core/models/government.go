@@ -17,6 +17,9 @@ type Government struct {
 	StartDate       time.Time    `gorm:"column:start_date;not null"`
 	EndDate         sql.NullTime `gorm:"column:end_date"`
 
+	PrimeMinister *Politician
+	Occupations   []*Occupation `gorm:"foreignKey:GovernmentID"`
+
 	CreatedAt time.Time      `gorm:"column:created_at;not null;default:now()"`
 	UpdatedAt time.Time      `gorm:"column:updated_at;not null;default:now()"`
 	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
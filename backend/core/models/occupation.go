@@ -20,6 +20,9 @@ type Occupation struct {
 	StartDate             time.Time      `gorm:"column:start_date;not null"`
 	EndDate               sql.NullTime   `gorm:"column:end_date"`
 
+	Politician *Politician
+	Government *Government
+
 	CreatedAt time.Time      `gorm:"column:created_at;not null;default:now()"`
 	UpdatedAt time.Time      `gorm:"column:updated_at;not null;default:now()"`
 	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
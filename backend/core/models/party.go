@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Party represents the parties table
+
+type Party struct {
+	ID   uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Code string    `gorm:"column:code;not null;unique"`
+	Name string    `gorm:"column:name;not null"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;not null;default:now()"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
+}
+
+func (Party) TableName() string { return "parties" }
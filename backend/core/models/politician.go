@@ -14,6 +14,9 @@ type Politician struct {
 	FirstName string         `gorm:"column:first_name;not null"`
 	ImageUrl  sql.NullString `gorm:"column:image_url"`
 
+	Occupations  []*Occupation            `gorm:"foreignKey:PoliticianID"`
+	Affiliations []*PoliticianAffiliation `gorm:"foreignKey:PoliticianID"`
+
 	CreatedAt time.Time      `gorm:"column:created_at;not null;default:now()"`
 	UpdatedAt time.Time      `gorm:"column:updated_at;not null;default:now()"`
 	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
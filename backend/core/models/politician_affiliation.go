@@ -0,0 +1,29 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PoliticianAffiliation represents the politician_affiliations table.
+// EndDate is NULL while the politician is still a member of the party.
+
+type PoliticianAffiliation struct {
+	ID           uuid.UUID    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	PoliticianID uuid.UUID    `gorm:"column:politician_id;type:uuid;not null"`
+	PartyID      uuid.UUID    `gorm:"column:party_id;type:uuid;not null"`
+	StartDate    time.Time    `gorm:"column:start_date;not null"`
+	EndDate      sql.NullTime `gorm:"column:end_date"`
+
+	Politician *Politician
+	Party      *Party
+
+	CreatedAt time.Time      `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;not null;default:now()"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
+}
+
+func (PoliticianAffiliation) TableName() string { return "politician_affiliations" }
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewComment represents the review_comments table
+
+type ReviewComment struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+
+	// ArticleID is the version the thread belongs to: threads follow the article when it is resubmitted
+	ArticleID uuid.UUID `gorm:"column:article_id;type:uuid;not null"`
+
+	// ParentID is set on replies, which always point to the root comment of their thread
+	ParentID *uuid.UUID       `gorm:"column:parent_id;type:uuid"`
+	Replies  []*ReviewComment `gorm:"foreignKey:ParentID"`
+
+	AuthorID uuid.UUID `gorm:"column:author_id;type:uuid;not null"`
+	Author   *User     `gorm:"foreignKey:AuthorID"`
+
+	// AnchorStart and AnchorEnd delimit the commented characters of Article.Body.
+	// They are cleared when the quoted text can't be found anymore in a new version.
+	AnchorStart *int   `gorm:"column:anchor_start"`
+	AnchorEnd   *int   `gorm:"column:anchor_end"`
+	Quote       string `gorm:"column:quote;not null;default:''"`
+
+	SourceURL *string `gorm:"column:source_url"`
+
+	Text       string     `gorm:"column:text;not null"`
+	ResolvedAt *time.Time `gorm:"column:resolved_at"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;not null;default:now()"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
+}
+
+func (ReviewComment) TableName() string { return "review_comments" }
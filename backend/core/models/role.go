@@ -23,6 +23,11 @@ func (r RoleName) IsValid() bool {
 	return false
 }
 
+// RequiresMFA tells whether users holding the role must sign in with a second factor
+func (r RoleName) RequiresMFA() bool {
+	return r == RoleAdmin || r == RoleModerator
+}
+
 type Role struct {
 	ID        uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
 	Name      RoleName       `gorm:"column:name;unique;not null"`
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SourceSnapshot represents the source_snapshots table
+// A snapshot is shared by every article citing the same URL.
+
+type SourceSnapshot struct {
+	ID  uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	URL string    `gorm:"column:url;not null;uniqueIndex:uq_source_snapshots_url"`
+
+	// the capture is taken by the first successful fetch and kept afterward, so the cited content survives the link
+	StatusCode  int        `gorm:"column:status_code"`
+	FinalURL    string     `gorm:"column:final_url;not null;default:''"`
+	Title       string     `gorm:"column:title;not null;default:''"`
+	ContentHash string     `gorm:"column:content_hash;not null;default:''"`
+	HTML        string     `gorm:"column:html;not null;default:''"`
+	Text        string     `gorm:"column:text;not null;default:''"`
+	CapturedAt  *time.Time `gorm:"column:captured_at"`
+
+	// until captured, a source is fetched again at RetryAt, later after each failure
+	CaptureFailures int        `gorm:"column:capture_failures;not null;default:0"`
+	RetryAt         *time.Time `gorm:"column:retry_at"`
+
+	// the link is checked again periodically, Broken flags a source that errored or answered with a 4xx/5xx status
+	CheckStatusCode int        `gorm:"column:check_status_code"`
+	CheckError      string     `gorm:"column:check_error;not null;default:''"`
+	Broken          bool       `gorm:"column:broken;not null;default:false"`
+	CheckedAt       *time.Time `gorm:"column:checked_at"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (SourceSnapshot) TableName() string { return "source_snapshots" }
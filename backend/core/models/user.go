@@ -44,3 +44,12 @@ func (u User) HasRole(roleName RoleName) bool {
 	}
 	return false
 }
+
+func (u User) RequiresMFA() bool {
+	for _, r := range u.RoleNames() {
+		if r.RequiresMFA() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserPasskey is a WebAuthn credential registered by a user, see core/passkey
+type UserPasskey struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+
+	UserID uuid.UUID `gorm:"column:user_id;type:uuid;not null"`
+	User   *User     `gorm:"foreignKey:UserID"`
+
+	// Name is chosen by the user to tell their passkeys apart
+	Name string `gorm:"column:name;not null;default:''"`
+
+	CredentialID    []byte `gorm:"column:credential_id;not null;unique"`
+	PublicKey       []byte `gorm:"column:public_key;not null"`
+	AttestationType string `gorm:"column:attestation_type;not null;default:''"`
+	// Transports are comma separated hints for the client, such as internal,hybrid
+	Transports string `gorm:"column:transports;not null;default:''"`
+	AAGUID     []byte `gorm:"column:aaguid"`
+
+	// SignCount and Flags are updated on each sign-in, a sign count going backwards reveals a cloned authenticator
+	SignCount int64 `gorm:"column:sign_count;not null;default:0"`
+	Flags     int16 `gorm:"column:flags;not null;default:0"`
+
+	CreatedAt  time.Time  `gorm:"column:created_at;not null;default:now()"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at"`
+}
+
+func (UserPasskey) TableName() string { return "user_passkeys" }
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserRecoveryCode stands in for a TOTP code once, when the user lost their authenticator
+type UserRecoveryCode struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+
+	UserID uuid.UUID `gorm:"column:user_id;type:uuid;not null"`
+	User   *User     `gorm:"foreignKey:UserID"`
+
+	Hash   string     `gorm:"column:hash;not null;unique"`
+	UsedAt *time.Time `gorm:"column:used_at"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;default:now()"`
+}
+
+func (UserRecoveryCode) TableName() string { return "user_recovery_codes" }
@@ -22,6 +22,19 @@ type UserToken struct {
 	Category UserTokenCategory `gorm:"column:category;not null"`
 	Hash     string            `gorm:"column:hash;not null"`
 	Expiry   time.Time         `gorm:"column:expiry;not null"`
+
+	// FamilyID chains the refresh tokens rotated from the same sign-in.
+	// ConsumedAt is set when a refresh token is exchanged for the next one of its family; presenting it again revokes the family.
+	FamilyID   *uuid.UUID `gorm:"column:family_id;type:uuid"`
+	ConsumedAt *time.Time `gorm:"column:consumed_at"`
+
+	// A family of refresh tokens is a session of the user. The device label and the creation time are carried over by rotations,
+	// the user agent, the IP and the last use are those of the latest rotation.
+	DeviceLabel string    `gorm:"column:device_label;not null;default:''"`
+	UserAgent   string    `gorm:"column:user_agent;not null;default:''"`
+	IP          string    `gorm:"column:ip;not null;default:''"`
+	CreatedAt   time.Time `gorm:"column:created_at;not null;default:now()"`
+	LastUsedAt  time.Time `gorm:"column:last_used_at;not null;default:now()"`
 }
 
 func (UserToken) TableName() string { return "user_tokens" }
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserTOTP is the TOTP second factor of a user, pending until the user confirms it with a first code
+type UserTOTP struct {
+	UserID uuid.UUID `gorm:"column:user_id;type:uuid;primaryKey"`
+	User   *User     `gorm:"foreignKey:UserID"`
+
+	// Secret is encrypted with aes_utils
+	Secret      string     `gorm:"column:secret;not null"`
+	ConfirmedAt *time.Time `gorm:"column:confirmed_at"`
+
+	// LastUsedStep is the time step of the latest accepted code, codes of this step or older are refused so they can't be replayed
+	LastUsedStep int64 `gorm:"column:last_used_step;not null;default:0"`
+
+	// consecutive failed sign-in attempts
+	FailedAttempts int        `gorm:"column:failed_attempts;not null;default:0"`
+	LastFailedAt   *time.Time `gorm:"column:last_failed_at"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;default:now()"`
+}
+
+func (UserTOTP) TableName() string { return "user_totps" }
+
+func (t UserTOTP) Confirmed() bool {
+	return t.ConfirmedAt != nil
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebAuthnCeremony string
+
+const (
+	WebAuthnCeremonyRegistration WebAuthnCeremony = "REGISTRATION"
+	WebAuthnCeremonyLogin        WebAuthnCeremony = "LOGIN"
+)
+
+// WebAuthnChallenge is the server side state of a passkey ceremony, consumed by its second step
+type WebAuthnChallenge struct {
+	Challenge string `gorm:"column:challenge;primaryKey"`
+
+	Ceremony WebAuthnCeremony `gorm:"column:ceremony;not null"`
+
+	// UserID is nil for the sign-in ceremony, the user is only known from the passkey they pick
+	UserID *uuid.UUID `gorm:"column:user_id;type:uuid"`
+	User   *User      `gorm:"foreignKey:UserID"`
+
+	// Session is the JSON encoded webauthn.SessionData of the ceremony
+	Session string    `gorm:"column:session;not null"`
+	Expiry  time.Time `gorm:"column:expiry;not null"`
+}
+
+func (WebAuthnChallenge) TableName() string { return "webauthn_challenges" }
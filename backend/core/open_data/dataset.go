@@ -0,0 +1,108 @@
+package open_data
+
+import (
+	"time"
+	"vdm/core/models"
+)
+
+// Article is a row of the dataset: a published version of an article, with its relations nested.
+// Column names are shared by every format.
+type Article struct {
+	ID             string       `json:"id" parquet:"id"`
+	Reference      string       `json:"reference" parquet:"reference"`
+	Major          int32        `json:"major" parquet:"major"`
+	Minor          int32        `json:"minor" parquet:"minor"`
+	Title          string       `json:"title" parquet:"title"`
+	Body           string       `json:"body" parquet:"body"`
+	Category       string       `json:"category" parquet:"category"`
+	EventDate      time.Time    `json:"event_date" parquet:"event_date,timestamp(millisecond)"`
+	PublishedAt    *time.Time   `json:"published_at" parquet:"published_at,optional,timestamp(millisecond)"`
+	UpdatedAt      time.Time    `json:"updated_at" parquet:"updated_at,timestamp(millisecond)"`
+	CorrectionNote string       `json:"correction_note" parquet:"correction_note"`
+	Politicians    []Politician `json:"politicians" parquet:"politicians,list"`
+	Tags           []string     `json:"tags" parquet:"tags,list"`
+	Sources        []Source     `json:"sources" parquet:"sources,list"`
+}
+
+// Politician is quoted by an article. The occupation is the one held at the event date, if any.
+type Politician struct {
+	ID              string `json:"id" parquet:"id"`
+	FirstName       string `json:"first_name" parquet:"first_name"`
+	LastName        string `json:"last_name" parquet:"last_name"`
+	OccupationCode  string `json:"occupation_code" parquet:"occupation_code"`
+	OccupationTitle string `json:"occupation_title" parquet:"occupation_title"`
+}
+
+type Source struct {
+	URL              string     `json:"url" parquet:"url"`
+	Type             string     `json:"type" parquet:"type"`
+	Publisher        string     `json:"publisher" parquet:"publisher"`
+	PublishedAt      *time.Time `json:"published_at" parquet:"published_at,optional,timestamp(millisecond)"`
+	TimestampSeconds *int32     `json:"timestamp_seconds" parquet:"timestamp_seconds,optional"`
+	Quote            string     `json:"quote" parquet:"quote"`
+}
+
+func newArticle(entity models.Article) Article {
+	row := Article{
+		ID:             entity.ID.String(),
+		Reference:      entity.Reference.String(),
+		Major:          int32(entity.Major),
+		Minor:          int32(entity.Minor),
+		Title:          entity.Title,
+		Body:           entity.Body,
+		Category:       string(entity.Category),
+		EventDate:      entity.EventDate.UTC(),
+		UpdatedAt:      entity.UpdatedAt.UTC(),
+		CorrectionNote: entity.CorrectionNote,
+		Politicians:    make([]Politician, 0, len(entity.Politicians)),
+		Tags:           make([]string, 0, len(entity.Tags)),
+		Sources:        make([]Source, 0, len(entity.Sources)),
+	}
+
+	if entity.PublishedAt != nil {
+		publishedAt := entity.PublishedAt.UTC()
+		row.PublishedAt = &publishedAt
+	}
+
+	for _, p := range entity.Politicians {
+		politician := Politician{ID: p.ID.String(), FirstName: p.FirstName, LastName: p.LastName}
+
+		for _, ap := range entity.ArticlePoliticians {
+			if ap.PoliticianID == p.ID {
+				politician.OccupationTitle = ap.Office
+				if ap.Occupation != nil {
+					politician.OccupationCode = ap.Occupation.Code
+				}
+			}
+		}
+
+		row.Politicians = append(row.Politicians, politician)
+	}
+
+	for _, t := range entity.Tags {
+		row.Tags = append(row.Tags, t.Tag)
+	}
+
+	for _, s := range entity.Sources {
+		source := Source{
+			URL:       s.URL,
+			Type:      string(s.Type),
+			Publisher: s.Publisher,
+			Quote:     s.Quote,
+		}
+
+		if s.PublishedAt != nil {
+			publishedAt := s.PublishedAt.UTC()
+			source.PublishedAt = &publishedAt
+		}
+
+		if s.TimestampSeconds != nil {
+			timestamp := int32(*s.TimestampSeconds)
+			source.TimestampSeconds = &timestamp
+		}
+
+		row.Sources = append(row.Sources, source)
+	}
+
+	return row
+}
@@ -0,0 +1,124 @@
+package open_data
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// encoder writes the rows of one format as they are streamed from the database
+type encoder interface {
+	encode(row Article) error
+	close() error
+}
+
+type format struct {
+	Name      string
+	FileName  string
+	MediaType string
+
+	newEncoder func(w io.Writer) encoder
+}
+
+var formats = []format{
+	{Name: "csv", FileName: "articles.csv", MediaType: "text/csv", newEncoder: newCSVEncoder},
+	{Name: "ndjson", FileName: "articles.ndjson", MediaType: "application/x-ndjson", newEncoder: newNDJSONEncoder},
+	{Name: "parquet", FileName: "articles.parquet", MediaType: "application/vnd.apache.parquet", newEncoder: newParquetEncoder},
+}
+
+// csvEncoder flattens the nested relations of a row into JSON cells
+type csvEncoder struct {
+	w      *csv.Writer
+	header bool
+}
+
+var csvHeader = []string{
+	"id", "reference", "major", "minor", "title", "body", "category",
+	"event_date", "published_at", "updated_at", "correction_note",
+	"politicians", "tags", "sources",
+}
+
+func newCSVEncoder(w io.Writer) encoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+func (e *csvEncoder) encode(row Article) error {
+	if !e.header {
+		if err := e.w.Write(csvHeader); err != nil {
+			return err
+		}
+		e.header = true
+	}
+
+	politicians, err := json.Marshal(row.Politicians)
+	if err != nil {
+		return err
+	}
+	tags, err := json.Marshal(row.Tags)
+	if err != nil {
+		return err
+	}
+	sources, err := json.Marshal(row.Sources)
+	if err != nil {
+		return err
+	}
+
+	publishedAt := ""
+	if row.PublishedAt != nil {
+		publishedAt = row.PublishedAt.Format(time.RFC3339)
+	}
+
+	return e.w.Write([]string{
+		row.ID, row.Reference, strconv.Itoa(int(row.Major)), strconv.Itoa(int(row.Minor)), row.Title, row.Body, row.Category,
+		row.EventDate.Format(time.RFC3339), publishedAt, row.UpdatedAt.Format(time.RFC3339), row.CorrectionNote,
+		string(politicians), string(tags), string(sources),
+	})
+}
+
+func (e *csvEncoder) close() error {
+	// an empty dataset still has its header
+	if !e.header {
+		if err := e.w.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func newNDJSONEncoder(w io.Writer) encoder {
+	return &ndjsonEncoder{enc: json.NewEncoder(w)}
+}
+
+func (e *ndjsonEncoder) encode(row Article) error {
+	return e.enc.Encode(row)
+}
+
+func (e *ndjsonEncoder) close() error {
+	return nil
+}
+
+type parquetEncoder struct {
+	w *parquet.GenericWriter[Article]
+}
+
+func newParquetEncoder(w io.Writer) encoder {
+	return &parquetEncoder{w: parquet.NewGenericWriter[Article](w, parquet.Compression(&parquet.Zstd))}
+}
+
+func (e *parquetEncoder) encode(row Article) error {
+	_, err := e.w.Write([]Article{row})
+	return err
+}
+
+func (e *parquetEncoder) close() error {
+	return e.w.Close()
+}
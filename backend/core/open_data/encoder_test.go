@@ -0,0 +1,148 @@
+package open_data
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRow() Article {
+	publishedAt := time.Date(2024, 6, 10, 8, 30, 0, 0, time.UTC)
+	timestamp := int32(754)
+
+	return Article{
+		ID:          "d3b07384-d9a7-4f3b-9a4e-0f6f2b1c9a11",
+		Reference:   "9a0364b9-e99b-4b3d-8c1a-3f5a7a2b8c22",
+		Major:       2,
+		Minor:       0,
+		Title:       "Article about Emmanuel Macron",
+		Body:        "Body, with a comma\nand a new line",
+		Category:    "LIE",
+		EventDate:   time.Date(2024, 6, 9, 20, 0, 0, 0, time.UTC),
+		PublishedAt: &publishedAt,
+		UpdatedAt:   publishedAt,
+		Politicians: []Politician{{
+			ID:              "5f1c6e0a-7c55-4c8f-bb0d-6d3a3b2c1d33",
+			FirstName:       "Emmanuel",
+			LastName:        "Macron",
+			OccupationCode:  "PRESIDENT",
+			OccupationTitle: "Président de la République",
+		}},
+		Tags: []string{"Européennes"},
+		Sources: []Source{{
+			URL:              "https://example.com/video",
+			Type:             "VIDEO",
+			Publisher:        "France 2",
+			TimestampSeconds: &timestamp,
+			Quote:            "Le chômage n'a jamais été aussi bas",
+		}},
+	}
+}
+
+func encode(t *testing.T, newEncoder func(w *bytes.Buffer) encoder, rows ...Article) []byte {
+	var buf bytes.Buffer
+	enc := newEncoder(&buf)
+
+	for _, row := range rows {
+		if err := enc.encode(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := enc.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestCSVEncoder(t *testing.T) {
+	row := newTestRow()
+
+	out := encode(t, func(w *bytes.Buffer) encoder { return newCSVEncoder(w) }, row)
+
+	records, err := csv.NewReader(bytes.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, csvHeader, records[0])
+		assert.Equal(t, row.Body, records[1][5])
+		assert.Equal(t, "2024-06-10T08:30:00Z", records[1][8])
+
+		var politicians []Politician
+		if err = json.Unmarshal([]byte(records[1][11]), &politicians); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, row.Politicians, politicians)
+	}
+}
+
+func TestCSVEncoder_Empty(t *testing.T) {
+	out := encode(t, func(w *bytes.Buffer) encoder { return newCSVEncoder(w) })
+
+	records, err := csv.NewReader(bytes.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, [][]string{csvHeader}, records)
+}
+
+func TestNDJSONEncoder(t *testing.T) {
+	row := newTestRow()
+
+	out := encode(t, func(w *bytes.Buffer) encoder { return newNDJSONEncoder(w) }, row, row)
+
+	lines := bytes.Split(bytes.TrimSpace(out), []byte("\n"))
+	if assert.Len(t, lines, 2) {
+		var actual Article
+		if err := json.Unmarshal(lines[1], &actual); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, row, actual)
+	}
+}
+
+func TestParquetEncoder(t *testing.T) {
+	row := newTestRow()
+
+	out := encode(t, func(w *bytes.Buffer) encoder { return newParquetEncoder(w) }, row)
+
+	rows, err := parquet.Read[Article](bytes.NewReader(out), int64(len(out)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, rows, 1) {
+		actual := rows[0]
+		assert.Equal(t, row.ID, actual.ID)
+		assert.Equal(t, row.Major, actual.Major)
+		assert.True(t, row.EventDate.Equal(actual.EventDate))
+		assert.True(t, row.PublishedAt.Equal(*actual.PublishedAt))
+		assert.Equal(t, row.Politicians, actual.Politicians)
+		assert.Equal(t, row.Tags, actual.Tags)
+		if assert.Len(t, actual.Sources, 1) {
+			assert.Nil(t, actual.Sources[0].PublishedAt)
+			assert.Equal(t, row.Sources[0].TimestampSeconds, actual.Sources[0].TimestampSeconds)
+		}
+	}
+}
+
+func TestChecksumWriter(t *testing.T) {
+	var buf bytes.Buffer
+	cw := newChecksumWriter(&buf)
+
+	_, _ = cw.Write([]byte("hello "))
+	_, _ = cw.Write([]byte("world"))
+
+	assert.Equal(t, int64(11), cw.size)
+	// sha256("hello world")
+	assert.Equal(t, "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", cw.sum())
+}
@@ -0,0 +1,145 @@
+package open_data
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	published *models.Article
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	politician := &models.Politician{FirstName: "Emmanuel", LastName: "Macron"}
+	if err = connector.GormDB().Create(politician).Error; err != nil {
+		return
+	}
+
+	occupation := &models.Occupation{
+		PoliticianID: politician.ID,
+		Code:         "PRESIDENT",
+		Title:        "Président de la République",
+		StartDate:    time.Date(2017, 5, 14, 0, 0, 0, 0, time.UTC),
+	}
+	if err = connector.GormDB().Create(occupation).Error; err != nil {
+		return
+	}
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	publishedAt := time.Date(2024, 6, 10, 8, 30, 0, 0, time.UTC)
+
+	data.published = &models.Article{
+		RedactorID:  redactor.ID,
+		Title:       "Published",
+		Status:      models.ArticleStatusPublished,
+		Category:    models.ArticleCategoryLie,
+		Reference:   uuid.New(),
+		Major:       1,
+		EventDate:   time.Date(2024, 6, 9, 20, 0, 0, 0, time.UTC),
+		PublishedAt: &publishedAt,
+		Tags:        []*models.ArticleTag{{Tag: "Européennes"}},
+		Sources:     []*models.ArticleSource{{URL: "https://example.com", Type: models.ArticleSourceTypePressArticle}},
+	}
+	if err = connector.GormDB().Create(data.published).Error; err != nil {
+		return
+	}
+
+	if err = connector.GormDB().Create(&models.ArticlePolitician{
+		ArticleID:    data.published.ID,
+		PoliticianID: politician.ID,
+		OccupationID: &occupation.ID,
+		Office:       occupation.Title,
+	}).Error; err != nil {
+		return
+	}
+
+	draft := &models.Article{
+		RedactorID: redactor.ID,
+		Title:      "Draft",
+		Status:     models.ArticleStatusDraft,
+		Reference:  uuid.New(),
+	}
+	err = connector.GormDB().Create(draft).Error
+	return
+}
+
+type memoryFile struct {
+	name string
+	buf  bytes.Buffer
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	var files []*memoryFile
+
+	manifest, err := Export(connector.GormDB(), func(name string) (io.Writer, error) {
+		f := &memoryFile{name: name}
+		files = append(files, f)
+		return &f.buf, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, ManifestVersion, manifest.Version)
+	assert.Equal(t, 1, manifest.Articles)
+
+	if !assert.Len(t, files, len(formats)+1) {
+		return
+	}
+
+	for i, file := range manifest.Files {
+		sum := sha256.Sum256(files[i].buf.Bytes())
+		assert.Equal(t, files[i].name, file.Name)
+		assert.Equal(t, int64(files[i].buf.Len()), file.Size)
+		assert.Equal(t, hex.EncodeToString(sum[:]), file.SHA256)
+	}
+
+	var written Manifest
+	if err = json.Unmarshal(files[len(files)-1].buf.Bytes(), &written); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ManifestFileName, files[len(files)-1].name)
+	assert.Equal(t, manifest.Files, written.Files)
+
+	var row Article
+	if err = json.Unmarshal(files[1].buf.Bytes(), &row); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, data.published.ID.String(), row.ID)
+	assert.Equal(t, []string{"Européennes"}, row.Tags)
+	if assert.Len(t, row.Politicians, 1) {
+		assert.Equal(t, "PRESIDENT", row.Politicians[0].OccupationCode)
+		assert.Equal(t, "Président de la République", row.Politicians[0].OccupationTitle)
+	}
+}
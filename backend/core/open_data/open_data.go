@@ -0,0 +1,136 @@
+// Package open_data exports the published fact-checks as a versioned dataset, for researchers and re-users.
+package open_data
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+	"vdm/core/models"
+
+	"gorm.io/gorm"
+)
+
+// ManifestVersion is bumped whenever a column is added, removed or changes meaning
+const ManifestVersion = 1
+
+const ManifestFileName = "manifest.json"
+
+const batchSize = 100
+
+type Manifest struct {
+	Version     int            `json:"version"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	Articles    int            `json:"articles"`
+	Files       []ManifestFile `json:"files"`
+}
+
+type ManifestFile struct {
+	Name      string `json:"name"`
+	Format    string `json:"format"`
+	MediaType string `json:"media_type"`
+	Size      int64  `json:"size"`
+	SHA256    string `json:"sha256"`
+}
+
+// Export writes the dataset in every format, then its manifest, to the files opened by create, one after the other.
+// All formats are read from the same snapshot so that they hold the same articles.
+func Export(db *gorm.DB, create func(name string) (io.Writer, error)) (*Manifest, error) {
+	manifest := &Manifest{Version: ManifestVersion, GeneratedAt: time.Now().UTC()}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, f := range formats {
+			w, err := create(f.FileName)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %v", f.FileName, err)
+			}
+
+			cw := newChecksumWriter(w)
+			enc := f.newEncoder(cw)
+
+			count := 0
+			if err = streamPublishedArticles(tx, func(row Article) error {
+				count++
+				return enc.encode(row)
+			}); err != nil {
+				return fmt.Errorf("failed to write %s: %v", f.FileName, err)
+			}
+
+			if err = enc.close(); err != nil {
+				return fmt.Errorf("failed to close %s: %v", f.FileName, err)
+			}
+
+			manifest.Articles = count
+			manifest.Files = append(manifest.Files, ManifestFile{
+				Name:      f.FileName,
+				Format:    f.Name,
+				MediaType: f.MediaType,
+				Size:      cw.size,
+				SHA256:    cw.sum(),
+			})
+		}
+
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := create(ManifestFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %v", ManifestFileName, err)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %v", ManifestFileName, err)
+	}
+
+	return manifest, nil
+}
+
+func streamPublishedArticles(tx *gorm.DB, fn func(row Article) error) error {
+	var batch []models.Article
+
+	return tx.Where("status = ?", models.ArticleStatusPublished).
+		Preload("Politicians", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "first_name", "last_name")
+		}).
+		Preload("ArticlePoliticians.Occupation").
+		Preload("Tags").
+		Preload("Sources").
+		FindInBatches(&batch, batchSize, func(_ *gorm.DB, _ int) error {
+			for i := range batch {
+				if err := fn(newArticle(batch[i])); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+type checksumWriter struct {
+	w    io.Writer
+	hash hash.Hash
+	size int64
+}
+
+func newChecksumWriter(w io.Writer) *checksumWriter {
+	return &checksumWriter{w: w, hash: sha256.New()}
+}
+
+func (cw *checksumWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.hash.Write(p[:n])
+	cw.size += int64(n)
+	return n, err
+}
+
+func (cw *checksumWriter) sum() string {
+	return hex.EncodeToString(cw.hash.Sum(nil))
+}
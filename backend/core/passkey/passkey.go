@@ -0,0 +1,125 @@
+package passkey
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"vdm/core/env"
+	"vdm/core/models"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+)
+
+// New builds the relying party of the passkey ceremonies.
+// Passkeys are discoverable and verify the user with a PIN or biometrics, so a passkey alone stands for two factors.
+func New(cfg env.SecurityConfig) (*webauthn.WebAuthn, error) {
+	timeout := webauthn.TimeoutConfig{Enforce: true, Timeout: cfg.WebAuthnChallengeTTL, TimeoutUVD: cfg.WebAuthnChallengeTTL}
+
+	return webauthn.New(&webauthn.Config{
+		RPID:          cfg.WebAuthnRPID,
+		RPDisplayName: cfg.WebAuthnRPName,
+		RPOrigins:     cfg.WebAuthnRPOrigins,
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			ResidentKey:        protocol.ResidentKeyRequirementRequired,
+			RequireResidentKey: protocol.ResidentKeyRequired(),
+			UserVerification:   protocol.VerificationRequired,
+		},
+		Timeouts: webauthn.TimeoutsConfig{Login: timeout, Registration: timeout},
+	})
+}
+
+// User is a user along with their passkeys, as seen by webauthn.
+// The user handle stored by authenticators is the ID of the user.
+type User struct {
+	ID       uuid.UUID
+	Email    string
+	Passkeys []models.UserPasskey
+}
+
+func (u User) WebAuthnID() []byte { return u.ID[:] }
+
+func (u User) WebAuthnName() string { return u.Email }
+
+func (u User) WebAuthnDisplayName() string { return u.Email }
+
+func (u User) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, len(u.Passkeys))
+	for i, passkey := range u.Passkeys {
+		credentials[i] = Credential(passkey)
+	}
+	return credentials
+}
+
+// UserID returns the ID of the user identified by a user handle
+func UserID(userHandle []byte) (uuid.UUID, error) {
+	return uuid.FromBytes(userHandle)
+}
+
+// Credential is the webauthn credential stored as passkey
+func Credential(passkey models.UserPasskey) webauthn.Credential {
+	var transports []protocol.AuthenticatorTransport
+	if passkey.Transports != "" {
+		for _, transport := range strings.Split(passkey.Transports, ",") {
+			transports = append(transports, protocol.AuthenticatorTransport(transport))
+		}
+	}
+
+	return webauthn.Credential{
+		ID:              passkey.CredentialID,
+		PublicKey:       passkey.PublicKey,
+		AttestationType: passkey.AttestationType,
+		Transport:       transports,
+		Flags:           webauthn.NewCredentialFlags(protocol.AuthenticatorFlags(passkey.Flags)),
+		Authenticator: webauthn.Authenticator{
+			AAGUID:    passkey.AAGUID,
+			SignCount: uint32(passkey.SignCount),
+		},
+	}
+}
+
+// NewUserPasskey is the passkey to store for a credential registered by the user
+func NewUserPasskey(userID uuid.UUID, name string, credential *webauthn.Credential) models.UserPasskey {
+	transports := make([]string, len(credential.Transport))
+	for i, transport := range credential.Transport {
+		transports[i] = string(transport)
+	}
+
+	return models.UserPasskey{
+		UserID:          userID,
+		Name:            name,
+		CredentialID:    credential.ID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		Transports:      strings.Join(transports, ","),
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       int64(credential.Authenticator.SignCount),
+		Flags:           int16(credential.Flags.ProtocolValue()),
+	}
+}
+
+// NewChallenge stores the session of a ceremony until its second step, userID is nil for a sign-in
+func NewChallenge(ceremony models.WebAuthnCeremony, userID *uuid.UUID, session *webauthn.SessionData) (models.WebAuthnChallenge, error) {
+	raw, err := json.Marshal(session)
+	if err != nil {
+		return models.WebAuthnChallenge{}, fmt.Errorf("failed to marshal webauthn session: %v", err)
+	}
+
+	return models.WebAuthnChallenge{
+		Challenge: session.Challenge,
+		Ceremony:  ceremony,
+		UserID:    userID,
+		Session:   string(raw),
+		Expiry:    session.Expires,
+	}, nil
+}
+
+// Session restores the session of a ceremony from its challenge
+func Session(challenge models.WebAuthnChallenge) (webauthn.SessionData, error) {
+	var session webauthn.SessionData
+	if err := json.Unmarshal([]byte(challenge.Session), &session); err != nil {
+		return webauthn.SessionData{}, fmt.Errorf("failed to unmarshal webauthn session: %v", err)
+	}
+	return session, nil
+}
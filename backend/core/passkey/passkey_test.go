@@ -0,0 +1,152 @@
+package passkey
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+	"vdm/core/env"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var testCfg = env.SecurityConfig{
+	WebAuthnRPID:         "localhost",
+	WebAuthnRPName:       "Vigie du mensonge",
+	WebAuthnRPOrigins:    []string{"http://localhost:5173"},
+	WebAuthnChallengeTTL: time.Minute,
+}
+
+// roundTrip sends options to the client and stores the session as the routes do
+func roundTrip[T any](t *testing.T, options any, ceremony models.WebAuthnCeremony, session *webauthn.SessionData) (T, webauthn.SessionData) {
+	var sent T
+	raw, err := json.Marshal(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(raw, &sent); err != nil {
+		t.Fatal(err)
+	}
+
+	challenge, err := NewChallenge(ceremony, nil, session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, session.Expires, challenge.Expiry)
+
+	stored, err := Session(challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sent, stored
+}
+
+func TestPasskey(t *testing.T) {
+	wa, err := New(testCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authenticator, err := test_utils.NewPasskeyAuthenticator("http://localhost:5173")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := User{ID: uuid.New(), Email: "user@test.com"}
+
+	// registration
+	creation, session, err := wa.BeginRegistration(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, protocol.VerificationRequired, creation.Response.AuthenticatorSelection.UserVerification)
+
+	sentCreation, storedSession := roundTrip[protocol.CredentialCreation](t, creation, models.WebAuthnCeremonyRegistration, session)
+
+	body, err := authenticator.Create(sentCreation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsedCreation, err := protocol.ParseCredentialCreationResponseBytes(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	credential, err := wa.CreateCredential(user, storedSession, parsedCreation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	passkey := NewUserPasskey(user.ID, "laptop", credential)
+	assert.Equal(t, authenticator.CredentialID, passkey.CredentialID)
+	assert.Equal(t, "internal", passkey.Transports)
+	user.Passkeys = []models.UserPasskey{passkey}
+
+	// sign-in, the user is found from the user handle
+	assertion, session, err := wa.BeginDiscoverableLogin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sentAssertion, storedSession := roundTrip[protocol.CredentialAssertion](t, assertion, models.WebAuthnCeremonyLogin, session)
+
+	body, err = authenticator.Get(sentAssertion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsedAssertion, err := protocol.ParseCredentialRequestResponseBytes(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, credential, err := wa.ValidatePasskeyLogin(func(rawID, userHandle []byte) (webauthn.User, error) {
+		userID, err := UserID(userHandle)
+		if err != nil {
+			return nil, err
+		}
+		assert.Equal(t, user.ID, userID)
+		return user, nil
+	}, storedSession, parsedAssertion)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, user.WebAuthnID(), found.WebAuthnID())
+	assert.Equal(t, uint32(1), credential.Authenticator.SignCount)
+	assert.False(t, credential.Authenticator.CloneWarning)
+}
+
+func TestPasskey_ErrWrongOrigin(t *testing.T) {
+	wa, err := New(testCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authenticator, err := test_utils.NewPasskeyAuthenticator("https://phishing.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := User{ID: uuid.New(), Email: "user@test.com"}
+
+	creation, session, err := wa.BeginRegistration(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := authenticator.Create(*creation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = wa.CreateCredential(user, *session, parsed)
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+	"vdm/core/device"
+	"vdm/core/env"
+	"vdm/core/hmac_utils"
+	"vdm/core/jwt_utils"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var testCfg = env.SecurityConfig{
+	AccessTokenSecret:  []byte("access"),
+	AccessTokenTTL:     time.Minute,
+	RefreshTokenSecret: []byte("refresh"),
+	RefreshTokenTTL:    time.Hour,
+}
+
+func TestIntegration_Open(t *testing.T) {
+	c := context.Background()
+	container, connector := test_utils.NewTestContainerConnector(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+	db := connector.GormDB()
+
+	user := models.User{Email: "user@test.com", Tag: "user0123", Password: "x"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	expired := models.UserToken{UserID: user.ID, Hash: "expired", Category: models.UserTokenCategoryRefresh, Expiry: time.Now().Add(-time.Minute)}
+	if err := db.Create(&expired).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	accessToken, refreshToken, err := NewOpener(db, testCfg).Open(user, device.Device{UserAgent: "Firefox", IP: "192.0.2.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authedUser, err := jwt_utils.ParseJWT(accessToken.Token, testCfg.AccessTokenSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, user.ID, authedUser.ID)
+	assert.Equal(t, user.Email, authedUser.Email)
+
+	var tokens []models.UserToken
+	if err = db.Where("user_id = ?", user.ID).Find(&tokens).Error; err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, tokens, 1, "the expired token is cleaned up") {
+		assert.Equal(t, hmac_utils.HashUUID(refreshToken.Token, testCfg.RefreshTokenSecret), tokens[0].Hash)
+		assert.Equal(t, authedUser.SessionID, *tokens[0].FamilyID)
+		assert.Equal(t, "192.0.2.1", tokens[0].IP)
+	}
+
+	// a failing transaction leaves no session behind
+	_ = db.Transaction(func(tx *gorm.DB) error {
+		if _, _, err := Open(tx, testCfg, user, device.Device{}); err != nil {
+			t.Fatal(err)
+		}
+		return gorm.ErrInvalidTransaction
+	})
+
+	var count int64
+	if err = db.Model(&models.UserToken{}).Where("user_id = ?", user.ID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(1), count)
+}
+
+func TestIntegration_TOTPConfirmed(t *testing.T) {
+	c := context.Background()
+	container, connector := test_utils.NewTestContainerConnector(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+	db := connector.GormDB()
+
+	user := models.User{Email: "user@test.com", Tag: "user0123", Password: "x"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	userTOTP := models.UserTOTP{UserID: user.ID, Secret: "secret"}
+	if err := db.Create(&userTOTP).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// an enrolment which is not confirmed yet is no second factor
+	confirmed, err := TOTPConfirmed(db, user.ID)
+	assert.NoError(t, err)
+	assert.False(t, confirmed)
+
+	if err = db.Model(&userTOTP).Where("user_id = ?", user.ID).Update("confirmed_at", time.Now()).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	confirmed, err = TOTPConfirmed(db, user.ID)
+	assert.NoError(t, err)
+	assert.True(t, confirmed)
+}
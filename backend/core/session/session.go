@@ -0,0 +1,92 @@
+// Package session opens the sessions of the users signing in or up:
+// a refresh token starting a new family, stored, and a signed access token.
+package session
+
+import (
+	"fmt"
+	"time"
+	"vdm/core/device"
+	"vdm/core/env"
+	"vdm/core/hmac_utils"
+	"vdm/core/jwt_utils"
+	"vdm/core/locals"
+	"vdm/core/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Opener opens sessions for the services, which stub it in their unit tests
+type Opener interface {
+	Open(user models.User, dev device.Device) (locals.AccessToken, locals.RefreshToken, error)
+}
+
+type opener struct {
+	db  *gorm.DB
+	cfg env.SecurityConfig
+}
+
+func NewOpener(db *gorm.DB, cfg env.SecurityConfig) Opener {
+	return &opener{db: db, cfg: cfg}
+}
+
+func (o *opener) Open(user models.User, dev device.Device) (locals.AccessToken, locals.RefreshToken, error) {
+	return Open(o.db, o.cfg, user, dev)
+}
+
+// Open stores the refresh token of a new session of user on dev, and signs its access token.
+// db may be a transaction, for the session to be opened along with the creation of the user.
+func Open(db *gorm.DB, cfg env.SecurityConfig, user models.User, dev device.Device) (locals.AccessToken, locals.RefreshToken, error) {
+	rft := uuid.New()
+	// every sign-in starts a new family of refresh tokens, i.e. a new session
+	family := uuid.New()
+	now := time.Now()
+
+	usrTok := models.UserToken{
+		UserID:      user.ID,
+		Expiry:      now.Add(cfg.RefreshTokenTTL),
+		Hash:        hmac_utils.HashUUID(rft, cfg.RefreshTokenSecret),
+		Category:    models.UserTokenCategoryRefresh,
+		FamilyID:    &family,
+		DeviceLabel: dev.Label(),
+		UserAgent:   dev.UserAgent,
+		IP:          dev.IP,
+		CreatedAt:   now,
+		LastUsedAt:  now,
+	}
+
+	if err := createRefreshToken(db, &usrTok); err != nil {
+		return locals.AccessToken{}, locals.RefreshToken{}, fmt.Errorf("failed to create refresh token: %v", err)
+	}
+
+	jwtExpiry := now.Add(cfg.AccessTokenTTL)
+	jwt, err := jwt_utils.GenerateJWT(locals.AuthedUser{ID: user.ID, Email: user.Email, SessionID: family},
+		cfg.AccessTokenSecret, jwtExpiry)
+	if err != nil {
+		return locals.AccessToken{}, locals.RefreshToken{}, fmt.Errorf("failed to generate JWT: %v", err)
+	}
+
+	return locals.AccessToken{Token: jwt, Expiry: jwtExpiry},
+		locals.RefreshToken{Token: rft, Expiry: usrTok.Expiry},
+		nil
+}
+
+func createRefreshToken(db *gorm.DB, rft *models.UserToken) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		// the other sessions of the user are kept, only the expired ones are cleaned up
+		if err := tx.Where("user_id = ? AND category = ? AND expiry <= ?", rft.UserID, models.UserTokenCategoryRefresh, time.Now()).
+			Delete(&models.UserToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(rft).Error
+	})
+}
+
+// TOTPConfirmed tells whether the user enrolled a TOTP, i.e. has a second factor to check
+func TOTPConfirmed(db *gorm.DB, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := db.Model(&models.UserTOTP{}).
+		Where("user_id = ? AND confirmed_at IS NOT NULL", userID).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,47 @@
+package totp
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+const (
+	RecoveryCodesCount = 10
+
+	recoveryCodeSize = 10
+	// the alphabet leaves out the characters that are easily mistaken for one another
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+// GenerateRecoveryCodes returns codes formatted as "xxxxx-xxxxx", shown once to the user and stored hashed
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, RecoveryCodesCount)
+
+	for i := range codes {
+		raw := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+
+		var b strings.Builder
+		for j, r := range raw {
+			if j == recoveryCodeSize/2 {
+				b.WriteByte('-')
+			}
+			b.WriteByte(recoveryCodeAlphabet[int(r)%len(recoveryCodeAlphabet)])
+		}
+		codes[i] = b.String()
+	}
+
+	return codes, nil
+}
+
+// NormalizeRecoveryCode tolerates the case and the separators of a typed code, it is applied before hashing
+func NormalizeRecoveryCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+}
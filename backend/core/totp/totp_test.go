@@ -0,0 +1,90 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is the SHA1 seed of the RFC 6238 test vectors
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238(t *testing.T) {
+	// the 6 last digits of the 8 digit codes of RFC 6238 appendix B
+	for unix, expected := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		code, err := Code(rfcSecret, Step(time.Unix(unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	step, ok := Validate(rfcSecret, "081804", now)
+	assert.True(t, ok)
+	assert.Equal(t, Step(now), step)
+
+	// the previous code is still accepted, not the ones before it
+	_, ok = Validate(rfcSecret, "081804", now.Add(Period*time.Second))
+	assert.True(t, ok)
+	_, ok = Validate(rfcSecret, "081804", now.Add(2*Period*time.Second))
+	assert.False(t, ok)
+
+	for _, code := range []string{"", "12345", "1234567", "000000"} {
+		_, ok = Validate(rfcSecret, code, now)
+		assert.False(t, ok, code)
+	}
+
+	_, ok = Validate("not base32!", "081804", now)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, secret, 32)
+
+	other, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, secret, other)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("Vigie du mensonge", "user@test.com", "JBSWY3DPEHPK3PXP")
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Vigie%20du%20mensonge:user@test.com?"), uri)
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=Vigie+du+mensonge")
+	assert.Contains(t, uri, "digits=6")
+	assert.Contains(t, uri, "period=30")
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, codes, RecoveryCodesCount)
+	for _, code := range codes {
+		assert.Regexp(t, `^[a-z2-9]{5}-[a-z2-9]{5}$`, code)
+	}
+
+	assert.Equal(t, "abcde23456", NormalizeRecoveryCode(" ABCDE-23456 "))
+	assert.Equal(t, NormalizeRecoveryCode(codes[0]), NormalizeRecoveryCode(strings.ToUpper(codes[0])))
+}
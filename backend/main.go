@@ -12,7 +12,11 @@ import (
 	"vdm/core/dependencies/mailer"
 	"vdm/core/env"
 	"vdm/core/fiberx"
+	"vdm/core/http_cache"
+	"vdm/core/lockout"
 	"vdm/core/logger"
+	"vdm/core/passkey"
+	"vdm/workers"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/recover"
@@ -42,9 +46,28 @@ func main() {
 		}
 	}(dbConn)
 
-	deps := dependencies.New(cfg, dbConn, mailer.New(cfg.Mailer))
+	cacheStore, err := http_cache.NewStore(cfg.Cache)
+	if err != nil {
+		logger.Error("failed to init cache store", logger.Err(err))
+		os.Exit(1)
+	}
+
+	lockoutStore, err := lockout.NewStore(cfg.Lockout)
+	if err != nil {
+		logger.Error("failed to init lockout store", logger.Err(err))
+		os.Exit(1)
+	}
 
-	app := fiberx.NewApp()
+	webAuthn, err := passkey.New(cfg.Security)
+	if err != nil {
+		logger.Error("failed to init webauthn", logger.Err(err))
+		os.Exit(1)
+	}
+
+	deps := dependencies.New(cfg, dbConn, mailer.New(cfg.Mailer), http_cache.New(cacheStore, cfg.Cache.TTL, cfg.Cache.MaxAge), webAuthn,
+		lockout.New(lockoutStore, cfg.Lockout))
+
+	app := fiberx.NewApp(fiberx.WithProxy(cfg.Proxy))
 	app.Use(recover.New())
 	app.Use(requestid.New())
 
@@ -74,6 +97,8 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	workers.Start(ctx, deps)
+
 	go func() {
 		<-ctx.Done()
 		if err := app.Shutdown(); err != nil {
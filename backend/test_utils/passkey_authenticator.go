@@ -0,0 +1,166 @@
+package test_utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+)
+
+// PasskeyAuthenticator is a software authenticator holding a single ES256 passkey,
+// it answers the options of the passkey ceremonies like a browser would, with no attestation.
+type PasskeyAuthenticator struct {
+	Origin       string
+	CredentialID []byte
+	UserHandle   []byte
+	SignCount    uint32
+
+	key *ecdsa.PrivateKey
+}
+
+const (
+	flagUserPresent   = 0x01
+	flagUserVerified  = 0x04
+	flagAttestedCreds = 0x40
+)
+
+func NewPasskeyAuthenticator(origin string) (*PasskeyAuthenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialID := make([]byte, 32)
+	if _, err = rand.Read(credentialID); err != nil {
+		return nil, err
+	}
+
+	return &PasskeyAuthenticator{Origin: origin, CredentialID: credentialID, key: key}, nil
+}
+
+// Create answers navigator.credentials.create, the JSON returned is the body the client sends to the API
+func (a *PasskeyAuthenticator) Create(creation protocol.CredentialCreation) ([]byte, error) {
+	userHandle, err := userHandle(creation.Response.User.ID)
+	if err != nil {
+		return nil, err
+	}
+	a.UserHandle = userHandle
+
+	clientData, err := a.clientData(protocol.CreateCeremony, creation.Response.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := a.key.PublicKey.ECDH()
+	if err != nil {
+		return nil, err
+	}
+	point := pub.Bytes()
+
+	coseKey, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  1, // P-256
+		XCoord: point[1:33],
+		YCoord: point[33:],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	authData := a.authData(creation.Response.RelyingParty.ID, flagUserPresent|flagUserVerified|flagAttestedCreds)
+	authData = append(authData, make([]byte, 16)...) // AAGUID
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.CredentialID)))
+	authData = append(authData, a.CredentialID...)
+	authData = append(authData, coseKey...)
+
+	attestationObject, err := webauthncbor.Marshal(struct {
+		Format   string         `cbor:"fmt"`
+		AttStmt  map[string]any `cbor:"attStmt"`
+		AuthData []byte         `cbor:"authData"`
+	}{Format: "none", AttStmt: map[string]any{}, AuthData: authData})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]any{
+		"id":    base64.RawURLEncoding.EncodeToString(a.CredentialID),
+		"rawId": base64.RawURLEncoding.EncodeToString(a.CredentialID),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientData),
+			"attestationObject": base64.RawURLEncoding.EncodeToString(attestationObject),
+			"transports":        []string{"internal"},
+		},
+	})
+}
+
+// Get answers navigator.credentials.get, the JSON returned is the body the client sends to the API
+func (a *PasskeyAuthenticator) Get(assertion protocol.CredentialAssertion) ([]byte, error) {
+	clientData, err := a.clientData(protocol.AssertCeremony, assertion.Response.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	a.SignCount++
+	authData := a.authData(assertion.Response.RelyingPartyID, flagUserPresent|flagUserVerified)
+
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(authData, clientDataHash[:]...))
+
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]any{
+		"id":    base64.RawURLEncoding.EncodeToString(a.CredentialID),
+		"rawId": base64.RawURLEncoding.EncodeToString(a.CredentialID),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientData),
+			"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+			"signature":         base64.RawURLEncoding.EncodeToString(signature),
+			"userHandle":        base64.RawURLEncoding.EncodeToString(a.UserHandle),
+		},
+	})
+}
+
+func (a *PasskeyAuthenticator) clientData(ceremony protocol.CeremonyType, challenge protocol.URLEncodedBase64) ([]byte, error) {
+	return json.Marshal(protocol.CollectedClientData{
+		Type:      ceremony,
+		Challenge: challenge.String(),
+		Origin:    a.Origin,
+	})
+}
+
+func (a *PasskeyAuthenticator) authData(rpID string, flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+
+	authData := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(authData, a.SignCount)
+}
+
+// userHandle reads the user.id of creation options, built by webauthn or decoded from the JSON sent to the client
+func userHandle(id any) ([]byte, error) {
+	switch id := id.(type) {
+	case protocol.URLEncodedBase64:
+		return id, nil
+	case []byte:
+		return id, nil
+	case string:
+		return base64.RawURLEncoding.DecodeString(id)
+	default:
+		return nil, fmt.Errorf("unexpected user id %T", id)
+	}
+}
@@ -0,0 +1,142 @@
+package archive_sources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// maxBodySize bounds the captured content of a source
+const maxBodySize = 2 << 20
+
+const userAgent = "VigieDuMensonge-SourceArchiver/1.0 (+https://vigiedumensonge.gocorp.fr)"
+
+var (
+	titleRegexp  = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	hiddenRegexp = regexp.MustCompile(`(?is)<(script|style|noscript|template)[^>]*>.*?</(script|style|noscript|template)>`)
+	tagRegexp    = regexp.MustCompile(`(?s)<!--.*?-->|<[^>]*>`)
+	spaceRegexp  = regexp.MustCompile(`\s+`)
+)
+
+type capture struct {
+	statusCode  int
+	finalURL    string
+	title       string
+	contentHash string
+	html        string
+	text        string
+}
+
+type fetcher struct {
+	client *http.Client
+}
+
+// newFetcher refuses to connect to loopback, private and link-local addresses unless allowPrivate is set,
+// so that a cited URL can't be used to reach the internal network. Tests allow them to fetch a local stub.
+func newFetcher(timeout time.Duration, allowPrivate bool) *fetcher {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = refusePrivateAddress
+	}
+
+	return &fetcher{client: &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   timeout,
+			ResponseHeaderTimeout: timeout,
+			MaxIdleConnsPerHost:   2,
+		},
+	}}
+}
+
+// refusePrivateAddress runs once the host is resolved, which also covers redirects and DNS names pointing to private addresses.
+func refusePrivateAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("refusing to connect to non-public address %s", host)
+	}
+
+	return nil
+}
+
+func (f *fetcher) fetch(ctx context.Context, rawURL string) (capture, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return capture{}, fmt.Errorf("invalid source url %q", rawURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return capture{}, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	res, err := f.client.Do(req)
+	if err != nil {
+		return capture{}, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxBodySize))
+	if err != nil {
+		return capture{}, fmt.Errorf("failed to read body of %s: %v", rawURL, err)
+	}
+
+	sum := sha256.Sum256(body)
+	snap := capture{
+		statusCode:  res.StatusCode,
+		finalURL:    res.Request.URL.String(),
+		contentHash: hex.EncodeToString(sum[:]),
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		snap.html = sanitize(string(body))
+		snap.title, snap.text = extractText(snap.html)
+	case strings.HasPrefix(mediaType, "text/"):
+		snap.text = sanitize(string(body))
+	}
+
+	return snap, nil
+}
+
+// sanitize makes content storable in a text column
+func sanitize(s string) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(s, ""), "\x00", "")
+}
+
+// extractText returns the title and the visible text of a page.
+func extractText(page string) (title, text string) {
+	if match := titleRegexp.FindStringSubmatch(page); match != nil {
+		title = collapse(html.UnescapeString(tagRegexp.ReplaceAllString(match[1], " ")))
+	}
+
+	text = hiddenRegexp.ReplaceAllString(page, " ")
+	text = titleRegexp.ReplaceAllString(text, " ")
+	text = tagRegexp.ReplaceAllString(text, " ")
+
+	return title, collapse(html.UnescapeString(text))
+}
+
+func collapse(s string) string {
+	return strings.TrimSpace(spaceRegexp.ReplaceAllString(s, " "))
+}
@@ -0,0 +1,62 @@
+package archive_sources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const page = `<html><head><title>Chômage &amp; emploi</title><style>p { color: red }</style></head>
+<body><script>track()</script><p>Le taux de chômage   atteint 7,3 %.</p><!-- hidden --></body></html>`
+
+func newStub() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(page))
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/article", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusGone)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestFetch(t *testing.T) {
+	stub := newStub()
+	defer stub.Close()
+
+	f := newFetcher(time.Second, true)
+
+	snap, err := f.fetch(context.Background(), stub.URL+"/moved")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, snap.statusCode)
+		assert.Equal(t, stub.URL+"/article", snap.finalURL)
+		assert.Equal(t, "Chômage & emploi", snap.title)
+		assert.Equal(t, "Le taux de chômage atteint 7,3 %.", snap.text)
+		assert.Len(t, snap.contentHash, 64)
+		assert.Equal(t, page, snap.html)
+	}
+
+	snap, err = f.fetch(context.Background(), stub.URL+"/gone")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusGone, snap.statusCode)
+	}
+
+	_, err = f.fetch(context.Background(), "ftp://example.com/file")
+	assert.Error(t, err)
+}
+
+func TestFetch_RefusesPrivateAddresses(t *testing.T) {
+	stub := newStub()
+	defer stub.Close()
+
+	_, err := newFetcher(time.Second, false).fetch(context.Background(), stub.URL+"/article")
+	assert.ErrorContains(t, err, "non-public address")
+}
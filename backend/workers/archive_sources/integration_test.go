@@ -0,0 +1,165 @@
+package archive_sources
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	submitted *models.Article
+	draft     *models.Article
+}
+
+func loadTestData(c context.Context, t *testing.T, stubURL string) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	data.submitted = &models.Article{
+		RedactorID: redactor.ID,
+		Title:      "Submitted",
+		Status:     models.ArticleStatusUnderReview,
+		Reference:  uuid.New(),
+		Minor:      1,
+		Sources: []*models.ArticleSource{
+			{URL: stubURL + "/moved"},
+			{URL: stubURL + "/gone"},
+		},
+	}
+	if err = connector.GormDB().Create(data.submitted).Error; err != nil {
+		return
+	}
+
+	data.draft = &models.Article{
+		RedactorID: redactor.ID,
+		Title:      "Draft",
+		Status:     models.ArticleStatusDraft,
+		Reference:  uuid.New(),
+		Sources:    []*models.ArticleSource{{URL: stubURL + "/draft"}},
+	}
+	err = connector.GormDB().Create(data.draft).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	stub := newStub()
+	defer stub.Close()
+
+	c := context.Background()
+	container, connector, _ := loadTestData(c, t, stub.URL)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	run := job(&repository{connector.GormDB()}, newFetcher(time.Second, true), time.Hour, time.Hour)
+	if err := run(c); err != nil {
+		t.Fatal(err)
+	}
+
+	var snapshots []models.SourceSnapshot
+	if err := connector.GormDB().Order("url").Find(&snapshots).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// drafts are not archived
+	if !assert.Len(t, snapshots, 2) {
+		return
+	}
+
+	gone, moved := snapshots[0], snapshots[1]
+
+	assert.True(t, gone.Broken)
+	assert.Equal(t, http.StatusGone, gone.CheckStatusCode)
+	assert.Nil(t, gone.CapturedAt)
+	assert.NotNil(t, gone.CheckedAt)
+
+	assert.False(t, moved.Broken)
+	assert.Equal(t, stub.URL+"/article", moved.FinalURL)
+	assert.Equal(t, "Chômage & emploi", moved.Title)
+	assert.NotEmpty(t, moved.ContentHash)
+	assert.NotNil(t, moved.CapturedAt)
+
+	// checked sources are not fetched again before the recheck delay
+	stub.Close()
+	if err := run(c); err != nil {
+		t.Fatal(err)
+	}
+	if err := connector.GormDB().First(&moved, "id = ?", moved.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, moved.Broken)
+
+	// once due, a dead link is flagged but its capture is kept
+	run = job(&repository{connector.GormDB()}, newFetcher(time.Second, true), time.Hour, 0)
+	if err := run(c); err != nil {
+		t.Fatal(err)
+	}
+	if err := connector.GormDB().First(&moved, "id = ?", moved.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, moved.Broken)
+	assert.NotEmpty(t, moved.CheckError)
+	assert.Equal(t, "Chômage & emploi", moved.Title)
+}
+
+func TestIntegration_RetriesUncapturedSources(t *testing.T) {
+	stub := newStub()
+	defer stub.Close()
+
+	c := context.Background()
+	container, connector, _ := loadTestData(c, t, stub.URL)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	run := job(&repository{connector.GormDB()}, newFetcher(time.Second, true), 10*time.Millisecond, time.Hour)
+	if err := run(c); err != nil {
+		t.Fatal(err)
+	}
+
+	var gone, moved models.SourceSnapshot
+	if err := connector.GormDB().First(&gone, "url = ?", stub.URL+"/gone").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := connector.GormDB().First(&moved, "url = ?", stub.URL+"/moved").Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, gone.CaptureFailures)
+	if assert.NotNil(t, gone.RetryAt) {
+		assert.True(t, gone.RetryAt.After(*gone.CheckedAt))
+	}
+	assert.Nil(t, moved.RetryAt)
+
+	// the uncaptured source is fetched again once its retry is due, the captured one waits for the recheck delay
+	time.Sleep(20 * time.Millisecond)
+	if err := run(c); err != nil {
+		t.Fatal(err)
+	}
+
+	var retried, unchanged models.SourceSnapshot
+	if err := connector.GormDB().First(&retried, "id = ?", gone.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := connector.GormDB().First(&unchanged, "id = ?", moved.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, retried.CaptureFailures)
+	assert.True(t, retried.CheckedAt.After(*gone.CheckedAt))
+	assert.True(t, unchanged.CheckedAt.Equal(*moved.CheckedAt))
+}
@@ -0,0 +1,152 @@
+package archive_sources
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"vdm/core/env"
+	"vdm/core/logger"
+	"vdm/core/models"
+
+	"gorm.io/gorm"
+)
+
+// batchSize bounds the number of sources fetched by a single run
+const batchSize = 100
+
+// Job archives the sources of newly submitted articles and re-checks the links of the ones already archived.
+// A source that couldn't be captured yet is retried after CheckInterval, then twice as late after each failure.
+func Job(db *gorm.DB, cfg env.SourcesConfig) func(ctx context.Context) error {
+	return job(&repository{db}, newFetcher(cfg.FetchTimeout, false), cfg.CheckInterval, cfg.RecheckAfter)
+}
+
+func job(repo *repository, fetcher *fetcher, retryAfter, recheckAfter time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if err := repo.trackSubmittedSources(ctx); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		due, err := repo.findDueSnapshots(ctx, now, now.Add(-recheckAfter))
+		if err != nil {
+			return err
+		}
+
+		broken := 0
+		for _, snapshot := range due {
+			result, fetchErr := fetcher.fetch(ctx, snapshot.URL)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			retryDelay := captureRetryDelay(snapshot.CaptureFailures+1, retryAfter, recheckAfter)
+			if err = repo.saveCheck(ctx, snapshot, result, fetchErr, retryDelay); err != nil {
+				return err
+			}
+			if fetchErr != nil || result.statusCode >= 400 {
+				broken++
+			}
+		}
+
+		if len(due) > 0 {
+			logger.Info("checked article sources", logger.Any("count", len(due)), logger.Any("broken", broken))
+		}
+
+		return nil
+	}
+}
+
+// captureRetryDelay doubles retryAfter with each failed capture, up to recheckAfter
+func captureRetryDelay(failures int, retryAfter, recheckAfter time.Duration) time.Duration {
+	delay := retryAfter
+	for range failures - 1 {
+		if delay >= recheckAfter/2 {
+			return recheckAfter
+		}
+		delay *= 2
+	}
+	return min(delay, recheckAfter)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// citedSources are the sources of articles submitted for review, or published.
+// Drafts are ignored, and so are archived versions unless a live version still cites the source.
+const citedSources = `SELECT DISTINCT article_sources.url
+FROM article_sources
+JOIN articles ON articles.id = article_sources.article_id
+WHERE articles.deleted_at IS NULL AND articles.status IN ?`
+
+var citingStatuses = []models.ArticleStatus{
+	models.ArticleStatusUnderReview, models.ArticleStatusChangeRequested, models.ArticleStatusPublished,
+}
+
+// trackSubmittedSources creates an empty snapshot for each cited source that has none yet.
+func (r *repository) trackSubmittedSources(ctx context.Context) error {
+	if err := r.db.WithContext(ctx).
+		Exec("INSERT INTO source_snapshots (url) "+citedSources+" ON CONFLICT (url) DO NOTHING", citingStatuses).
+		Error; err != nil {
+		return fmt.Errorf("failed to track submitted sources: %v", err)
+	}
+
+	return nil
+}
+
+// findDueSnapshots returns the cited sources never checked, then the ones not captured yet whose retry is due,
+// and the captured ones checked before deadline.
+func (r *repository) findDueSnapshots(ctx context.Context, now, deadline time.Time) ([]models.SourceSnapshot, error) {
+	var snapshots []models.SourceSnapshot
+
+	if err := r.db.WithContext(ctx).
+		Where("url IN ("+citedSources+") AND (checked_at IS NULL"+
+			" OR (captured_at IS NULL AND retry_at <= ?)"+
+			" OR (captured_at IS NOT NULL AND checked_at < ?))", citingStatuses, now, deadline).
+		Select("id", "url", "captured_at", "capture_failures").
+		Order("checked_at NULLS FIRST").
+		Limit(batchSize).
+		Find(&snapshots).Error; err != nil {
+		return nil, fmt.Errorf("failed to find source snapshots checked before %s: %v", deadline, err)
+	}
+
+	return snapshots, nil
+}
+
+// saveCheck records the result of a check. The first successful fetch also stores the capture,
+// until then each failure schedules a retry after retryDelay.
+func (r *repository) saveCheck(ctx context.Context, snapshot models.SourceSnapshot, result capture, fetchErr error, retryDelay time.Duration) error {
+	updates := map[string]any{
+		"check_status_code": result.statusCode,
+		"check_error":       "",
+		"broken":            fetchErr != nil || result.statusCode >= 400,
+		"checked_at":        time.Now(),
+		"updated_at":        time.Now(),
+	}
+	if fetchErr != nil {
+		updates["check_error"] = fetchErr.Error()
+	}
+
+	if snapshot.CapturedAt == nil && fetchErr == nil && result.statusCode < 400 {
+		updates["status_code"] = result.statusCode
+		updates["final_url"] = result.finalURL
+		updates["title"] = result.title
+		updates["content_hash"] = result.contentHash
+		updates["html"] = result.html
+		updates["text"] = result.text
+		updates["captured_at"] = time.Now()
+		updates["retry_at"] = nil
+	} else if snapshot.CapturedAt == nil {
+		updates["capture_failures"] = snapshot.CaptureFailures + 1
+		updates["retry_at"] = time.Now().Add(retryDelay)
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&models.SourceSnapshot{}).
+		Where("id = ?", snapshot.ID).
+		Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to save check of SourceSnapshot{URL=%s}: %v", snapshot.URL, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package archive_sources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureRetryDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Minute, captureRetryDelay(1, 5*time.Minute, 168*time.Hour))
+	assert.Equal(t, 10*time.Minute, captureRetryDelay(2, 5*time.Minute, 168*time.Hour))
+	assert.Equal(t, 40*time.Minute, captureRetryDelay(4, 5*time.Minute, 168*time.Hour))
+
+	// never later than a recheck
+	assert.Equal(t, 168*time.Hour, captureRetryDelay(12, 5*time.Minute, 168*time.Hour))
+	assert.Equal(t, 168*time.Hour, captureRetryDelay(1000, 5*time.Minute, 168*time.Hour))
+	assert.Equal(t, time.Hour, captureRetryDelay(1, 2*time.Hour, time.Hour))
+}
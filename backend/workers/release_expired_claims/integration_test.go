@@ -0,0 +1,92 @@
+package release_expired_claims
+
+import (
+	"context"
+	"testing"
+	"time"
+	"vdm/core/dependencies/database"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type testData struct {
+	expired *models.Article
+	fresh   *models.Article
+}
+
+func loadTestData(c context.Context, t *testing.T) (container testcontainers.Container, connector database.Connector, data testData) {
+	container, connector = test_utils.NewTestContainerConnector(c, t)
+
+	var err error
+	defer func() {
+		if err != nil {
+			test_utils.CleanUpTestData(c, t, container, connector)
+			t.Fatal(err)
+		}
+	}()
+
+	redactor := &models.User{Email: "redactor@test.com", Tag: "redactor0123", Password: "x"}
+	if err = connector.GormDB().Create(redactor).Error; err != nil {
+		return
+	}
+
+	moderator := &models.User{Email: "moderator@test.com", Tag: "moderator0123", Password: "x"}
+	if err = connector.GormDB().Create(moderator).Error; err != nil {
+		return
+	}
+
+	expiredClaim := time.Now().Add(-4 * 24 * time.Hour)
+	freshClaim := time.Now().Add(-time.Hour)
+
+	data.expired = &models.Article{
+		RedactorID:  redactor.ID,
+		ModeratorID: &moderator.ID,
+		ClaimedAt:   &expiredClaim,
+		Title:       "Expired claim",
+		Status:      models.ArticleStatusUnderReview,
+		Reference:   uuid.New(),
+		Minor:       1,
+	}
+	if err = connector.GormDB().Create(data.expired).Error; err != nil {
+		return
+	}
+
+	data.fresh = &models.Article{
+		RedactorID:  redactor.ID,
+		ModeratorID: &moderator.ID,
+		ClaimedAt:   &freshClaim,
+		Title:       "Fresh claim",
+		Status:      models.ArticleStatusUnderReview,
+		Reference:   uuid.New(),
+		Minor:       1,
+	}
+	err = connector.GormDB().Create(data.fresh).Error
+	return
+}
+
+func TestIntegration_Success(t *testing.T) {
+	c := context.Background()
+	container, connector, data := loadTestData(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	if err := Job(connector.GormDB(), 72*time.Hour)(c); err != nil {
+		t.Fatal(err)
+	}
+
+	var expired, fresh models.Article
+	if err := connector.GormDB().First(&expired, "id = ?", data.expired.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := connector.GormDB().First(&fresh, "id = ?", data.fresh.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, expired.ModeratorID)
+	assert.Nil(t, expired.ClaimedAt)
+	assert.NotNil(t, fresh.ModeratorID)
+	assert.NotNil(t, fresh.ClaimedAt)
+}
@@ -0,0 +1,48 @@
+package release_expired_claims
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"vdm/core/logger"
+	"vdm/core/models"
+
+	"gorm.io/gorm"
+)
+
+// Job puts back in the pending queue the articles a moderator has kept under review for longer than claimTTL.
+func Job(db *gorm.DB, claimTTL time.Duration) func(ctx context.Context) error {
+	repo := &repository{db}
+
+	return func(ctx context.Context) error {
+		released, err := repo.releaseClaimsBefore(ctx, time.Now().Add(-claimTTL))
+		if err != nil {
+			return err
+		}
+
+		if released > 0 {
+			logger.Info("released expired moderator claims", logger.Any("count", released))
+		}
+
+		return nil
+	}
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+// releaseClaimsBefore also releases claims predating the claimed_at column, whose age is unknown.
+func (r *repository) releaseClaimsBefore(ctx context.Context, deadline time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).
+		Model(&models.Article{}).
+		Where("status = ? AND moderator_id IS NOT NULL AND (claimed_at IS NULL OR claimed_at < ?)",
+			models.ArticleStatusUnderReview, deadline).
+		Updates(map[string]any{"moderator_id": nil, "claimed_at": nil})
+
+	if res.Error != nil {
+		return 0, fmt.Errorf("failed to release claims older than %s: %v", deadline, res.Error)
+	}
+
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,51 @@
+package watch_cache
+
+import (
+	"context"
+	"testing"
+	"vdm/core/models"
+	"vdm/test_utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegration_Fingerprint(t *testing.T) {
+	c := context.Background()
+	container, connector := test_utils.NewTestContainerConnector(c, t)
+	t.Cleanup(func() { test_utils.CleanUpTestData(c, t, container, connector) })
+
+	repo := &repository{connector.GormDB()}
+	tables := []any{&models.Politician{}}
+
+	empty, err := repo.fingerprint(c, tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	politician := &models.Politician{FirstName: "Emmanuel", LastName: "Macron"}
+	if err = connector.GormDB().Create(politician).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := repo.fingerprint(c, tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, empty, created)
+
+	if err = connector.GormDB().Delete(politician).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := repo.fingerprint(c, tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, created, deleted)
+
+	unchanged, err := repo.fingerprint(c, tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, deleted, unchanged)
+}
@@ -0,0 +1,86 @@
+package watch_cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"vdm/core/http_cache"
+	"vdm/core/logger"
+	"vdm/core/models"
+
+	"gorm.io/gorm"
+)
+
+// watched lists the tables each cache scope is built from
+var watched = map[http_cache.Scope][]any{
+	http_cache.ScopeArticles:    {&models.Article{}},
+	http_cache.ScopePoliticians: {&models.Politician{}, &models.Occupation{}, &models.Government{}, &models.Party{}, &models.PoliticianAffiliation{}},
+}
+
+// Job invalidates a cache scope when its tables change outside the API, such as politicians loaded by data_import.
+// A scope is invalidated the first time it is seen, since it may have changed while no replica was watching.
+func Job(db *gorm.DB, cache http_cache.Invalidator) func(ctx context.Context) error {
+	repo := &repository{db}
+	return job(repo, cache)
+}
+
+func job(repo Repository, cache http_cache.Invalidator) func(ctx context.Context) error {
+	fingerprints := make(map[http_cache.Scope]string)
+
+	return func(ctx context.Context) error {
+		for scope, tables := range watched {
+			fingerprint, err := repo.fingerprint(ctx, tables)
+			if err != nil {
+				return err
+			}
+
+			if previous, ok := fingerprints[scope]; ok && previous == fingerprint {
+				continue
+			}
+
+			if err = cache.Invalidate(ctx, scope); err != nil {
+				return err
+			}
+			fingerprints[scope] = fingerprint
+
+			logger.Debug("invalidated cache scope", logger.Any("scope", scope))
+		}
+
+		return nil
+	}
+}
+
+type Repository interface {
+	fingerprint(ctx context.Context, tables []any) (string, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+type tableState struct {
+	Count     int64
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+}
+
+// fingerprint changes whenever a row is inserted, updated, soft or hard deleted in tables
+func (r *repository) fingerprint(ctx context.Context, tables []any) (string, error) {
+	var fingerprint string
+
+	for _, table := range tables {
+		var state tableState
+
+		if err := r.db.WithContext(ctx).
+			Unscoped().
+			Model(table).
+			Select("COUNT(*) AS count, MAX(updated_at) AS updated_at, MAX(deleted_at) AS deleted_at").
+			Scan(&state).Error; err != nil {
+			return "", fmt.Errorf("failed to get state of %T: %v", table, err)
+		}
+
+		fingerprint += fmt.Sprintf("%d/%v/%v;", state.Count, state.UpdatedAt, state.DeletedAt)
+	}
+
+	return fingerprint, nil
+}
@@ -0,0 +1,57 @@
+package watch_cache
+
+import (
+	"context"
+	"testing"
+	"vdm/core/http_cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubRepository struct {
+	fingerprints map[any]string
+}
+
+func (r *stubRepository) fingerprint(ctx context.Context, tables []any) (string, error) {
+	var fingerprint string
+	for _, table := range tables {
+		fingerprint += r.fingerprints[table]
+	}
+	return fingerprint, nil
+}
+
+type recordingInvalidator struct {
+	scopes []http_cache.Scope
+}
+
+func (i *recordingInvalidator) Invalidate(ctx context.Context, scopes ...http_cache.Scope) error {
+	i.scopes = append(i.scopes, scopes...)
+	return nil
+}
+
+func TestJob(t *testing.T) {
+	ctx := context.Background()
+	politicians := watched[http_cache.ScopePoliticians][0]
+
+	repo := &stubRepository{fingerprints: map[any]string{politicians: "1"}}
+	cache := &recordingInvalidator{}
+	run := job(repo, cache)
+
+	// every scope is invalidated when first seen
+	if err := run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	assert.ElementsMatch(t, []http_cache.Scope{http_cache.ScopeArticles, http_cache.ScopePoliticians}, cache.scopes)
+
+	cache.scopes = nil
+	if err := run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, cache.scopes)
+
+	repo.fingerprints[politicians] = "2"
+	if err := run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []http_cache.Scope{http_cache.ScopePoliticians}, cache.scopes)
+}
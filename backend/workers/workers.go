@@ -0,0 +1,38 @@
+package workers
+
+import (
+	"context"
+	"time"
+	"vdm/core/dependencies"
+	"vdm/core/logger"
+	"vdm/workers/archive_sources"
+	"vdm/workers/release_expired_claims"
+	"vdm/workers/watch_cache"
+)
+
+// Start launches the background jobs. They stop when ctx is done.
+func Start(ctx context.Context, deps *dependencies.Dependencies) {
+	go every(ctx, "release_expired_claims", deps.Config.Moderation.ClaimSweepInterval,
+		release_expired_claims.Job(deps.GormDB(), deps.Config.Moderation.ClaimTTL))
+	go every(ctx, "archive_sources", deps.Config.Sources.CheckInterval,
+		archive_sources.Job(deps.GormDB(), deps.Config.Sources))
+	go every(ctx, "watch_cache", deps.Config.Cache.WatchInterval,
+		watch_cache.Job(deps.GormDB(), deps.Cache))
+}
+
+// every runs job at each interval until ctx is done. A failing run is logged and retried at the next tick.
+func every(ctx context.Context, name string, interval time.Duration, job func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job(ctx); err != nil {
+				logger.Error("background job failed", logger.Any("job", name), logger.Err(err))
+			}
+		}
+	}
+}
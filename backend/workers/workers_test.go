@@ -0,0 +1,35 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var runs atomic.Int32
+	done := make(chan struct{})
+
+	go func() {
+		every(ctx, "test", 5*time.Millisecond, func(ctx context.Context) error {
+			runs.Add(1)
+			return errors.New("a failing run does not stop the job")
+		})
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected job to stop when context is done")
+	}
+}
@@ -0,0 +1,190 @@
+package import_parties
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+	"vdm/data_import/models"
+
+	"gorm.io/gorm"
+)
+
+type nameKey struct{ first, last string }
+
+// LoadFromCSV imports parties.csv into parties and politician_affiliations tables.
+// CSV format (comma-separated):
+// code_parti,parti,prenom,nom,date_debut,date_fin
+// - Ensures a Party exists for code_parti, named parti, and renames it when parti changed
+// - Resolves the Politician by prenom/nom, who must have been imported beforehand
+// - Inserts a PoliticianAffiliation, or updates its date_fin when one already exists for the same politician, party and start date
+// - Dates are parsed as YYYY-MM-DD; date_fin may be empty (NULL) while the politician is still a member
+func LoadFromCSV(db *gorm.DB) error {
+	f, err := os.Open("parties.csv")
+	if err != nil {
+		return fmt.Errorf("open parties.csv: %w", err)
+	}
+	defer f.Close()
+
+	return load(db, bufio.NewReader(f))
+}
+
+func load(db *gorm.DB, csvReader io.Reader) error {
+	r := csv.NewReader(csvReader)
+	r.Comma = ','
+	r.FieldsPerRecord = -1 // allow empty last column
+
+	// header
+	if _, err := r.Read(); err != nil {
+		return fmt.Errorf("read header: %w", err)
+	}
+
+	// caches: parties by code, politicians by name
+	partyCache := make(map[string]models.Party)
+	polCache := make(map[nameKey]models.Politician)
+
+	const dateLayout = "2006-01-02"
+	loc := time.UTC
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for {
+			rec, err := r.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("read record: %w", err)
+			}
+			if len(rec) < 6 {
+				return fmt.Errorf("unexpected record length %d: %v", len(rec), rec)
+			}
+
+			code := strings.TrimSpace(rec[0])
+			name := strings.TrimSpace(rec[1])
+			first := normalizeName(rec[2])
+			last := normalizeName(rec[3])
+			startStr := strings.TrimSpace(rec[4])
+			endStr := strings.TrimSpace(rec[5])
+
+			if code == "" || name == "" {
+				return fmt.Errorf("missing party code or name: %v", rec)
+			}
+
+			start, err := time.ParseInLocation(dateLayout, startStr, loc)
+			if err != nil {
+				return fmt.Errorf("parse start date '%s': %w", startStr, err)
+			}
+
+			var end sql.NullTime
+			if endStr != "" {
+				te, err := time.ParseInLocation(dateLayout, endStr, loc)
+				if err != nil {
+					return fmt.Errorf("parse end date '%s': %w", endStr, err)
+				}
+				if !te.After(start) {
+					return fmt.Errorf("end date %s is not after start date %s for %s %s", endStr, startStr, first, last)
+				}
+				end = sql.NullTime{Valid: true, Time: te}
+			}
+
+			party, err := getOrCreateParty(tx, partyCache, code, name)
+			if err != nil {
+				return err
+			}
+
+			pol, err := getPoliticianByName(tx, polCache, first, last)
+			if err != nil {
+				return err
+			}
+
+			// idempotency: an affiliation is identified by its politician, party and start date,
+			// its end date follows the CSV so that closing a membership reaches existing databases
+			var existing []models.PoliticianAffiliation
+			if err := tx.Where("politician_id = ? AND party_id = ? AND start_date = ?", pol.ID, party.ID, start).
+				Limit(1).
+				Find(&existing).Error; err != nil {
+				return fmt.Errorf("find existing affiliation: %w", err)
+			}
+			if len(existing) > 0 {
+				if existing[0].EndDate.Valid == end.Valid && existing[0].EndDate.Time.Equal(end.Time) {
+					continue
+				}
+				if err := tx.Model(&existing[0]).Update("end_date", end).Error; err != nil {
+					return fmt.Errorf("update affiliation %s %s %s: %w", code, first, last, err)
+				}
+				continue
+			}
+
+			aff := models.PoliticianAffiliation{
+				PoliticianID: pol.ID,
+				PartyID:      party.ID,
+				StartDate:    start,
+				EndDate:      end,
+			}
+
+			if err := tx.Create(&aff).Error; err != nil {
+				return fmt.Errorf("insert affiliation %s %s %s: %w", code, first, last, err)
+			}
+		}
+		return nil
+	})
+}
+
+func getOrCreateParty(tx *gorm.DB, cache map[string]models.Party, code, name string) (models.Party, error) {
+	if p, ok := cache[code]; ok {
+		return p, nil
+	}
+
+	var p models.Party
+	if err := tx.Where("code = ?", code).First(&p).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p = models.Party{Code: code, Name: name}
+			if err := tx.Create(&p).Error; err != nil {
+				return models.Party{}, fmt.Errorf("create party %s: %w", code, err)
+			}
+		} else {
+			return models.Party{}, fmt.Errorf("find party %s: %w", code, err)
+		}
+	} else if p.Name != name {
+		if err := tx.Model(&p).Update("name", name).Error; err != nil {
+			return models.Party{}, fmt.Errorf("rename party %s: %w", code, err)
+		}
+	}
+
+	cache[code] = p
+	return p, nil
+}
+
+func getPoliticianByName(tx *gorm.DB, cache map[nameKey]models.Politician, first, last string) (models.Politician, error) {
+	k := nameKey{first: first, last: last}
+	if p, ok := cache[k]; ok {
+		return p, nil
+	}
+
+	var p models.Politician
+	if err := tx.Where("first_name = ? AND last_name = ?", first, last).First(&p).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Politician{}, fmt.Errorf("politician %s %s not found; import presidents and occupations first", first, last)
+		}
+		return models.Politician{}, fmt.Errorf("find politician %s %s: %w", first, last, err)
+	}
+
+	cache[k] = p
+	return p, nil
+}
+
+// normalizeName trims and converts Unicode spaces (including NBSP) to normal spaces.
+func normalizeName(s string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '\u00A0' { // NBSP
+			return ' '
+		}
+		return r
+	}, s))
+}
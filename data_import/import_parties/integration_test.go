@@ -0,0 +1,103 @@
+package import_parties
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"vdm/data_import/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const header = "code_parti,parti,prenom,nom,date_debut,date_fin\n"
+
+func newTestDB(c context.Context, t *testing.T) *gorm.DB {
+	container, err := testcontainers.GenericContainer(c, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "postgres:17-alpine",
+			ExposedPorts: []string{"5432/tcp"},
+			WaitingFor:   wait.ForListeningPort("5432/tcp"),
+			Env: map[string]string{
+				"POSTGRES_USER":     "postgres",
+				"POSTGRES_PASSWORD": "postgres",
+				"POSTGRES_DB":       "test_db",
+			},
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to start postgres container: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(c); err != nil {
+			t.Logf("failed to terminate container: %v", err)
+		}
+	})
+
+	host, err := container.Host(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := container.MappedPort(c, "5432")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dsn := fmt.Sprintf("host=%s user=postgres password=postgres dbname=test_db port=%s sslmode=disable", host, port.Port())
+
+	var db *gorm.DB
+	for range 5 {
+		if db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{}); err == nil {
+			break
+		}
+		time.Sleep(2 * time.Second)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = db.AutoMigrate(&models.Politician{}, &models.Party{}, &models.PoliticianAffiliation{}); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestIntegration_LoadUpdatesExistingRows(t *testing.T) {
+	db := newTestDB(context.Background(), t)
+
+	politician := models.Politician{FirstName: "Nicolas", LastName: "Sarkozy"}
+	if err := db.Create(&politician).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := load(db, strings.NewReader(header+"LR,Les Républicains,Nicolas,Sarkozy,2015-05-30,\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	// the membership is closed and the party renamed in a later version of the CSV
+	if err := load(db, strings.NewReader(header+"LR,Les Républicains (LR),Nicolas,Sarkozy,2015-05-30,2024-01-01\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	var affiliations []models.PoliticianAffiliation
+	if err := db.Where("politician_id = ?", politician.ID).Find(&affiliations).Error; err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, affiliations, 1) {
+		assert.True(t, affiliations[0].EndDate.Valid)
+		assert.True(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Equal(affiliations[0].EndDate.Time))
+	}
+
+	var party models.Party
+	if err := db.Where("code = ?", "LR").First(&party).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Les Républicains (LR)", party.Name)
+}
@@ -5,6 +5,7 @@ import (
 	"vdm/data_import/database"
 	"vdm/data_import/import_governments"
 	"vdm/data_import/import_occupations"
+	"vdm/data_import/import_parties"
 	"vdm/data_import/import_presidents"
 	"vdm/data_import/models"
 )
@@ -27,18 +28,22 @@ func main() {
 
 	if loaded {
 		fmt.Println("Data already loaded")
-		return
-	}
+	} else {
+		if err := import_presidents.LoadFromCSV(dbConn.GormDB()); err != nil {
+			panic(err)
+		}
 
-	if err := import_presidents.LoadFromCSV(dbConn.GormDB()); err != nil {
-		panic(err)
-	}
+		if err := import_governments.LoadFromCSV(dbConn.GormDB()); err != nil {
+			panic(err)
+		}
 
-	if err := import_governments.LoadFromCSV(dbConn.GormDB()); err != nil {
-		panic(err)
+		if err := import_occupations.LoadFromCSV(dbConn.GormDB()); err != nil {
+			panic(err)
+		}
 	}
 
-	if err := import_occupations.LoadFromCSV(dbConn.GormDB()); err != nil {
+	// parties are imported on every run, so that affiliations added to parties.csv reach existing databases
+	if err := import_parties.LoadFromCSV(dbConn.GormDB()); err != nil {
 		panic(err)
 	}
 
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PoliticianAffiliation struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+
+	PoliticianID uuid.UUID
+	PartyID      uuid.UUID
+
+	StartDate time.Time
+	EndDate   sql.NullTime
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt
+}
+
+func (PoliticianAffiliation) TableName() string {
+	return "politician_affiliations"
+}
@@ -1,5 +1,12 @@
 CREATE
 EXTENSION IF NOT EXISTS pgcrypto;
+CREATE
+EXTENSION IF NOT EXISTS unaccent;
+
+-- french stemming that ignores accents, used by the articles full-text search
+CREATE TEXT SEARCH CONFIGURATION french_unaccent (COPY = french);
+ALTER TEXT SEARCH CONFIGURATION french_unaccent
+    ALTER MAPPING FOR hword, hword_part, word WITH unaccent, french_stem;
 
 
 CREATE TABLE politicians
@@ -65,6 +72,47 @@ CREATE TABLE occupations
 );
 
 
+CREATE TABLE parties
+(
+    id         UUID        NOT NULL DEFAULT gen_random_uuid(),
+    CONSTRAINT pk_parties PRIMARY KEY (id),
+
+    code       TEXT        NOT NULL,
+    CONSTRAINT uq_parties_code UNIQUE (code),
+
+    name       TEXT        NOT NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+
+-- membership of a politician in a party, end_date is NULL while the politician is still a member
+CREATE TABLE politician_affiliations
+(
+    id            UUID        NOT NULL DEFAULT gen_random_uuid(),
+    CONSTRAINT pk_politician_affiliations PRIMARY KEY (id),
+
+    politician_id UUID        NOT NULL,
+    CONSTRAINT fk_politician_affiliations_politician FOREIGN KEY (politician_id) REFERENCES politicians (id),
+
+    party_id      UUID        NOT NULL,
+    CONSTRAINT fk_politician_affiliations_party FOREIGN KEY (party_id) REFERENCES parties (id),
+
+    start_date    TIMESTAMPTZ NOT NULL,
+    end_date      TIMESTAMPTZ,
+    CONSTRAINT ck_politician_affiliations_dates CHECK (end_date IS NULL OR end_date > start_date),
+
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at    TIMESTAMPTZ
+);
+
+CREATE INDEX idx_politician_affiliations_politician ON politician_affiliations (politician_id, start_date);
+CREATE INDEX idx_politician_affiliations_party ON politician_affiliations (party_id);
+
+
 CREATE TABLE roles
 (
     id         UUID        NOT NULL DEFAULT gen_random_uuid(),
@@ -114,23 +162,119 @@ CREATE INDEX idx_user_roles_user ON user_roles (user_id);
 
 CREATE TABLE user_tokens
 (
-    id       UUID        NOT NULL DEFAULT gen_random_uuid(),
+    id           UUID        NOT NULL DEFAULT gen_random_uuid(),
     CONSTRAINT pk_user_tokens PRIMARY KEY (id),
 
-    user_id  UUID        NOT NULL,
+    user_id      UUID        NOT NULL,
     CONSTRAINT fk_user_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
 
-    category TEXT        NOT NULL,
+    category     TEXT        NOT NULL,
     CONSTRAINT ck_user_tokens_category CHECK (category IN ('REFRESH', 'PASSWORD')),
 
-    hash     TEXT        NOT NULL,
+    hash         TEXT        NOT NULL,
     CONSTRAINT uq_user_tokens_hash UNIQUE (hash),
 
-    expiry   TIMESTAMPTZ NOT NULL
+    expiry       TIMESTAMPTZ NOT NULL,
+
+    -- refresh tokens rotated from the same sign-in share a family, consumed ones are kept to detect their replay
+    family_id    UUID,
+    consumed_at  TIMESTAMPTZ,
+
+    -- a family is a session, described to the user by the device it was started from
+    device_label TEXT        NOT NULL DEFAULT '',
+    user_agent   TEXT        NOT NULL DEFAULT '',
+    ip           TEXT        NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT now()
 );
 
 CREATE INDEX IF NOT EXISTS idx_user_tokens_hash_category_expiry ON user_tokens (hash, category, expiry);
 CREATE INDEX IF NOT EXISTS idx_user_tokens_user_category ON user_tokens (user_id, category);
+CREATE INDEX IF NOT EXISTS idx_user_tokens_family ON user_tokens (family_id) WHERE family_id IS NOT NULL;
+
+
+CREATE TABLE user_totps
+(
+    user_id         UUID        NOT NULL,
+    CONSTRAINT pk_user_totps PRIMARY KEY (user_id),
+    CONSTRAINT fk_user_totps_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+
+    -- encrypted with MFA_ENCRYPTION_KEY, pending until confirmed with a first code
+    secret          TEXT        NOT NULL,
+    confirmed_at    TIMESTAMPTZ,
+
+    -- time step of the latest accepted code, older codes can't be replayed
+    last_used_step  BIGINT      NOT NULL DEFAULT 0,
+
+    failed_attempts INT         NOT NULL DEFAULT 0,
+    last_failed_at  TIMESTAMPTZ,
+
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+
+CREATE TABLE user_recovery_codes
+(
+    id         UUID        NOT NULL DEFAULT gen_random_uuid(),
+    CONSTRAINT pk_user_recovery_codes PRIMARY KEY (id),
+
+    user_id    UUID        NOT NULL,
+    CONSTRAINT fk_user_recovery_codes_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+
+    hash       TEXT        NOT NULL,
+    CONSTRAINT uq_user_recovery_codes_hash UNIQUE (hash),
+
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_recovery_codes_user ON user_recovery_codes (user_id);
+
+CREATE TABLE user_passkeys
+(
+    id               UUID        NOT NULL DEFAULT gen_random_uuid(),
+    CONSTRAINT pk_user_passkeys PRIMARY KEY (id),
+
+    user_id          UUID        NOT NULL,
+    CONSTRAINT fk_user_passkeys_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+
+    name             TEXT        NOT NULL DEFAULT '',
+
+    credential_id    BYTEA       NOT NULL,
+    CONSTRAINT uq_user_passkeys_credential_id UNIQUE (credential_id),
+
+    public_key       BYTEA       NOT NULL,
+    attestation_type TEXT        NOT NULL DEFAULT '',
+    transports       TEXT        NOT NULL DEFAULT '',
+    aaguid           BYTEA,
+
+    -- a sign count going backwards reveals a cloned authenticator
+    sign_count       BIGINT      NOT NULL DEFAULT 0,
+    flags            SMALLINT    NOT NULL DEFAULT 0,
+
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at     TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_passkeys_user ON user_passkeys (user_id);
+
+-- state of the passkey ceremonies between their two steps, single use
+CREATE TABLE webauthn_challenges
+(
+    challenge TEXT        NOT NULL,
+    CONSTRAINT pk_webauthn_challenges PRIMARY KEY (challenge),
+
+    ceremony  TEXT        NOT NULL,
+    CONSTRAINT ck_webauthn_challenges_ceremony CHECK (ceremony IN ('REGISTRATION', 'LOGIN')),
+
+    user_id   UUID,
+    CONSTRAINT fk_webauthn_challenges_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+
+    session   TEXT        NOT NULL,
+    expiry    TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webauthn_challenges_expiry ON webauthn_challenges (expiry);
 
 
 CREATE TABLE articles
@@ -144,6 +288,7 @@ CREATE TABLE articles
     moderator_id UUID,
     CONSTRAINT fk_articles_moderator FOREIGN KEY (moderator_id) REFERENCES users (id),
     CONSTRAINT ck_articles_moderator CHECK (moderator_id IS NULL OR moderator_id <> redactor_id),
+    claimed_at   TIMESTAMPTZ,
 
     status       TEXT        NOT NULL,
     CONSTRAINT ck_articles_status CHECK (status IN
@@ -161,24 +306,46 @@ CREATE TABLE articles
     minor        SMALLINT    NOT NULL,
     CONSTRAINT uq_articles_version UNIQUE (reference, major, minor),
 
+    -- public note explaining what changed since the previous published version
+    correction_note TEXT     NOT NULL DEFAULT '',
+    published_at TIMESTAMPTZ,
+
+    -- weighted document of the full-text search, kept up to date by the triggers declared after article_politicians
+    search_vector TSVECTOR   NOT NULL DEFAULT '',
+
     created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     deleted_at   TIMESTAMPTZ
 );
 
-CREATE UNIQUE INDEX uq_articles_reference_not_archived ON articles (reference) WHERE status <> 'ARCHIVED';
+-- a reference has at most one live version and at most one revision in progress
+CREATE UNIQUE INDEX uq_articles_reference_published ON articles (reference) WHERE status = 'PUBLISHED';
+CREATE UNIQUE INDEX uq_articles_reference_in_progress ON articles (reference) WHERE status NOT IN ('ARCHIVED', 'PUBLISHED');
 CREATE INDEX idx_articles_redactor_status_created_at_desc ON articles (redactor_id, status, created_at DESC);
 CREATE INDEX idx_articles_moderator_status_created_at_desc ON articles (moderator_id, status, created_at DESC);
 CREATE INDEX idx_articles_redactor_reference_created_at_desc ON articles (redactor_id, reference, created_at DESC);
+CREATE INDEX idx_articles_published_event_date_id_desc ON articles (event_date DESC, id DESC) WHERE status = 'PUBLISHED';
+CREATE INDEX idx_articles_under_review_claimed_at ON articles (claimed_at) WHERE status = 'UNDER_REVIEW' AND moderator_id IS NOT NULL;
 
 CREATE TABLE article_sources
 (
-    article_id UUID NOT NULL,
+    article_id        UUID NOT NULL,
     CONSTRAINT fk_article_sources_article FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE,
 
-    url        TEXT NOT NULL,
+    url               TEXT NOT NULL,
 
-    CONSTRAINT pk_article_sources PRIMARY KEY (article_id, url)
+    CONSTRAINT pk_article_sources PRIMARY KEY (article_id, url),
+
+    type              TEXT NOT NULL,
+    CONSTRAINT ck_article_sources_type CHECK (type IN ('VIDEO', 'OFFICIAL_TRANSCRIPT', 'PRESS_ARTICLE', 'SOCIAL_POST')),
+    publisher         TEXT NOT NULL DEFAULT '',
+    published_at      TIMESTAMPTZ,
+
+    -- locates the quote in a video
+    timestamp_seconds INTEGER,
+    CONSTRAINT ck_article_sources_timestamp CHECK (timestamp_seconds IS NULL OR
+                                                   (type = 'VIDEO' AND timestamp_seconds >= 0)),
+    quote             TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX idx_article_sources_article ON article_sources (article_id);
@@ -203,10 +370,11 @@ CREATE TABLE article_reviews
 
     article_id   UUID        NOT NULL,
     CONSTRAINT fk_article_reviews_article FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE,
-    CONSTRAINT uq_article_reviews_article UNIQUE (article_id),
 
     moderator_id UUID        NOT NULL,
     CONSTRAINT fk_article_reviews_moderator FOREIGN KEY (moderator_id) REFERENCES users (id),
+    -- each version may be reviewed by several moderators, but at most once by each of them
+    CONSTRAINT uq_article_reviews_article_moderator UNIQUE (article_id, moderator_id),
 
     decision     TEXT        NOT NULL,
     CONSTRAINT ck_article_reviews_decision CHECK (decision IN ('PUBLISHED', 'ARCHIVED', 'CHANGE_REQUESTED')),
@@ -229,12 +397,205 @@ CREATE TABLE article_politicians
     politician_id UUID NOT NULL,
     CONSTRAINT fk_article_politicians_politician FOREIGN KEY (politician_id) REFERENCES politicians (id),
 
+    -- role held by the politician at the article's event date
+    occupation_id UUID,
+    CONSTRAINT fk_article_politicians_occupation FOREIGN KEY (occupation_id) REFERENCES occupations (id),
+
+    office        TEXT NOT NULL DEFAULT '',
+
     CONSTRAINT pk_article_politicians PRIMARY KEY (article_id, politician_id)
 );
 
 CREATE INDEX idx_article_politicians_article ON article_politicians (article_id);
 CREATE INDEX idx_article_politicians_politician ON article_politicians (politician_id);
 
+
+-- Full-text search: title and politician names weigh the most, then tags, then the body.
+-- A generated column can't read the tags and the politicians, so triggers keep articles.search_vector up to date.
+CREATE FUNCTION article_search_vector(article_id UUID, title TEXT, body TEXT) RETURNS TSVECTOR
+    LANGUAGE sql
+    STABLE
+AS
+$$
+SELECT setweight(to_tsvector('french_unaccent', title), 'A') ||
+       setweight(to_tsvector('french_unaccent', coalesce((SELECT string_agg(p.first_name || ' ' || p.last_name, ' ')
+                                                          FROM article_politicians ap
+                                                                   JOIN politicians p ON p.id = ap.politician_id
+                                                          WHERE ap.article_id = article_search_vector.article_id), '')), 'A') ||
+       setweight(to_tsvector('french_unaccent', coalesce((SELECT string_agg(t.tag, ' ')
+                                                          FROM article_tags t
+                                                          WHERE t.article_id = article_search_vector.article_id), '')), 'B') ||
+       setweight(to_tsvector('french_unaccent', body), 'C')
+$$;
+
+CREATE FUNCTION articles_set_search_vector() RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    NEW.search_vector := article_search_vector(NEW.id, NEW.title, NEW.body);
+    RETURN NEW;
+END
+$$;
+
+CREATE TRIGGER trg_articles_search_vector
+    BEFORE INSERT OR UPDATE OF title, body
+    ON articles
+    FOR EACH ROW
+EXECUTE FUNCTION articles_set_search_vector();
+
+-- the tags and politicians of an article are written after the article itself
+CREATE FUNCTION article_links_refresh_search_vector() RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE articles SET search_vector = article_search_vector(id, title, body) WHERE id = OLD.article_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        UPDATE articles SET search_vector = article_search_vector(id, title, body) WHERE id = NEW.article_id;
+    END IF;
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER trg_article_tags_search_vector
+    AFTER INSERT OR UPDATE OR DELETE
+    ON article_tags
+    FOR EACH ROW
+EXECUTE FUNCTION article_links_refresh_search_vector();
+
+CREATE TRIGGER trg_article_politicians_search_vector
+    AFTER INSERT OR UPDATE OR DELETE
+    ON article_politicians
+    FOR EACH ROW
+EXECUTE FUNCTION article_links_refresh_search_vector();
+
+CREATE FUNCTION politicians_refresh_search_vector() RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    UPDATE articles a
+    SET search_vector = article_search_vector(a.id, a.title, a.body)
+    FROM article_politicians ap
+    WHERE ap.article_id = a.id
+      AND ap.politician_id = NEW.id;
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER trg_politicians_search_vector
+    AFTER UPDATE OF first_name, last_name
+    ON politicians
+    FOR EACH ROW
+    WHEN (OLD.first_name IS DISTINCT FROM NEW.first_name OR OLD.last_name IS DISTINCT FROM NEW.last_name)
+EXECUTE FUNCTION politicians_refresh_search_vector();
+
+CREATE INDEX idx_articles_search_vector ON articles USING GIN (search_vector);
+
+CREATE TABLE review_comments
+(
+    id           UUID        NOT NULL DEFAULT gen_random_uuid(),
+    CONSTRAINT pk_review_comments PRIMARY KEY (id),
+
+    -- threads follow the article from one version to the next
+    article_id   UUID        NOT NULL,
+    CONSTRAINT fk_review_comments_article FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE,
+
+    -- replies point to the root comment of their thread
+    parent_id    UUID,
+    CONSTRAINT fk_review_comments_parent FOREIGN KEY (parent_id) REFERENCES review_comments (id) ON DELETE CASCADE,
+
+    author_id    UUID        NOT NULL,
+    CONSTRAINT fk_review_comments_author FOREIGN KEY (author_id) REFERENCES users (id),
+
+    -- commented characters of the article body, cleared when the quote can't be found in a new version
+    anchor_start INTEGER,
+    anchor_end   INTEGER,
+    CONSTRAINT ck_review_comments_anchor CHECK ((anchor_start IS NULL AND anchor_end IS NULL) OR
+                                                (anchor_start >= 0 AND anchor_start < anchor_end)),
+    quote        TEXT        NOT NULL DEFAULT '',
+
+    source_url   TEXT,
+
+    CONSTRAINT ck_review_comments_reply CHECK (parent_id IS NULL OR
+                                               (anchor_start IS NULL AND quote = '' AND source_url IS NULL)),
+
+    text         TEXT        NOT NULL,
+    resolved_at  TIMESTAMPTZ,
+
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at   TIMESTAMPTZ
+);
+
+CREATE INDEX idx_review_comments_article ON review_comments (article_id);
+CREATE INDEX idx_review_comments_parent ON review_comments (parent_id);
+
+CREATE TABLE article_status_events
+(
+    id          UUID        NOT NULL DEFAULT gen_random_uuid(),
+    CONSTRAINT pk_article_status_events PRIMARY KEY (id),
+
+    -- cleared when a draft is replaced by its first submission, the event stays in the history of the reference
+    article_id  UUID,
+    CONSTRAINT fk_article_status_events_article FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE SET NULL,
+    reference   UUID        NOT NULL,
+
+    actor_id    UUID        NOT NULL,
+    CONSTRAINT fk_article_status_events_actor FOREIGN KEY (actor_id) REFERENCES users (id),
+
+    -- NULL when the version is created
+    from_status TEXT,
+    CONSTRAINT ck_article_status_events_from_status CHECK (from_status IN
+                                                          ('DRAFT', 'UNDER_REVIEW', 'CHANGE_REQUESTED', 'PUBLISHED',
+                                                           'ARCHIVED')),
+    to_status   TEXT        NOT NULL,
+    CONSTRAINT ck_article_status_events_to_status CHECK (to_status IN
+                                                        ('DRAFT', 'UNDER_REVIEW', 'CHANGE_REQUESTED', 'PUBLISHED',
+                                                         'ARCHIVED')),
+
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_article_status_events_reference ON article_status_events (reference, created_at);
+
+-- archived copy of a cited source, shared by every article citing the URL
+CREATE TABLE source_snapshots
+(
+    id                UUID        NOT NULL DEFAULT gen_random_uuid(),
+    CONSTRAINT pk_source_snapshots PRIMARY KEY (id),
+
+    url               TEXT        NOT NULL,
+    CONSTRAINT uq_source_snapshots_url UNIQUE (url),
+
+    -- taken by the first successful fetch and kept afterward
+    status_code       INTEGER,
+    final_url         TEXT        NOT NULL DEFAULT '',
+    title             TEXT        NOT NULL DEFAULT '',
+    content_hash      TEXT        NOT NULL DEFAULT '',
+    html              TEXT        NOT NULL DEFAULT '',
+    text              TEXT        NOT NULL DEFAULT '',
+    captured_at       TIMESTAMPTZ,
+    -- until captured, retried with backoff
+    capture_failures  INTEGER     NOT NULL DEFAULT 0,
+    retry_at          TIMESTAMPTZ,
+
+    -- latest link check
+    check_status_code INTEGER,
+    check_error       TEXT        NOT NULL DEFAULT '',
+    broken            BOOLEAN     NOT NULL DEFAULT FALSE,
+    checked_at        TIMESTAMPTZ,
+
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_source_snapshots_checked_at ON source_snapshots (checked_at NULLS FIRST);
+CREATE INDEX idx_source_snapshots_retry_at ON source_snapshots (retry_at) WHERE captured_at IS NULL;
+
 -- TEST DATA
 
 INSERT INTO roles (id, name)
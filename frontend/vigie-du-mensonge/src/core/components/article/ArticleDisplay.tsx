@@ -1,5 +1,6 @@
 import type {Article} from "@/core/models/article.ts";
 import {ArticleCategoryLabels} from "@/core/models/articleCategory.ts";
+import {ArticleSourceTypeLabels} from "@/core/models/articleSource.ts";
 import {fmtDate} from "@/core/utils/fmtDate.ts";
 
 export function ArticleDisplay({article}: { article: Article }) {
@@ -69,14 +70,23 @@ export function ArticleDisplay({article}: { article: Article }) {
                             <h2 className="mb-2 text-sm font-semibold">Sources</h2>
                             <ul className="space-y-2">
                                 {article.sources.map((src, idx) => (
-                                    <li key={`${src}-${idx}`} className="break-words">
+                                    <li key={`${src.url}-${idx}`} className="break-words text-sm">
+                                        <div className="text-xs text-muted-foreground">
+                                            {ArticleSourceTypeLabels[src.type]}
+                                            {src.publisher ? ` · ${src.publisher}` : null}
+                                            {src.publishedAt ? ` · ${fmtDate(src.publishedAt)}` : null}
+                                            {src.timestampLabel ? ` · à ${src.timestampLabel}` : null}
+                                        </div>
+                                        {src.quote ? (
+                                            <blockquote className="my-1 border-l-2 pl-2 italic">« {src.quote} »</blockquote>
+                                        ) : null}
                                         <a
-                                            href={src}
+                                            href={src.url}
                                             target="_blank"
                                             rel="noopener noreferrer"
                                             className="hover:underline"
                                         >
-                                            {src}
+                                            {src.url}
                                         </a>
                                     </li>
                                 ))}
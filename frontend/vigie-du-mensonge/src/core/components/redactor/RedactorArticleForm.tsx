@@ -13,6 +13,13 @@ import {RedactorClient, type SaveRedactorArticle} from "@/core/dependencies/reda
 import type {Article} from "@/core/models/article.ts";
 import {DatePicker} from "@/core/components/misc/DatePicker.tsx";
 import type {Politician} from "@/core/models/politician.ts";
+import {
+    type ArticleSourceType,
+    ArticleSourceTypeLabels,
+    ArticleSourceTypes,
+    fmtTimestamp,
+    parseTimestamp
+} from "@/core/models/articleSource.ts";
 
 export type RedactorArticleFormProps = {
     redactorClient: RedactorClient
@@ -21,13 +28,22 @@ export type RedactorArticleFormProps = {
     onSubmitSuccess?: (articleRef?: string) => void
 }
 
+const sourceSchema = z.object({
+    url: z.url("URL invalide").max(2048, "2048 caractères maximum"),
+    type: z.enum(Object.values(ArticleSourceTypes)),
+    publisher: z.string().trim().max(100, "100 caractères maximum"),
+    publishedAt: z.date().optional(),
+    timestampSeconds: z.number().int().min(0).optional(),
+    quote: z.string().trim().max(1000, "1000 caractères maximum"),
+});
+
 const publishSchema = z.object({
     title: z.string().trim().min(1, "Titre requis").max(200, "100 caractères maximum").min(20, "20 caractères minimum"),
     eventDate: z.date().nonoptional("Date de l'évènement requise"),
     category: z.enum(Object.values(ArticleCategories)).nonoptional(),
     body: z.string().trim().min(1, "Contenu requis").max(2000, "2000 caractères maximum").min(200, "200 caractères minimum"),
     tags: z.array(z.string().min(1).max(25)).min(1, "Au moins 1 tag").max(5, "5 tags maximum"),
-    sources: z.array(z.url("URL invalide")).min(1, "Au moins 1 source").max(5, "5 sources maximum"),
+    sources: z.array(sourceSchema).min(1, "Au moins 1 source").max(5, "5 sources maximum"),
     politicians: z.array(z.string()).max(5, "5 politiciens maximum"),
 });
 
@@ -37,7 +53,7 @@ const draftSchema = z.object({
     category: z.enum(Object.values(ArticleCategories)).nonoptional(),
     body: z.string().trim().max(2000, "2000 caractères maximum"),
     tags: z.array(z.string().min(1).max(25)).max(5, "5 tags maximum"),
-    sources: z.array(z.url("URL invalide")).max(5, "5 sources maximum"),
+    sources: z.array(sourceSchema).max(5, "5 sources maximum"),
     politicians: z.array(z.string()).max(5, "5 politiciens maximum"),
 });
 
@@ -48,6 +64,8 @@ const formSchema = z.discriminatedUnion("mode", [
 
 export type RedactorArticleFormInput = z.infer<typeof formSchema>;
 
+type SourceInput = z.infer<typeof sourceSchema>;
+
 function mapInput(input: RedactorArticleFormInput, articleId?: string): SaveRedactorArticle {
     return {
         id: articleId,
@@ -72,7 +90,14 @@ export function RedactorArticleForm({redactorClient, politicians, article, onSub
             body: article?.body ?? "",
             category: article?.category ?? ArticleCategories.FALSEHOOD,
             tags: article?.tags ?? [],
-            sources: article?.sources ?? [],
+            sources: article?.sources?.map(src => ({
+                url: src.url,
+                type: src.type,
+                publisher: src.publisher ?? "",
+                publishedAt: src.publishedAt,
+                timestampSeconds: src.timestampSeconds,
+                quote: src.quote ?? "",
+            })) ?? [],
             politicians: article?.politicianIds ?? [],
         },
         mode: "onSubmit",
@@ -127,6 +152,7 @@ export function RedactorArticleForm({redactorClient, politicians, article, onSub
     // Simple add input for tags and sources
     const [tagInput, setTagInput] = React.useState("");
     const [sourceInput, setSourceInput] = React.useState("");
+    const [sourceTypeInput, setSourceTypeInput] = React.useState<ArticleSourceType>(ArticleSourceTypes.PRESS_ARTICLE);
 
     function addTag() {
         const v = tagInput.trim();
@@ -155,15 +181,21 @@ export function RedactorArticleForm({redactorClient, politicians, article, onSub
 
         const sources = form.getValues("sources");
         if (sources.length >= 5) return;
-        if (!sources.includes(v)) {
-            form.setValue("sources", [...sources, v], {shouldValidate: true});
+        if (!sources.some(s => s.url === v)) {
+            form.setValue("sources", [...sources, {url: v, type: sourceTypeInput, publisher: "", quote: ""}],
+                {shouldValidate: true});
             setSourceInput("");
         }
     }
 
+    function updateSource(url: string, patch: Partial<SourceInput>) {
+        const sources = form.getValues("sources");
+        form.setValue("sources", sources.map(s => s.url === url ? {...s, ...patch} : s), {shouldValidate: true});
+    }
+
     function removeSource(u: string) {
         const sources = form.getValues("sources");
-        form.setValue("sources", sources.filter(s => s !== u), {shouldValidate: true});
+        form.setValue("sources", sources.filter(s => s.url !== u), {shouldValidate: true});
     }
 
     async function onSubmit(values: RedactorArticleFormInput) {
@@ -375,8 +407,19 @@ export function RedactorArticleForm({redactorClient, politicians, article, onSub
                         name="sources"
                         render={() => (
                             <FormItem>
-                                <FormLabel>Sources (URL)</FormLabel>
+                                <FormLabel>Sources</FormLabel>
                                 <div className="flex gap-2">
+                                    <Select value={sourceTypeInput}
+                                            onValueChange={(selected) => setSourceTypeInput(selected as ArticleSourceType)}>
+                                        <SelectTrigger className="w-56">
+                                            <SelectValue/>
+                                        </SelectTrigger>
+                                        <SelectContent>
+                                            {Object.entries(ArticleSourceTypeLabels).map(([value, label]) => (
+                                                <SelectItem key={value} value={value}>{label}</SelectItem>
+                                            ))}
+                                        </SelectContent>
+                                    </Select>
                                     <Input
                                         placeholder="https://exemple.com/article"
                                         value={sourceInput}
@@ -391,16 +434,44 @@ export function RedactorArticleForm({redactorClient, politicians, article, onSub
                                     <Button type="button" onClick={addSource} variant="secondary">Ajouter</Button>
                                 </div>
                                 <div className="flex flex-col gap-2 pt-2">
-                                    {form.getValues("sources").map(src => (
-                                        <div key={src}
-                                             className="flex items-center justify-between rounded-md border px-2 py-1 text-xs">
-                                            <a href={src} target="_blank" rel="noreferrer"
-                                               className="truncate max-w-40 sm:max-w-100 underline">
-                                                {src}
-                                            </a>
-                                            <button type="button"
-                                                    className="text-muted-foreground hover:text-foreground"
-                                                    onClick={() => removeSource(src)}>&times;</button>
+                                    {form.watch("sources").map(src => (
+                                        <div key={src.url} className="flex flex-col gap-2 rounded-md border px-2 py-2 text-xs">
+                                            <div className="flex items-center justify-between gap-2">
+                                                <span className="font-semibold">{ArticleSourceTypeLabels[src.type]}</span>
+                                                <a href={src.url} target="_blank" rel="noreferrer"
+                                                   className="truncate max-w-40 sm:max-w-100 underline">
+                                                    {src.url}
+                                                </a>
+                                                <button type="button"
+                                                        className="text-muted-foreground hover:text-foreground"
+                                                        onClick={() => removeSource(src.url)}>&times;</button>
+                                            </div>
+                                            <div className="flex flex-wrap gap-2">
+                                                <Input
+                                                    className="h-8 flex-1 text-xs"
+                                                    placeholder="Média, institution ou compte"
+                                                    maxLength={100}
+                                                    value={src.publisher}
+                                                    onChange={e => updateSource(src.url, {publisher: e.target.value})}
+                                                />
+                                                <DatePicker date={src.publishedAt}
+                                                            setDate={date => updateSource(src.url, {publishedAt: date})}/>
+                                                {src.type === ArticleSourceTypes.VIDEO ? (
+                                                    <Input
+                                                        className="h-8 w-24 text-xs"
+                                                        placeholder="mm:ss"
+                                                        defaultValue={src.timestampSeconds !== undefined ? fmtTimestamp(src.timestampSeconds) : ""}
+                                                        onBlur={e => updateSource(src.url, {timestampSeconds: parseTimestamp(e.target.value)})}
+                                                    />
+                                                ) : null}
+                                            </div>
+                                            <textarea
+                                                className="border-input w-full min-h-12 rounded-md border bg-transparent px-2 py-1 text-xs"
+                                                placeholder="Citation exacte des propos"
+                                                maxLength={1000}
+                                                value={src.quote}
+                                                onChange={e => updateSource(src.url, {quote: e.target.value})}
+                                            />
                                         </div>
                                     ))}
                                 </div>
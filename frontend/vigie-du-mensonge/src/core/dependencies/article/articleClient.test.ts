@@ -0,0 +1,38 @@
+import {describe, expect, it} from 'vitest';
+import {http, HttpResponse} from 'msw';
+import {server} from '@/test/testServer';
+import {articleClient} from '@/core/dependencies/article/articleClient.ts';
+
+const articleJson = (id: string) => ({
+    id,
+    reference: id,
+    title: `Article ${id}`,
+    category: 'LIE',
+    status: 'PUBLISHED',
+    eventDate: '2024-06-10T00:00:00Z',
+    updatedAt: '2024-06-10T00:00:00Z',
+});
+
+describe('ArticleClient', () => {
+    it('getAll: follows nextCursor until the last page', async () => {
+        server.resetHandlers();
+        server.use(http.get('http://localhost:8080/api/v1/articles', ({request}) => {
+            const cursor = new URL(request.url).searchParams.get('cursor');
+            if (cursor === null) {
+                return HttpResponse.json({articles: [articleJson('a1'), articleJson('a2')], nextCursor: 'c1'});
+            }
+            expect(cursor).toBe('c1');
+            return HttpResponse.json({articles: [articleJson('a3')]});
+        }));
+
+        const {queryFn, initialPageParam, getNextPageParam} = articleClient.getAll();
+
+        const first = await queryFn({pageParam: initialPageParam});
+        expect(first.articles.map((a) => a.id)).toEqual(['a1', 'a2']);
+        expect(getNextPageParam(first)).toBe('c1');
+
+        const last = await queryFn({pageParam: getNextPageParam(first)});
+        expect(last.articles.map((a) => a.id)).toEqual(['a3']);
+        expect(getNextPageParam(last)).toBeUndefined();
+    });
+});
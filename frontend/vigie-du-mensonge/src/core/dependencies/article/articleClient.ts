@@ -2,6 +2,11 @@ import {Article, type ArticleJson} from "@/core/models/article.ts";
 import type {KyInstance} from "ky";
 import {api} from "@/core/dependencies/api.ts";
 
+export type ArticlePage = {
+    articles: Article[];
+    nextCursor?: string;
+};
+
 export class ArticleClient {
     private readonly api: KyInstance;
 
@@ -9,18 +14,29 @@ export class ArticleClient {
         this.api = api;
     }
 
-    private async _getAll(): Promise<Article[]> {
+    private async _getPage(cursor?: string): Promise<ArticlePage> {
         const res = await this.api
-            .get("articles")
-            .json<ArticleJson[]>();
+            .get("articles", {searchParams: cursor ? {cursor} : undefined})
+            .json<{ articles: ArticleJson[], nextCursor?: string }>();
 
-        return res.map((json) => Article.fromJson(json));
+        return {
+            articles: res.articles.map((json) => Article.fromJson(json)),
+            nextCursor: res.nextCursor,
+        };
     }
 
-    getAll = (): { queryKey: string[], queryFn: () => Promise<Article[]> } => {
+    // pages through the published articles, nextCursor being absent from the last page
+    getAll = (): {
+        queryKey: string[],
+        queryFn: (ctx: { pageParam: string | undefined }) => Promise<ArticlePage>,
+        initialPageParam: string | undefined,
+        getNextPageParam: (lastPage: ArticlePage) => string | undefined,
+    } => {
         return {
             queryKey: ["articles"],
-            queryFn: () => this._getAll(),
+            queryFn: ({pageParam}) => this._getPage(pageParam),
+            initialPageParam: undefined,
+            getNextPageParam: (lastPage) => lastPage.nextCursor,
         };
     };
 
@@ -1,5 +1,15 @@
 import type {KyInstance} from "ky";
 import {Article, type ArticleJson} from "@/core/models/article.ts";
+import type {ArticleSourceType} from "@/core/models/articleSource.ts";
+
+export type SaveRedactorArticleSource = {
+    url: string;
+    type: ArticleSourceType;
+    publisher?: string;
+    publishedAt?: Date;
+    timestampSeconds?: number;
+    quote?: string;
+}
 
 export type SaveRedactorArticle = {
     id?: string;
@@ -7,7 +17,7 @@ export type SaveRedactorArticle = {
     body: string;
     eventDate: Date;
     tags: string[];
-    sources: string[];
+    sources: SaveRedactorArticleSource[];
     politicianIds: string[];
     category: string;
 }
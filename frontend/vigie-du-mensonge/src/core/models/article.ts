@@ -2,6 +2,7 @@ import {Politician, type PoliticianJson} from "@/core/models/politician.ts";
 import {type ArticleCategory} from "@/core/models/articleCategory.ts";
 import {type ArticleStatus, ArticleStatuses} from "@/core/models/articleStatus.ts";
 import {ArticleReview, type ArticleReviewJson} from "@/core/models/articleReview.ts";
+import {ArticleSource, type ArticleSourceJson} from "@/core/models/articleSource.ts";
 
 export type ArticleJson = {
     id: string;
@@ -23,7 +24,7 @@ export type ArticleJson = {
     minor?: number;
     major?: number;
 
-    sources?: string[];
+    sources?: ArticleSourceJson[];
     tags?: string[];
     politicians?: PoliticianJson[];
 }
@@ -49,7 +50,7 @@ export class Article {
     public major?: number;
 
     public tags?: string[];
-    public sources?: string[];
+    public sources?: ArticleSource[];
     public politicians?: Politician[];
 
     constructor(
@@ -67,7 +68,7 @@ export class Article {
         minor: number | undefined,
         major: number | undefined,
         tags: string[] | undefined,
-        sources: string[] | undefined,
+        sources: ArticleSource[] | undefined,
         politicians: Politician[] | undefined,
     ) {
         this.id = id;
@@ -135,7 +136,7 @@ export class Article {
             json.minor,
             json.major,
             json.tags,
-            json.sources,
+            json.sources?.map(ArticleSource.fromJson),
             json.politicians?.map(Politician.fromJson),
         );
     }
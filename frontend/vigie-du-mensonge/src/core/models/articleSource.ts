@@ -0,0 +1,74 @@
+export const ArticleSourceTypes = {
+    VIDEO: 'VIDEO',
+    OFFICIAL_TRANSCRIPT: 'OFFICIAL_TRANSCRIPT',
+    PRESS_ARTICLE: 'PRESS_ARTICLE',
+    SOCIAL_POST: 'SOCIAL_POST',
+} as const;
+
+export type ArticleSourceType = keyof typeof ArticleSourceTypes;
+
+export const ArticleSourceTypeLabels: Record<ArticleSourceType, string> = {
+    VIDEO: 'Vidéo',
+    OFFICIAL_TRANSCRIPT: 'Compte rendu officiel',
+    PRESS_ARTICLE: 'Article de presse',
+    SOCIAL_POST: 'Réseau social',
+};
+
+export type ArticleSourceJson = {
+    url: string;
+    type: ArticleSourceType;
+    publisher?: string;
+    publishedAt?: string;
+    timestampSeconds?: number;
+    quote?: string;
+}
+
+export class ArticleSource {
+    public url: string;
+    public type: ArticleSourceType;
+    public publisher?: string;
+    public publishedAt?: Date;
+    public timestampSeconds?: number;
+    public quote?: string;
+
+    constructor(url: string, type: ArticleSourceType, publisher?: string, publishedAt?: Date,
+                timestampSeconds?: number, quote?: string) {
+        this.url = url;
+        this.type = type;
+        this.publisher = publisher;
+        this.publishedAt = publishedAt;
+        this.timestampSeconds = timestampSeconds;
+        this.quote = quote;
+    }
+
+    public get timestampLabel(): string | undefined {
+        return this.timestampSeconds === undefined ? undefined : fmtTimestamp(this.timestampSeconds);
+    }
+
+    static fromJson(json: ArticleSourceJson): ArticleSource {
+        return new ArticleSource(
+            json.url,
+            json.type,
+            json.publisher,
+            json.publishedAt ? new Date(json.publishedAt) : undefined,
+            json.timestampSeconds,
+            json.quote,
+        );
+    }
+}
+
+// fmtTimestamp formats a position in a video, e.g. 754 => "12:34"
+export function fmtTimestamp(seconds: number): string {
+    const h = Math.floor(seconds / 3600);
+    const m = Math.floor((seconds % 3600) / 60);
+    const s = seconds % 60;
+    const mmss = `${String(m).padStart(h > 0 ? 2 : 1, "0")}:${String(s).padStart(2, "0")}`;
+    return h > 0 ? `${h}:${mmss}` : mmss;
+}
+
+// parseTimestamp parses "12:34" or "1:02:03" into seconds, undefined if the input is invalid
+export function parseTimestamp(input: string): number | undefined {
+    const parts = input.trim().split(":");
+    if (parts.length < 1 || parts.length > 3 || parts.some(p => !/^\d+$/.test(p))) return undefined;
+    return parts.reduce((acc, p) => acc * 60 + Number(p), 0);
+}
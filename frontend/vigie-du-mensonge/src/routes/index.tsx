@@ -1,9 +1,10 @@
 import {createFileRoute} from '@tanstack/react-router';
-import {useQuery} from "@tanstack/react-query";
+import {useInfiniteQuery} from "@tanstack/react-query";
 import {Spinner} from "@/core/shadcn/components/ui/spinner.tsx";
 import {Link} from "@/core/utils/router.ts";
 import {ArticleOverviewItem} from "@/core/components/article/ArticleOverviewItem.tsx";
 import {Separator} from "@/core/shadcn/components/ui/separator.tsx";
+import {Button} from "@/core/shadcn/components/ui/button.tsx";
 
 export const Route = createFileRoute('/')({
     component: RouteComponent,
@@ -12,14 +13,13 @@ export const Route = createFileRoute('/')({
 function RouteComponent() {
     const articleClient = Route.useRouteContext().articleClient;
 
-    const {queryKey, queryFn} = articleClient.getAll();
-
-    const {data: articles, isLoading, isError} = useQuery({
-        queryKey: queryKey,
-        queryFn: () => queryFn(),
+    const {data, isLoading, isError, hasNextPage, fetchNextPage, isFetchingNextPage} = useInfiniteQuery({
+        ...articleClient.getAll(),
         staleTime: 24 * 60 * 60 * 1000,
     });
 
+    const articles = data?.pages.flatMap((page) => page.articles);
+
     if (isError) {
         return (
             <div className="flex items-center justify-center h-screen">
@@ -67,5 +67,13 @@ function RouteComponent() {
             </div>
         }
 
+        {
+            hasNextPage &&
+
+            <Button variant="outline" onClick={() => fetchNextPage()} disabled={isFetchingNextPage}>
+                {isFetchingNextPage ? <Spinner/> : "Voir plus d'articles"}
+            </Button>
+        }
+
     </div>;
 }
\ No newline at end of file